@@ -1,9 +1,14 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,10 +18,18 @@ type ServerConfig struct {
 	Host       string
 	Port       string
 	SessionKey string
+
+	// true면 PUT/PATCH/DELETE 요청에 If-Match 헤더가 없을 때 428을 반환
+	RequireIfMatch bool
+	// X-Forwarded-For를 믿을 프록시 주소 / CIDR 목록, 비어있으면 연결한 주소를 클라이언트 IP로 사용
+	TrustedProxies []string
 }
 
 // 데이버이스의 정보를 구성하는 구조버 (postgresql
+// DRIVER가 sqlite 또는 memory면 DB_* 접속 정보 대신 SQLite를 사용
 type PostgresConfig struct {
+	DRIVER      string // "postgres", "sqlite", "memory"
+	SQLITE_PATH string
 	DB_HOST     string
 	DB_USER     string
 	DB_PASSWORD string
@@ -24,6 +37,11 @@ type PostgresConfig struct {
 	DB_PORT     string
 	SSL_MODE    string
 	TIMEZONE    string
+
+	// 요청 하나에서 실행되는 DB 작업의 최대 시간 (0이면 제한 없음)
+	QUERY_TIMEOUT time.Duration
+	// 서버 시작 시 마이그레이션을 자동으로 적용할지 여부
+	AUTO_MIGRATE bool
 }
 
 // Redis의 정보를 구성하는 구조체
@@ -31,17 +49,156 @@ type RedisConfig struct {
 	Address  string
 	Password string
 	AuthDB   string
+
+	// 인증 세션 저장소 ("redis", "memory"), memory면 Redis 없이 실행
+	SessionStore string
+}
+
+// 인증 정책을 구성하는 구조체
+type AuthConfig struct {
+	// true면 비밀번호를 등록하지 않은 기존 사용자가 이름만으로 로그인할 수 있음 (계정 등록 유예 기간용, 기본값 false)
+	// 이름만 알면 누구나 로그인할 수 있으므로 켜는 동안에는 기존 사용자의 데이터가 노출 됨
+	LegacyNameLogin bool
+	// 비밀번호 재설정 토큰의 유효 시간
+	PasswordResetTTL time.Duration
+	// 관리자가 발급하는 계정 등록(claim) 토큰의 유효 시간
+	ClaimTokenTTL time.Duration
+	// 이메일 인증 토큰의 유효 시간
+	EmailVerificationTTL time.Duration
+	// access token(JWT)의 유효 시간
+	AccessTokenTTL time.Duration
+	// refresh token의 유효 시간, 갱신할 때마다 다시 늘어나므로 이 시간 동안 사용하지 않으면 로그아웃 됨
+	RefreshTokenTTL time.Duration
+	// 직전 refresh token을 재사용으로 보지 않는 시간, 여러 탭이 같은 토큰으로 동시에 갱신해도 로그아웃 되지 않게 함
+	RefreshTokenGracePeriod time.Duration
+	// access token(JWT) 서명 알고리즘 ("HS256", "RS256", "EdDSA"), HS256은 JWT_SECRET으로 서명
+	JWTAlgorithm string
+	// RS256 / EdDSA 서명에 사용할 PEM 개인 키 파일
+	JWTSigningKeyFile string
+	// 교체 전 키처럼 서명에는 쓰지 않지만 검증에 사용할 PEM 공개 키(또는 개인 키) 파일 목록
+	JWTVerificationKeyFiles []string
+	// JWT의 iss 클레임, 다른 서비스가 lux-list 토큰인지 확인할 때 사용
+	JWTIssuer string
+
+	// 인증 앱에 표시 될 2단계 인증 발급자 이름
+	TOTPIssuer string
+	// 저장하는 TOTP secret의 암호화 키, 기본값이 없으며 JWT_SECRET과 달라야 함 (설정하지 않으면 서버가 시작되지 않음)
+	TOTPEncryptionKey string
+	// 교체 전 TOTP 암호화 키 목록, 복호화에만 사용하며 로그인할 때 현재 키로 다시 암호화함
+	TOTPPreviousEncryptionKeys []string
+
+	// 관리자 대리 로그인 세션의 유효 시간, 갱신해도 늘어나지 않음
+	ImpersonationTTL time.Duration
+}
+
+// OpenID Connect 로그인(외부 ID 공급자)을 구성하는 구조체
+type OIDCConfig struct {
+	Enabled bool
+	// ID 공급자의 issuer URL, {Issuer}/.well-known/openid-configuration에서 설정을 가져옴
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// ID 공급자에 등록한 콜백 주소 (예: http://localhost:5000/api/v1/auth/oidc/callback)
+	RedirectURL string
+	Scopes      []string
+
+	// true면 연결 된 계정이 없는 사용자를 새로 가입시킴
+	AllowSignup bool
+	// true면 ID 공급자가 인증한(email_verified) 이메일과 같은 이메일을 인증한 기존 사용자에 연결함 (기본값 false)
+	// ID 공급자와 lux-list 양쪽에서 이메일 소유를 확인한 경우에만 연결하며, 끄면 기존 사용자는 새 계정으로 가입 됨
+	LinkByEmail bool
+	// 로그인을 마친 뒤 이동할 주소
+	PostLoginRedirect string
+}
+
+// CORS를 구성하는 구조체
+type CORSConfig struct {
+	// 쿠키를 포함한 요청을 허용할 Origin 목록, "*"이면 모든 Origin 허용 (개발용)
+	AllowedOrigins []string
+}
+
+// 쿠키 세션 요청의 CSRF 보호를 구성하는 구조체
+type CSRFConfig struct {
+	Enabled bool
+	// true면 쿠키 없이 Authorization: Bearer 헤더로 인증하는 요청(개인 액세스 토큰)은 CSRF 토큰 없이 허용
+	ExemptBearer bool
+	// CSRF 검사를 건너뛸 경로 접두사 목록
+	ExemptPaths []string
+}
+
+// 데이터 내보내기와 계정 삭제를 구성하는 구조체
+type AccountConfig struct {
+	// 계정 삭제를 요청한 뒤 실제로 삭제하기까지의 유예 기간, 이 기간 안에는 로그인해서 취소할 수 있음
+	DeletionGracePeriod time.Duration
+	// 만든 내보내기 파일(ZIP)을 내려받을 수 있는 시간
+	ExportTTL time.Duration
+	// 내보내기 작업 하나의 제한 시간, 넘기거나 서버가 종료 되어 끝나지 않은 작업은 실패로 처리
+	ExportTimeout time.Duration
+	// 삭제 유예 기간이 지난 계정과 만료 된 내보내기 파일을 정리하는 주기
+	MaintenanceInterval time.Duration
+}
+
+// 작업 공간 초대를 구성하는 구조체
+type WorkspaceConfig struct {
+	// 초대(초대 링크)의 유효 시간
+	InvitationTTL time.Duration
+	// 초대 링크 서명 키, 기본값이 없으며 JWT_SECRET과 달라야 함
+	InvitationSigningKey string
+	// 초대 링크 주소, 서명 된 토큰을 token 쿼리로 붙임 (클라이언트가 토큰으로 수락 / 거절 API를 호출)
+	InvitationURL string
+}
+
+// 요청 제한 규칙, Window 동안 Limit 번까지 허용 (Limit이 0이면 제한 없음)
+type RateLimitRule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// 요청 제한(rate limiting)을 구성하는 구조체
+type RateLimitConfig struct {
+	Enabled bool
+	// 요청 기록 저장소 ("redis", "memory"), 기본값은 SESSION_STORE와 같음
+	Store string
+	// 라우트 그룹별 규칙 (RATE_LIMIT_<GROUP>=<limit>/<window>, 예: RATE_LIMIT_LOGIN=10/15m)
+	Rules map[string]RateLimitRule
+}
+
+// 라우트 그룹별 기본 요청 제한 규칙
+var defaultRateLimitRules = map[string]string{
+	"api":           "1200/1m", // IP별 전체 API
+	"auth":          "60/1m",   // IP별 /auth
+	"login":         "10/15m",  // IP별 로그인 시도
+	"login_account": "20/1h",   // 계정별 로그인 실패
+	"tasks":         "300/1m",  // 사용자별
+	"tags":          "300/1m",  // 사용자별
+	"sync":          "120/1m",  // 사용자별
+	"tokens":        "30/1m",   // 사용자별
+	"workspaces":    "60/1m",   // 사용자별
 }
 
 // 프로그램의 환경변수 설정을 포함하는 구조체
 type Config struct {
-	Server   ServerConfig
-	Database PostgresConfig
-	Redis    RedisConfig
+	Server    ServerConfig
+	Database  PostgresConfig
+	Redis     RedisConfig
+	Auth      AuthConfig
+	OIDC      OIDCConfig
+	RateLimit RateLimitConfig
+	CORS      CORSConfig
+	CSRF      CSRFConfig
+	Account   AccountConfig
+	Workspace WorkspaceConfig
 
+	// HS256 access token 서명 키, 기본값이 없으며 HS256이면 32자 이상이어야 함
 	JWTSecret string
 }
 
+// 서명 / 암호화 키의 최소 길이
+const SECRET_KEY_MIN_LENGTH = 32
+
+// 이전 버전의 JWT_SECRET 기본값, 그대로 설정한 서버는 토큰이 위조될 수 있으므로 시작하지 않음
+const legacyDefaultJWTSecret = "jwt_secret"
+
 // Config 구조체의 인스턴스를 저장하기 위한 변수와 동기화 객체
 var (
 	config_instance *Config
@@ -60,8 +217,13 @@ func loadConfig() *Config {
 			Host:       getEnv("SERVER_HOST", "localhost"),
 			Port:       getEnv("SERVER_PORT", "5000"),
 			SessionKey: getEnv("SESSION_KEY", "session-key"),
+
+			RequireIfMatch: getEnvBool("REQUIRE_IF_MATCH", false),
+			TrustedProxies: getEnvList("TRUSTED_PROXIES", nil),
 		},
 		Database: PostgresConfig{
+			DRIVER:      getEnv("DB_DRIVER", "postgres"),
+			SQLITE_PATH: getEnv("SQLITE_PATH", "lux-list.db"),
 			DB_HOST:     getEnv("DB_HOST", "localhost"),
 			DB_USER:     getEnv("DB_USER", "postgres"),
 			DB_PASSWORD: getEnv("DB_PASSWORD", "postgres"),
@@ -69,13 +231,73 @@ func loadConfig() *Config {
 			DB_PORT:     getEnv("DB_PORT", "5432"),
 			SSL_MODE:    getEnv("SSL_MODE", "disable"),
 			TIMEZONE:    getEnv("TIMEZONE", "Asia/Seoul"),
+
+			QUERY_TIMEOUT: getEnvDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+			AUTO_MIGRATE:  getEnvBool("DB_AUTO_MIGRATE", true),
 		},
 		Redis: RedisConfig{
 			Address:  getEnv("REDIS_ADDRESS", "localhost:6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
 			AuthDB:   getEnv("REDIS_AUTH_DB", "0"),
+
+			SessionStore: getEnv("SESSION_STORE", "redis"),
+		},
+		Auth: AuthConfig{
+			LegacyNameLogin:         getEnvBool("AUTH_LEGACY_NAME_LOGIN", false),
+			PasswordResetTTL:        getEnvDuration("PASSWORD_RESET_TTL", 30*time.Minute),
+			ClaimTokenTTL:           getEnvDuration("CLAIM_TOKEN_TTL", 72*time.Hour),
+			EmailVerificationTTL:    getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+			AccessTokenTTL:          getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:         getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			RefreshTokenGracePeriod: getEnvDuration("REFRESH_TOKEN_GRACE_PERIOD", 10*time.Second),
+			ImpersonationTTL:        getEnvDuration("ADMIN_IMPERSONATION_TTL", time.Hour),
+
+			JWTAlgorithm:            getEnv("JWT_ALGORITHM", "HS256"),
+			JWTSigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+			JWTVerificationKeyFiles: getEnvList("JWT_VERIFICATION_KEY_FILES", nil),
+			JWTIssuer:               getEnv("JWT_ISSUER", "lux-list"),
+
+			TOTPIssuer:                 getEnv("TOTP_ISSUER", "Lux List"),
+			TOTPEncryptionKey:          getEnv("TOTP_ENCRYPTION_KEY", ""),
+			TOTPPreviousEncryptionKeys: getEnvList("TOTP_PREVIOUS_ENCRYPTION_KEYS", nil),
+		},
+		OIDC: OIDCConfig{
+			Enabled:      getEnvBool("OIDC_ENABLED", false),
+			Issuer:       getEnv("OIDC_ISSUER", ""),
+			ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+			Scopes:       getEnvList("OIDC_SCOPES", []string{"openid", "email", "profile"}),
+
+			AllowSignup:       getEnvBool("OIDC_ALLOW_SIGNUP", true),
+			LinkByEmail:       getEnvBool("OIDC_LINK_BY_EMAIL", false),
+			PostLoginRedirect: getEnv("OIDC_POST_LOGIN_REDIRECT", "/"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+			Store:   getEnv("RATE_LIMIT_STORE", getEnv("SESSION_STORE", "redis")),
+			Rules:   loadRateLimitRules(),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
+		},
+		CSRF: CSRFConfig{
+			Enabled:      getEnvBool("CSRF_ENABLED", true),
+			ExemptBearer: getEnvBool("CSRF_EXEMPT_BEARER", true),
+			ExemptPaths:  getEnvList("CSRF_EXEMPT_PATHS", nil),
+		},
+		Account: AccountConfig{
+			DeletionGracePeriod: getEnvDuration("ACCOUNT_DELETION_GRACE_PERIOD", 14*24*time.Hour),
+			ExportTTL:           getEnvDuration("ACCOUNT_EXPORT_TTL", 24*time.Hour),
+			ExportTimeout:       getEnvDuration("ACCOUNT_EXPORT_TIMEOUT", 5*time.Minute),
+			MaintenanceInterval: getEnvDuration("ACCOUNT_MAINTENANCE_INTERVAL", 10*time.Minute),
+		},
+		Workspace: WorkspaceConfig{
+			InvitationTTL:        getEnvDuration("WORKSPACE_INVITATION_TTL", 7*24*time.Hour),
+			InvitationSigningKey: getEnv("WORKSPACE_INVITATION_SIGNING_KEY", ""),
+			InvitationURL:        getEnv("WORKSPACE_INVITATION_URL", "http://localhost:3000/invitations"),
 		},
-		JWTSecret: getEnv("JWT_SECRET", "jwt_secret"),
+		JWTSecret: getEnv("JWT_SECRET", ""),
 	}
 }
 
@@ -98,3 +320,105 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// 환경변수 값을 bool로 가져오는 함수
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// 환경변수 값을 time.Duration으로 가져오는 함수 (예: "5s", "500ms")
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// 환경변수 값을 쉼표로 구분 된 목록으로 가져오는 함수 (빈 항목은 제외)
+func getEnvList(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// loadRateLimitRules는 라우트 그룹별 요청 제한 규칙을 환경변수(RATE_LIMIT_<GROUP>)에서 읽는 함수
+// 형식이 잘못 된 값은 기본값을 사용함
+func loadRateLimitRules() map[string]RateLimitRule {
+	rules := make(map[string]RateLimitRule, len(defaultRateLimitRules))
+	for group, defaultValue := range defaultRateLimitRules {
+		rule, err := ParseRateLimitRule(getEnv("RATE_LIMIT_"+strings.ToUpper(group), defaultValue))
+		if err != nil {
+			log.Printf("Invalid RATE_LIMIT_%s: %v, using %s", strings.ToUpper(group), err, defaultValue)
+			rule, _ = ParseRateLimitRule(defaultValue)
+		}
+		rules[group] = rule
+	}
+	return rules
+}
+
+// ParseRateLimitRule은 "<limit>/<window>" 형식(예: "10/15m")의 요청 제한 규칙을 읽는 함수
+func ParseRateLimitRule(value string) (RateLimitRule, error) {
+	limitValue, windowValue, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return RateLimitRule{}, errors.New("expected <limit>/<window>")
+	}
+	limit, err := strconv.Atoi(limitValue)
+	if err != nil || limit < 0 {
+		return RateLimitRule{}, errors.New("limit must be a non-negative number")
+	}
+	window, err := time.ParseDuration(windowValue)
+	if err != nil || window <= 0 {
+		return RateLimitRule{}, errors.New("window must be a positive duration")
+	}
+	return RateLimitRule{Limit: limit, Window: window}, nil
+}
+
+// ValidateSecrets는 서버를 시작하기 전에 기본값이 없는 서명 / 암호화 키 설정을 확인하는 메서드
+// HS256이면 JWT_SECRET이 비어 있거나, 이전 기본값이거나, 짧으면 누구나 access token을 위조할 수 있으므로 거부함
+// 2단계 인증 secret의 암호화 키와 초대 링크 서명 키는 JWT_SECRET이 유출 되어도 secret이 노출되거나 링크가 위조되지 않도록 따로 설정해야 함
+func (c *Config) ValidateSecrets() error {
+	if c.Auth.JWTAlgorithm == "HS256" {
+		if c.JWTSecret == legacyDefaultJWTSecret {
+			return errors.New("access tokens: JWT_SECRET must not be the old default value, set a random secret")
+		}
+		if err := validateSecretKey("JWT_SECRET", c.JWTSecret, ""); err != nil {
+			return fmt.Errorf("access tokens: %w", err)
+		}
+	}
+	if err := validateSecretKey("TOTP_ENCRYPTION_KEY", c.Auth.TOTPEncryptionKey, c.JWTSecret); err != nil {
+		return fmt.Errorf("two-factor authentication: %w", err)
+	}
+	if err := validateSecretKey("WORKSPACE_INVITATION_SIGNING_KEY", c.Workspace.InvitationSigningKey, c.JWTSecret); err != nil {
+		return fmt.Errorf("workspace invitations: %w", err)
+	}
+	return nil
+}
+
+// validateSecretKey는 키가 설정 되어 있고, 충분히 길고, JWT_SECRET과 다른지 확인하는 함수 (jwtSecret이 비어 있으면 비교하지 않음)
+func validateSecretKey(name string, key string, jwtSecret string) error {
+	if key == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if len(key) < SECRET_KEY_MIN_LENGTH {
+		return fmt.Errorf("%s must be at least %d characters", name, SECRET_KEY_MIN_LENGTH)
+	}
+	if jwtSecret != "" && key == jwtSecret {
+		return fmt.Errorf("%s must differ from JWT_SECRET", name)
+	}
+	return nil
+}
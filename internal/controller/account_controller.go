@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"lux-list/internal/middleware"
+	"lux-list/internal/model"
+	"lux-list/internal/service"
+	"lux-list/pkg/redis"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountController는 데이터 내보내기와 계정 삭제 관련 메서드를 정의하는 인터페이스
+type AccountController interface {
+	RequestExport(c *gin.Context)
+	GetExport(c *gin.Context)
+	DownloadExport(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	CancelDeletion(c *gin.Context)
+}
+
+// accountController는 AccountController 인터페이스를 구현하는 구조체
+type accountController struct {
+	accountService service.AccountService
+}
+
+// RegisterAccountRoutes는 데이터 내보내기와 계정 삭제 라우트를 등록하는 함수
+// 관리자가 대리 로그인한 세션에서는 사용할 수 없음
+func RegisterAccountRoutes(router *gin.RouterGroup, accountController AccountController) {
+	account := router.Group("", middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware())
+	account.POST("/export", accountController.RequestExport)
+	account.GET("/export/:exportID", accountController.GetExport)
+	account.GET("/export/:exportID/download", accountController.DownloadExport)
+	account.DELETE("", accountController.DeleteAccount)
+	account.DELETE("/deletion", accountController.CancelDeletion)
+}
+
+// NewAccountController는 AccountController의 인스턴스를 생성하는 함수
+func NewAccountController(accountService service.AccountService) AccountController {
+	return &accountController{
+		accountService: accountService,
+	}
+}
+
+// RequestExport는 모든 데이터를 담은 ZIP 파일 내보내기를 요청하는 메서드
+// 파일은 백그라운드에서 만들어지므로 GET /auth/export/:exportID로 상태를 확인한 뒤 내려받아야 함
+func (c *accountController) RequestExport(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	export, status, err := c.accountService.RequestExport(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"export": export})
+}
+
+// GetExport는 내보내기 작업의 상태를 조회하는 메서드
+func (c *accountController) GetExport(ctx *gin.Context) {
+	userID, exportID, ok := exportParams(ctx)
+	if !ok {
+		return
+	}
+
+	export, status, err := c.accountService.GetExport(ctx.Request.Context(), userID, exportID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"export": export})
+}
+
+// DownloadExport는 완료 된 내보내기 ZIP 파일을 내려받는 메서드
+func (c *accountController) DownloadExport(ctx *gin.Context) {
+	userID, exportID, ok := exportParams(ctx)
+	if !ok {
+		return
+	}
+
+	export, content, status, err := c.accountService.GetExportContent(ctx.Request.Context(), userID, exportID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileName := fmt.Sprintf("lux-list-export-%d-%s.zip", userID, export.CreatedAt.Format("20060102"))
+	ctx.Header("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(status, "application/zip", content)
+}
+
+// DeleteAccount는 유예 기간 뒤에 계정을 삭제하도록 예약하는 메서드
+// 예약하면 모든 세션이 해제되며, 유예 기간 안에 다시 로그인해서 DELETE /auth/deletion으로 취소할 수 있음
+func (c *accountController) DeleteAccount(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.DeleteAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	deletionAt, status, err := c.accountService.ScheduleDeletion(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	revoked, err := redis.DeleteAuthSessions(ctx, userID, "")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete sessions in session store"})
+		return
+	}
+	utils.ClearSession(ctx)
+	ctx.JSON(status, gin.H{"message": "Account Deletion Scheduled", "deletion_scheduled_at": deletionAt, "revoked_sessions": revoked})
+}
+
+// CancelDeletion은 계정 삭제 예약을 취소하는 메서드
+func (c *accountController) CancelDeletion(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	status, err := c.accountService.CancelDeletion(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Account Deletion Cancelled"})
+}
+
+// exportParams는 요청 사용자 ID와 경로의 내보내기 작업 ID를 읽는 함수, 올바르지 않으면 400으로 응답하고 false를 반환
+func exportParams(ctx *gin.Context) (int, int, bool) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, 0, false
+	}
+	exportID, err := strconv.Atoi(ctx.Param("exportID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return 0, 0, false
+	}
+	return userID, exportID, true
+}
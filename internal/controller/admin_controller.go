@@ -0,0 +1,327 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/middleware"
+	"lux-list/internal/model"
+	"lux-list/internal/service"
+	"lux-list/pkg/redis"
+	"lux-list/pkg/types"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminController는 관리자 API 관련 메서드를 정의하는 인터페이스
+type AdminController interface {
+	ListUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	DisableUser(c *gin.Context)
+	EnableUser(c *gin.Context)
+	SetUserRole(c *gin.Context)
+	LogoutUser(c *gin.Context)
+	Impersonate(c *gin.Context)
+	EndImpersonation(c *gin.Context)
+	IssueClaimToken(c *gin.Context)
+	GetAuditLogs(c *gin.Context)
+}
+
+// adminController는 AdminController 인터페이스를 구현하는 구조체
+type adminController struct {
+	adminService service.AdminService
+}
+
+// RegisterAdminRoutes는 관리자 API 라우트를 등록하는 함수 (AuthMiddleware와 AdminMiddleware 필요)
+func RegisterAdminRoutes(router *gin.RouterGroup, adminController AdminController) {
+	router.GET("/users", adminController.ListUsers)
+	router.GET("/users/:userID", adminController.GetUser)
+	router.POST("/users/:userID/disable", adminController.DisableUser)
+	router.POST("/users/:userID/enable", adminController.EnableUser)
+	router.PUT("/users/:userID/role", adminController.SetUserRole)
+	router.POST("/users/:userID/logout", adminController.LogoutUser)
+	router.POST("/users/:userID/impersonate", adminController.Impersonate)
+	router.POST("/users/:userID/claim-token", adminController.IssueClaimToken)
+	router.GET("/audit-logs", adminController.GetAuditLogs)
+}
+
+// RegisterImpersonationRoutes는 대리 로그인 세션에서 사용하는 라우트를 등록하는 함수
+func RegisterImpersonationRoutes(router *gin.RouterGroup, adminController AdminController) {
+	router.DELETE("/impersonation", middleware.AuthMiddleware(), adminController.EndImpersonation)
+}
+
+// NewAdminController는 AdminController의 인스턴스를 생성하는 함수
+func NewAdminController(adminService service.AdminService) AdminController {
+	return &adminController{
+		adminService: adminService,
+	}
+}
+
+// ListUsers는 사용자 목록을 검색하는 메서드 (q: 이름 / 이메일, role, disabled, limit, page)
+func (c *adminController) ListUsers(ctx *gin.Context) {
+	filter := &model.AdminUserFilter{
+		Query: ctx.Query("q"),
+		Role:  ctx.Query("role"),
+	}
+	if value := ctx.Query("disabled"); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "disabled must be true or false"})
+			return
+		}
+		filter.Disabled = &disabled
+	}
+	filter.Limit, _ = strconv.Atoi(ctx.Query("limit"))
+	filter.Page, _ = strconv.Atoi(ctx.Query("page"))
+
+	result, status, err := c.adminService.ListUsers(ctx.Request.Context(), filter)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, result)
+}
+
+// GetUser는 사용자 정보와 사용량(할 일, 태그, 토큰, 활성 세션 수 등)을 조회하는 메서드
+func (c *adminController) GetUser(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+
+	user, status, err := c.adminService.GetUser(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	sessions, err := redis.ListAuthSessions(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load sessions from session store"})
+		return
+	}
+	user.Usage.ActiveSessions = len(sessions)
+	ctx.JSON(status, gin.H{"user": user})
+}
+
+// DisableUser는 사용자를 사용 중지하고 모든 세션을 해제하는 메서드
+func (c *adminController) DisableUser(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+
+	user, status, err := c.adminService.DisableUser(ctx.Request.Context(), actor, userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	revoked, err := redis.DeleteAuthSessions(ctx, userID, "")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete sessions in session store"})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Disabled", "user": user, "revoked_sessions": revoked})
+}
+
+// EnableUser는 사용 중지한 사용자를 다시 사용할 수 있게 하는 메서드
+func (c *adminController) EnableUser(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+
+	user, status, err := c.adminService.EnableUser(ctx.Request.Context(), actor, userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Enabled", "user": user})
+}
+
+// SetUserRole은 사용자의 역할(user, admin)을 바꾸는 메서드
+func (c *adminController) SetUserRole(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req model.SetUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, status, err := c.adminService.SetUserRole(ctx.Request.Context(), actor, userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Role Updated", "user": user})
+}
+
+// LogoutUser는 사용자의 모든 세션을 해제하는 메서드 (강제 로그아웃)
+func (c *adminController) LogoutUser(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+
+	revoked, err := redis.DeleteAuthSessions(ctx, userID, "")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete sessions in session store"})
+		return
+	}
+	status, err := c.adminService.RecordLogout(ctx.Request.Context(), actor, userID, revoked)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Logged Out", "revoked_sessions": revoked})
+}
+
+// Impersonate는 관리자가 사용자로 대리 로그인하는 메서드
+// 관리자의 현재 세션은 해제되고, 쿠키 세션이 ADMIN_IMPERSONATION_TTL 동안만 유효한 사용자의 세션으로 바뀜
+func (c *adminController) Impersonate(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+	sessionID, _ := utils.GetSessionIDFromContext(ctx)
+
+	var req model.ImpersonateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, token, status, err := c.adminService.Impersonate(ctx.Request.Context(), actor, userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := redis.DeleteAuthSession(ctx, actor.UserID, sessionID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	expiresAt := time.Now().UTC().Add(config.GetConfig().Auth.ImpersonationTTL)
+	deviceName := "Impersonation by user " + strconv.Itoa(actor.UserID)
+	err = startSession(ctx, user.ID, token, deviceName, func(authSession *model.AuthSession) {
+		authSession.ImpersonatorID = actor.UserID
+		authSession.ExpiresAt = &expiresAt
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Impersonation Started", "user": model.NewAdminUser(user), "expires_at": expiresAt})
+}
+
+// EndImpersonation은 대리 로그인 세션을 끝내는 메서드, 관리자는 다시 로그인해야 함
+func (c *adminController) EndImpersonation(ctx *gin.Context) {
+	impersonatorID, _ := ctx.Get(types.CONTEXT_IMPERSONATOR_ID)
+	adminID, ok := impersonatorID.(int)
+	if !ok {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "this session is not an impersonation session"})
+		return
+	}
+	userID, _ := utils.GetUserIDFromContext(ctx)
+	sessionID, _ := utils.GetSessionIDFromContext(ctx)
+
+	status, err := c.adminService.EndImpersonation(ctx.Request.Context(), service.AdminActor{UserID: adminID, IP: ctx.ClientIP()}, userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if err := redis.DeleteAuthSession(ctx, userID, sessionID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	utils.ClearSession(ctx)
+	ctx.JSON(status, gin.H{"message": "Impersonation Ended"})
+}
+
+// IssueClaimToken은 이름만으로 가입한 기존 사용자의 계정 등록 토큰을 발급하는 메서드
+func (c *adminController) IssueClaimToken(ctx *gin.Context) {
+	userID, ok := adminTargetUserID(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := adminActor(ctx)
+	if !ok {
+		return
+	}
+
+	res, status, err := c.adminService.IssueClaimToken(ctx.Request.Context(), actor, userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, res)
+}
+
+// GetAuditLogs는 관리자 작업 기록을 최신 순으로 조회하는 메서드 (user_id: 대상 사용자, limit, page)
+func (c *adminController) GetAuditLogs(ctx *gin.Context) {
+	targetUserID := 0
+	if value := ctx.Query("user_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		targetUserID = id
+	}
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	page, _ := strconv.Atoi(ctx.Query("page"))
+
+	logs, totalCount, status, err := c.adminService.GetAuditLogs(ctx.Request.Context(), targetUserID, limit, page)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"audit_logs": logs, "total_count": totalCount})
+}
+
+// adminTargetUserID는 경로의 대상 사용자 ID를 읽는 함수, 올바르지 않으면 400으로 응답하고 false를 반환
+func adminTargetUserID(ctx *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+	return userID, true
+}
+
+// adminActor는 요청한 관리자 정보를 읽는 함수, 없으면 400으로 응답하고 false를 반환
+func adminActor(ctx *gin.Context) (service.AdminActor, bool) {
+	adminID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.New("Invalid user ID").Error()})
+		return service.AdminActor{}, false
+	}
+	return service.AdminActor{UserID: adminID, IP: ctx.ClientIP()}, true
+}
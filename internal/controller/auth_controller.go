@@ -1,12 +1,21 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"lux-list/internal/config"
 	"lux-list/internal/middleware"
 	"lux-list/internal/model"
 	"lux-list/internal/service"
+	"lux-list/pkg/auth"
 	"lux-list/pkg/redis"
 	"lux-list/pkg/types"
 	"lux-list/pkg/utils"
@@ -17,9 +26,21 @@ import (
 
 // AuthController는 사용자 인증 관련 메서드를 정의하는 인터페이스
 type AuthController interface {
+	CSRFToken(c *gin.Context)
+	Register(c *gin.Context)
 	Login(c *gin.Context)
 	Logout(c *gin.Context)
 	Profile(c *gin.Context)
+	ClaimAccount(c *gin.Context)
+	RequestEmailVerification(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	Refresh(c *gin.Context)
+	ListSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeOtherSessions(c *gin.Context)
+	RequestPasswordReset(c *gin.Context)
+	ResetPassword(c *gin.Context)
 }
 
 // authController는 AuthController 인터페이스를 구현하는 구조체
@@ -29,9 +50,21 @@ type authController struct {
 
 // RegisterRoutes는 인증 관련 라우트를 등록하는 함수
 func RegisterAuthRoutes(router *gin.RouterGroup, authController AuthController) {
-	router.POST("/login", authController.Login)
+	router.GET("/csrf", authController.CSRFToken)
+	router.POST("/register", authController.Register)
+	router.POST("/login", middleware.RateLimitMiddleware("login", middleware.RATE_LIMIT_SCOPE_IP), authController.Login)
+	router.POST("/refresh", authController.Refresh)
 	router.GET("/logout", middleware.AuthMiddleware(), authController.Logout)
 	router.GET("", middleware.AuthMiddleware(), authController.Profile)
+	router.POST("/claim", authController.ClaimAccount)
+	router.POST("/email/verify-request", middleware.AuthMiddleware(), authController.RequestEmailVerification)
+	router.POST("/email/verify", authController.VerifyEmail)
+	router.PUT("/password", middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware(), authController.ChangePassword)
+	router.GET("/sessions", middleware.AuthMiddleware(), authController.ListSessions)
+	router.DELETE("/sessions", middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware(), authController.RevokeOtherSessions)
+	router.DELETE("/sessions/:sessionID", middleware.AuthMiddleware(), authController.RevokeSession)
+	router.POST("/password/reset-request", authController.RequestPasswordReset)
+	router.POST("/password/reset", authController.ResetPassword)
 }
 
 // NewAuthController는 AuthController의 인스턴스를 생성하는 함수
@@ -41,7 +74,42 @@ func NewAuthController(authService service.AuthService) AuthController {
 	}
 }
 
+// CSRFToken은 쿠키 세션의 CSRF 토큰을 발급하는 메서드
+// 쿠키 세션으로 보내는 POST / PUT / PATCH / DELETE 요청은 이 토큰을 X-CSRF-Token 헤더에 담아야 함
+func (c *authController) CSRFToken(ctx *gin.Context) {
+	token, err := middleware.EnsureCSRFToken(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue CSRF token"})
+		return
+	}
+	ctx.Header(middleware.CSRF_HEADER, token)
+	ctx.JSON(http.StatusOK, gin.H{"csrf_token": token})
+}
+
+// Register는 이메일 + 비밀번호 회원가입 요청을 처리하는 메서드, 가입 후 바로 로그인 상태가 됨
+func (c *authController) Register(ctx *gin.Context) {
+	var req model.RegisterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, token, status, err := c.authService.Register(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := startSession(ctx, user.ID, token, req.DeviceName); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Registered", "user": user})
+}
+
 // login은 사용자 로그인 요청을 처리하는 메서드
+// 2단계 인증을 켠 계정은 totp_code 또는 recovery_code까지 확인한 뒤에 JWT를 발급함
+// 비밀번호를 등록하지 않은 기존 사용자는 AUTH_LEGACY_NAME_LOGIN이 켜져 있을 때만 이름으로 로그인하며, 응답의 claim_required가 true가 됨
 func (c *authController) Login(ctx *gin.Context) {
 	var req model.LoginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -49,81 +117,493 @@ func (c *authController) Login(ctx *gin.Context) {
 		return
 	}
 
-	// 사용자 이름이 비어있는지 확인
-	if req.Name == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+	// 한 계정에 대한 로그인 실패가 많으면 IP와 관계없이 잠시 막음 (여러 IP에서 나눠 시도하는 공격 방지)
+	accountLimit := newLoginAccountLimit(&req)
+	if accountLimit.exceeded(ctx) {
 		return
 	}
 
-	isExistUser, err := c.authService.ExistUser(req.Name)
+	user, token, claimRequired, status, err := c.authService.Login(ctx.Request.Context(), &req)
+	if status == http.StatusUnauthorized && !errors.Is(err, service.ErrTwoFactorRequired) {
+		accountLimit.recordFailure(ctx)
+	}
+	if errors.Is(err, service.ErrTwoFactorRequired) {
+		// 같은 요청에 totp_code 또는 recovery_code를 넣어 다시 로그인해야 함
+		ctx.JSON(status, gin.H{"error": err.Error(), "code": "two_factor_required"})
+		return
+	}
 	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountLimit.reset(ctx)
+	if err := startSession(ctx, user.ID, token, req.DeviceName); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	ctx.JSON(status, gin.H{"message": "User Logged In", "user": user, "claim_required": claimRequired})
+}
 
-	session := sessions.Default(ctx)
-	var (
-		user   *model.User
-		token  string
-		status int
-	)
+// logout은 사용자 로그아웃 요청을 처리하는 메서드
+func (c *authController) Logout(ctx *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(ctx)
+	sessionID, _ := utils.GetSessionIDFromContext(ctx)
+	utils.ClearSession(ctx)
+
+	fmt.Print(userID)
+	if err := redis.DeleteAuthSession(ctx, userID, sessionID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
 
-	if isExistUser {
-		user, token, status, err = c.authService.Login(req.Name)
-	} else {
-		user, token, status, err = c.authService.RegisterAndGenerateJWT(req.Name)
+	ctx.JSON(http.StatusOK, gin.H{"message": "User Logged Out"})
+}
+
+// profile은 요청 사용자의 프로필 정보를 반환하는 메서드
+func (c *authController) Profile(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
 	}
+
+	user, status, err := c.authService.GetUserByID(ctx.Request.Context(), userID)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	// 세션에 사용자 정보와 토큰 저장
-	session.Set(types.SESSION_USERID, user.ID)
-	session.Set(types.SESSION_ACCESS_TOKEN, token)
+	ctx.JSON(http.StatusOK, gin.H{"user": user})
+}
 
-	// Redis에 세션 저장
-	if err := redis.SetAuthSession(ctx, user.ID, token); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session in Redis"})
+// ClaimAccount는 이름만으로 가입한 기존 사용자가 관리자에게 받은 토큰으로 이메일과 비밀번호를 등록하는 요청을 처리하는 메서드
+// 등록 후 바로 로그인 상태가 됨
+func (c *authController) ClaimAccount(ctx *gin.Context) {
+	var req model.ClaimAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 
+	user, token, status, err := c.authService.ClaimAccount(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := startSession(ctx, user.ID, token, req.DeviceName); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Account Claimed", "user": user})
+}
+
+// RequestEmailVerification은 이메일 인증 토큰을 다시 보내는 요청을 처리하는 메서드
+func (c *authController) RequestEmailVerification(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	status, err := c.authService.RequestEmailVerification(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "A verification link has been sent"})
+}
+
+// VerifyEmail은 이메일 인증 토큰으로 이메일을 인증하는 요청을 처리하는 메서드
+// 메일의 링크에서 호출하므로 로그인하지 않아도 사용할 수 있음
+func (c *authController) VerifyEmail(ctx *gin.Context) {
+	var req model.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, status, err := c.authService.VerifyEmail(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Email Verified", "user": user})
+}
+
+// ChangePassword는 비밀번호 변경 요청을 처리하는 메서드
+// 새 토큰으로 세션을 교체하므로 다른 기기의 세션은 만료됨
+func (c *authController) ChangePassword(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	token, status, err := c.authService.ChangePassword(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 현재 세션을 포함한 모든 세션을 해제하고 현재 기기에 새 세션 발급
+	currentSession, err := currentAuthSession(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := redis.DeleteAuthSessions(ctx, userID, ""); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	if err := startSession(ctx, userID, token, currentSession.DeviceName); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Password Changed"})
+}
+
+// RequestPasswordReset은 비밀번호 재설정 토큰 발급 요청을 처리하는 메서드
+// 가입 여부와 관계없이 같은 응답을 반환
+func (c *authController) RequestPasswordReset(ctx *gin.Context) {
+	var req model.PasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	status, err := c.authService.RequestPasswordReset(ctx.Request.Context(), req.Email)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+// ResetPassword는 재설정 토큰으로 비밀번호를 변경하는 요청을 처리하는 메서드
+// 재설정 후 기존 로그인 세션은 모두 만료됨
+func (c *authController) ResetPassword(ctx *gin.Context) {
+	var req model.ConfirmPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, status, err := c.authService.ResetPassword(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := redis.DeleteAuthSessions(ctx, user.ID, ""); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Password Reset"})
+}
+
+// Refresh는 쿠키 세션의 refresh token으로 access token을 갱신하는 메서드
+// refresh token도 매번 새로 발급(rotation)하며, 이미 사용한 refresh token이 다시 오면 해당 세션(토큰 family)을 해제함
+// 여러 탭이 같은 토큰으로 거의 동시에 갱신하면 먼저 온 요청만 토큰을 바꾸고, 나머지는 쿠키를 건드리지 않음 (200 또는 409)
+func (c *authController) Refresh(ctx *gin.Context) {
+	session := sessions.Default(ctx)
+	refreshToken, _ := session.Get(types.SESSION_REFRESH_TOKEN).(string)
+	sessionID, err := auth.ParseRefreshToken(refreshToken)
+	if err != nil {
+		utils.ClearSession(ctx)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요한 서비스입니다."})
+		return
+	}
+
+	var (
+		newAccessToken  string
+		newRefreshToken string
+		status          int
+	)
+	_, err = redis.RotateAuthSession(ctx, sessionID, auth.HashOpaqueToken(refreshToken), func(authSession *model.AuthSession) error {
+		var err error
+		newAccessToken, status, err = c.authService.RefreshAccessToken(ctx.Request.Context(), authSession.UserID)
+		if err != nil {
+			return err
+		}
+
+		var refreshTokenHash string
+		newRefreshToken, refreshTokenHash, err = auth.GenerateRefreshToken(authSession.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		authSession.TokenHash = auth.HashOpaqueToken(newAccessToken)
+		authSession.RotateRefreshToken(refreshTokenHash, now)
+		authSession.LastSeenAt = now
+		authSession.IP = ctx.ClientIP()
+		return nil
+	})
+	switch {
+	case errors.Is(err, redis.ErrRefreshTokenSuperseded):
+		// 다른 탭이 방금 갱신한 새 토큰이 쿠키에 저장되므로, 쿠키를 덮어쓰지 않고 성공으로 응답
+		ctx.JSON(http.StatusOK, gin.H{"message": "Token Refreshed"})
+		return
+	case errors.Is(err, redis.ErrRefreshTokenConflict):
+		// 먼저 갱신한 요청이 저장한 쿠키를 지우지 않도록 세션을 그대로 두고, 클라이언트는 잠시 후 다시 시도
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, redis.ErrRefreshTokenReused):
+		log.Printf("Refresh token reuse detected, session %s (token family) revoked", sessionID)
+		utils.ClearSession(ctx)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, redis.ErrRefreshTokenInvalid), errors.Is(err, redis.ErrSessionNotFound):
+		utils.ClearSession(ctx)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 세션이 만료되었습니다."})
+		return
+	case err != nil:
+		if status == 0 || status == http.StatusOK {
+			status = http.StatusInternalServerError
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	session.Set(types.SESSION_ACCESS_TOKEN, newAccessToken)
+	session.Set(types.SESSION_REFRESH_TOKEN, newRefreshToken)
 	if err := session.Save(); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
 		return
 	}
-	ctx.JSON(status, gin.H{"message": "User Logged In", "user": user})
+	ctx.JSON(http.StatusOK, gin.H{"message": "Token Refreshed"})
 }
 
-// logout은 사용자 로그아웃 요청을 처리하는 메서드
-func (c *authController) Logout(ctx *gin.Context) {
-	userID, _ := utils.GetUserIDFromContext(ctx)
-	session := sessions.Default(ctx)
-	session.Clear()
-	_ = session.Save()
+// ListSessions는 요청 사용자의 로그인 세션(기기) 목록을 반환하는 메서드
+func (c *authController) ListSessions(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	currentSessionID, _ := utils.GetSessionIDFromContext(ctx)
 
-	fmt.Print(userID)
-	if err := redis.DeleteAuthSession(ctx, userID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in Redis"})
+	authSessions, err := redis.ListAuthSessions(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load sessions"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "User Logged Out"})
+	// 최근에 사용한 세션부터 정렬
+	sort.Slice(authSessions, func(i, j int) bool {
+		return authSessions[i].LastSeenAt.After(authSessions[j].LastSeenAt)
+	})
+	response := make([]model.AuthSessionResponse, 0, len(authSessions))
+	for _, authSession := range authSessions {
+		response = append(response, model.NewAuthSessionResponse(authSession, currentSessionID))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"sessions": response})
 }
 
-// profile은 요청 사용자의 프로필 정보를 반환하는 메서드
-func (c *authController) Profile(ctx *gin.Context) {
+// RevokeSession은 요청 사용자의 세션 하나를 해제하는 메서드
+// 현재 세션을 해제하면 로그아웃과 같음
+func (c *authController) RevokeSession(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	currentSessionID, _ := utils.GetSessionIDFromContext(ctx)
+	sessionID := ctx.Param("sessionID")
 
-	user, status, err := c.authService.GetUserByID(userID)
+	// 다른 사용자의 세션 ID는 없는 세션과 같은 응답을 반환
+	authSession, err := redis.GetAuthSession(ctx, sessionID)
+	if errors.Is(err, redis.ErrSessionNotFound) || (err == nil && authSession.UserID != userID) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
 	if err != nil {
-		ctx.JSON(status, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"user": user})
+	if err := redis.DeleteAuthSession(ctx, userID, sessionID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	if sessionID == currentSessionID {
+		utils.ClearSession(ctx)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Session Revoked"})
+}
+
+// RevokeOtherSessions는 현재 세션을 제외한 요청 사용자의 모든 세션을 해제하는 메서드
+func (c *authController) RevokeOtherSessions(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	currentSessionID, err := utils.GetSessionIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	revoked, err := redis.DeleteAuthSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session in session store"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Other Sessions Revoked", "revoked": revoked})
+}
+
+// startSession은 새 인증 세션을 만들어 쿠키 세션과 인증 세션 저장소에 저장하는 함수
+// deviceName이 비어있으면 User-Agent를 기기 이름으로 사용하며, options로 저장 전에 세션을 바꿀 수 있음 (대리 로그인 등)
+func startSession(ctx *gin.Context, userID int, token string, deviceName string, options ...func(authSession *model.AuthSession)) error {
+	sessionID, _, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	refreshToken, refreshTokenHash, err := auth.GenerateRefreshToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	userAgent := truncate(ctx.Request.UserAgent(), 255)
+	deviceName = truncate(strings.TrimSpace(deviceName), model.SESSION_DEVICE_NAME_MAX_LENGTH)
+	if deviceName == "" {
+		deviceName = truncate(userAgent, model.SESSION_DEVICE_NAME_MAX_LENGTH)
+	}
+
+	now := time.Now().UTC()
+	authSession := &model.AuthSession{
+		ID:         sessionID,
+		UserID:     userID,
+		TokenHash:  auth.HashOpaqueToken(token),
+		DeviceName: deviceName,
+		IP:         ctx.ClientIP(),
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+
+		RefreshTokenHash: refreshTokenHash,
+	}
+	for _, option := range options {
+		option(authSession)
+	}
+
+	// 인증 세션 저장소에 세션 저장
+	if err := redis.SetAuthSession(ctx, authSession); err != nil {
+		return errors.New("Failed to save session in session store")
+	}
+
+	session := sessions.Default(ctx)
+	session.Set(types.SESSION_USERID, userID)
+	session.Set(types.SESSION_ACCESS_TOKEN, token)
+	session.Set(types.SESSION_ID, sessionID)
+	session.Set(types.SESSION_REFRESH_TOKEN, refreshToken)
+	if err := session.Save(); err != nil {
+		return errors.New("Failed to save session")
+	}
+	return nil
+}
+
+// currentAuthSession은 요청을 보낸 인증 세션을 반환하는 함수
+func currentAuthSession(ctx *gin.Context) (*model.AuthSession, error) {
+	sessionID, err := utils.GetSessionIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return redis.GetAuthSession(ctx, sessionID)
+}
+
+// truncate는 문자열을 최대 max 바이트로 자르는 함수 (UTF-8 문자 경계 유지)
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	for max > 0 && !utf8.RuneStart(value[max]) {
+		max--
+	}
+	return value[:max]
+}
+
+// loginAccountLimit은 로그인 대상 계정(이메일 또는 이름)별 실패 횟수 제한 (RATE_LIMIT_LOGIN_ACCOUNT)
+// 요청 제한이 꺼져 있거나 저장소에 접근할 수 없으면 아무것도 하지 않음
+type loginAccountLimit struct {
+	limiter redis.RateLimiter
+	rule    config.RateLimitRule
+	key     string
+}
+
+// newLoginAccountLimit은 로그인 요청의 계정으로 loginAccountLimit을 만드는 함수
+func newLoginAccountLimit(req *model.LoginRequest) *loginAccountLimit {
+	account := "name:" + strings.TrimSpace(req.Name)
+	if req.Email != "" {
+		account = "email:" + model.NormalizeEmail(req.Email)
+	}
+	return newAccountLimit(account)
+}
+
+// newUserLoginLimit은 사용자 ID로 loginAccountLimit을 만드는 함수 (OIDC 로그인 후 2단계 인증 코드 확인용)
+func newUserLoginLimit(userID int) *loginAccountLimit {
+	return newAccountLimit("user:" + strconv.Itoa(userID))
+}
+
+// newAccountLimit은 login_account 규칙으로 account의 실패 횟수를 세는 loginAccountLimit을 만드는 함수
+func newAccountLimit(account string) *loginAccountLimit {
+	rateLimitConfig := config.GetConfig().RateLimit
+	rule := rateLimitConfig.Rules["login_account"]
+	if !rateLimitConfig.Enabled || rule.Limit == 0 {
+		return &loginAccountLimit{}
+	}
+	limiter, err := redis.GetRateLimiter()
+	if err != nil {
+		return &loginAccountLimit{}
+	}
+
+	return &loginAccountLimit{
+		limiter: limiter,
+		rule:    rule,
+		key:     redis.RateLimitKey("login_account", account),
+	}
+}
+
+// exceeded는 계정의 실패 횟수가 제한을 넘었는지 확인하고, 넘었으면 429로 응답하는 메서드
+func (l *loginAccountLimit) exceeded(ctx *gin.Context) bool {
+	if l.limiter == nil {
+		return false
+	}
+	result, err := l.limiter.Peek(ctx.Request.Context(), l.key, l.rule)
+	if err != nil {
+		log.Printf("rate limit login_account: %v", err)
+		return false
+	}
+	if result.Allowed {
+		return false
+	}
+	return middleware.RateLimitExceeded(ctx, result)
+}
+
+// recordFailure는 계정의 로그인 실패를 기록하는 메서드
+func (l *loginAccountLimit) recordFailure(ctx *gin.Context) {
+	if l.limiter == nil {
+		return
+	}
+	if _, err := l.limiter.Allow(ctx.Request.Context(), l.key, l.rule); err != nil {
+		log.Printf("rate limit login_account: %v", err)
+	}
+}
+
+// reset은 로그인에 성공한 계정의 실패 기록을 지우는 메서드
+func (l *loginAccountLimit) reset(ctx *gin.Context) {
+	if l.limiter == nil {
+		return
+	}
+	if err := l.limiter.Reset(ctx.Request.Context(), l.key); err != nil {
+		log.Printf("rate limit login_account: %v", err)
+	}
 }
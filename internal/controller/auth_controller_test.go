@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lux-list/internal/model"
+	"lux-list/pkg/redis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestMain은 config.GetConfig가 읽을 .env를 임시 디렉터리에 만들고 그 디렉터리에서 테스트를 실행하는 함수
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	dir, err := os.MkdirTemp("", "lux-list-controller-test")
+	if err != nil {
+		panic(err)
+	}
+	env := "RATE_LIMIT_ENABLED=true\nRATE_LIMIT_LOGIN_ACCOUNT=3/1h\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// checkAccountLimit은 limit.exceeded를 호출하고 결과와 응답을 반환하는 함수
+func checkAccountLimit(limit *loginAccountLimit) (bool, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	return limit.exceeded(ctx), recorder
+}
+
+// failAccountLimit은 limit에 로그인 실패를 count번 기록하는 함수
+func failAccountLimit(limit *loginAccountLimit, count int) {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	for i := 0; i < count; i++ {
+		limit.recordFailure(ctx)
+	}
+}
+
+func TestLoginAccountLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	redis.SetRateLimiter(redis.NewMemoryRateLimiter(ctx))
+
+	// RATE_LIMIT_LOGIN_ACCOUNT=3/1h, 확인만 해서는 실패 횟수가 늘지 않음
+	limit := newLoginAccountLimit(&model.LoginRequest{Email: "User@Example.com"})
+	for i := 0; i < 5; i++ {
+		if exceeded, _ := checkAccountLimit(limit); exceeded {
+			t.Fatal("checking the limit must not count as a failure")
+		}
+	}
+	failAccountLimit(limit, 2)
+	if exceeded, _ := checkAccountLimit(limit); exceeded {
+		t.Fatal("expected 2 failures to be under the limit")
+	}
+	failAccountLimit(limit, 1)
+
+	// 정규화한 이메일이 같으면 IP나 대소문자와 관계없이 같은 계정
+	exceeded, recorder := checkAccountLimit(newLoginAccountLimit(&model.LoginRequest{Email: " user@example.COM "}))
+	if !exceeded || recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after 3 failures, got %v (%d)", exceeded, recorder.Code)
+	}
+	if recorder.Header().Get("RateLimit-Limit") != "3" || recorder.Header().Get("RateLimit-Remaining") != "0" || recorder.Header().Get("Retry-After") != "3600" {
+		t.Fatalf("unexpected rate limit headers %v", recorder.Header())
+	}
+
+	// 다른 계정, 같은 값의 이름, 사용자 ID는 따로 셈
+	for _, other := range []*loginAccountLimit{
+		newLoginAccountLimit(&model.LoginRequest{Email: "other@example.com"}),
+		newLoginAccountLimit(&model.LoginRequest{Name: "user@example.com"}),
+		newUserLoginLimit(1),
+	} {
+		if exceeded, _ := checkAccountLimit(other); exceeded {
+			t.Fatalf("expected %s to be under the limit", other.key)
+		}
+	}
+
+	// 로그인에 성공하면 실패 기록을 지움
+	resetCtx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	resetCtx.Request = httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	limit.reset(resetCtx)
+	if exceeded, _ := checkAccountLimit(limit); exceeded {
+		t.Fatal("expected the limit to be reset after a successful login")
+	}
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"lux-list/internal/model"
+	"lux-list/internal/realtime"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationController는 작업 공간 초대 관련 메서드를 정의하는 인터페이스
+type InvitationController interface {
+	GetWorkspaceInvitations(c *gin.Context)
+	CreateInvitation(c *gin.Context)
+	RevokeInvitation(c *gin.Context)
+	GetPendingInvitations(c *gin.Context)
+	AcceptInvitation(c *gin.Context)
+	DeclineInvitation(c *gin.Context)
+	AcceptInvitationByToken(c *gin.Context)
+	DeclineInvitationByToken(c *gin.Context)
+}
+
+// invitationController는 InvitationController 인터페이스를 구현하는 구조체
+type invitationController struct {
+	invitationService service.WorkspaceInvitationService
+	publisher         realtime.Publisher
+}
+
+// RegisterInvitationRoutes는 작업 공간 초대 관련 라우트를 등록하는 함수
+// /invitations는 작업 공간 ID 경로보다 먼저 매칭되는 고정 경로
+func RegisterInvitationRoutes(router *gin.RouterGroup, invitationController InvitationController) {
+	router.GET("/invitations", invitationController.GetPendingInvitations)
+	router.POST("/invitations/accept", invitationController.AcceptInvitationByToken)
+	router.POST("/invitations/decline", invitationController.DeclineInvitationByToken)
+	router.POST("/invitations/:invitationID/accept", invitationController.AcceptInvitation)
+	router.POST("/invitations/:invitationID/decline", invitationController.DeclineInvitation)
+	router.GET("/:workspaceID/invitations", invitationController.GetWorkspaceInvitations)
+	router.POST("/:workspaceID/invitations", invitationController.CreateInvitation)
+	router.DELETE("/:workspaceID/invitations/:invitationID", invitationController.RevokeInvitation)
+}
+
+// NewInvitationController는 InvitationController의 인스턴스를 생성하는 함수
+func NewInvitationController(invitationService service.WorkspaceInvitationService, publisher realtime.Publisher) InvitationController {
+	return &invitationController{
+		invitationService: invitationService,
+		publisher:         publisher,
+	}
+}
+
+// GetWorkspaceInvitations는 작업 공간의 대기 중인 초대 목록을 조회하는 메서드
+func (c *invitationController) GetWorkspaceInvitations(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	invitations, status, err := c.invitationService.GetWorkspaceInvitations(ctx.Request.Context(), userID, workspaceID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"invitations": invitations})
+}
+
+// CreateInvitation은 사용자 이름 또는 이메일로 작업 공간에 초대하는 메서드
+func (c *invitationController) CreateInvitation(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	var req model.CreateWorkspaceInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	res, status, err := c.invitationService.CreateInvitation(ctx.Request.Context(), userID, workspaceID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if recipient := res.Invitation.RecipientUserID; recipient != nil {
+		c.publisher.Publish(realtime.EVENT_INVITATION_CREATED, res.Invitation, realtime.UserTopic(*recipient))
+	}
+	ctx.JSON(status, res)
+}
+
+// RevokeInvitation은 대기 중인 초대를 취소하는 메서드
+func (c *invitationController) RevokeInvitation(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	invitationID, err := strconv.Atoi(ctx.Param("invitationID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	invitation, status, err := c.invitationService.RevokeInvitation(ctx.Request.Context(), userID, workspaceID, invitationID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if recipient := invitation.RecipientUserID; recipient != nil {
+		c.publisher.Publish(realtime.EVENT_INVITATION_REVOKED, gin.H{"id": invitation.ID, "workspace_id": workspaceID}, realtime.UserTopic(*recipient))
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetPendingInvitations는 사용자가 받은 대기 중인 초대 목록을 조회하는 메서드
+func (c *invitationController) GetPendingInvitations(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	invitations, status, err := c.invitationService.GetPendingInvitations(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"invitations": invitations})
+}
+
+// AcceptInvitation은 초대 목록의 초대를 수락하는 메서드
+func (c *invitationController) AcceptInvitation(ctx *gin.Context) {
+	c.respondInvitation(ctx, true)
+}
+
+// DeclineInvitation은 초대 목록의 초대를 거절하는 메서드
+func (c *invitationController) DeclineInvitation(ctx *gin.Context) {
+	c.respondInvitation(ctx, false)
+}
+
+// AcceptInvitationByToken은 초대 링크의 토큰으로 초대를 수락하는 메서드
+func (c *invitationController) AcceptInvitationByToken(ctx *gin.Context) {
+	c.respondInvitationByToken(ctx, true)
+}
+
+// DeclineInvitationByToken은 초대 링크의 토큰으로 초대를 거절하는 메서드
+func (c *invitationController) DeclineInvitationByToken(ctx *gin.Context) {
+	c.respondInvitationByToken(ctx, false)
+}
+
+// respondInvitation은 경로의 초대 ID로 초대에 응답하는 메서드
+func (c *invitationController) respondInvitation(ctx *gin.Context, accept bool) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	invitationID, err := strconv.Atoi(ctx.Param("invitationID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	invitation, status, err := c.invitationService.RespondInvitation(ctx.Request.Context(), userID, invitationID, accept)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publishResponse(userID, invitation)
+	ctx.JSON(status, gin.H{"invitation": invitation})
+}
+
+// respondInvitationByToken은 요청 본문의 초대 링크 토큰으로 초대에 응답하는 메서드
+func (c *invitationController) respondInvitationByToken(ctx *gin.Context, accept bool) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.RespondWorkspaceInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	invitation, status, err := c.invitationService.RespondInvitationByToken(ctx.Request.Context(), userID, req.Token, accept)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publishResponse(userID, invitation)
+	ctx.JSON(status, gin.H{"invitation": invitation})
+}
+
+// publishResponse는 초대 응답을 작업 공간 구성원에게 알리는 메서드
+// 수락하면 새 구성원이 추가 되었음을, 거절하면 초대가 거절 되었음을 발행
+func (c *invitationController) publishResponse(userID int, invitation *model.WorkspaceInvitation) {
+	if invitation.Status == model.INVITATION_STATUS_ACCEPTED {
+		c.publisher.Publish(realtime.EVENT_MEMBER_ADDED, gin.H{"workspace_id": invitation.WorkspaceID, "user_id": userID, "role": invitation.Role}, realtime.WorkspaceTopic(invitation.WorkspaceID), realtime.UserTopic(userID))
+		return
+	}
+	c.publisher.Publish(realtime.EVENT_INVITATION_DECLINED, gin.H{"id": invitation.ID, "workspace_id": invitation.WorkspaceID}, realtime.WorkspaceTopic(invitation.WorkspaceID))
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"net/http"
+
+	"lux-list/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSController는 access token 검증용 공개 키를 제공하는 메서드를 정의하는 인터페이스
+type JWKSController interface {
+	GetJWKS(c *gin.Context)
+}
+
+// jwksController는 JWKSController 인터페이스를 구현하는 구조체
+type jwksController struct{}
+
+// RegisterJWKSRoutes는 공개 키 라우트를 등록하는 함수 (인증 없이 접근 가능)
+func RegisterJWKSRoutes(router gin.IRoutes, jwksController JWKSController) {
+	router.GET("/.well-known/jwks.json", jwksController.GetJWKS)
+}
+
+// NewJWKSController는 JWKSController의 인스턴스를 생성하는 함수
+func NewJWKSController() JWKSController {
+	return &jwksController{}
+}
+
+// GetJWKS는 다른 서비스가 lux-list access token을 검증할 수 있도록 공개 키 목록을 반환하는 메서드
+// 키를 교체할 때 새 키를 가져갈 수 있도록 짧게 캐시함
+func (c *jwksController) GetJWKS(ctx *gin.Context) {
+	jwks, err := auth.PublicJWKS()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load signing keys"})
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jwks)
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"lux-list/internal/middleware"
+	"lux-list/internal/model"
+	"lux-list/internal/service"
+	"lux-list/pkg/auth"
+	"lux-list/pkg/redis"
+	"lux-list/pkg/types"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCController는 OpenID Connect 로그인 관련 메서드를 정의하는 인터페이스
+type OIDCController interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+	CompleteTwoFactor(c *gin.Context)
+	GetIdentities(c *gin.Context)
+	UnlinkIdentity(c *gin.Context)
+}
+
+// oidcController는 OIDCController 인터페이스를 구현하는 구조체
+type oidcController struct {
+	oidcService       service.OIDCService
+	postLoginRedirect string
+}
+
+// RegisterOIDCRoutes는 OpenID Connect 로그인 관련 라우트를 등록하는 함수
+func RegisterOIDCRoutes(router *gin.RouterGroup, oidcController OIDCController) {
+	router.GET("/oidc/login", oidcController.Login)
+	router.GET("/oidc/callback", oidcController.Callback)
+	router.POST("/oidc/2fa", middleware.RateLimitMiddleware("login", middleware.RATE_LIMIT_SCOPE_IP), oidcController.CompleteTwoFactor)
+	router.GET("/identities", middleware.AuthMiddleware(), oidcController.GetIdentities)
+	router.DELETE("/identities/:identityID", middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware(), oidcController.UnlinkIdentity)
+}
+
+// NewOIDCController는 OIDCController의 인스턴스를 생성하는 함수
+func NewOIDCController(oidcService service.OIDCService, postLoginRedirect string) OIDCController {
+	return &oidcController{
+		oidcService:       oidcService,
+		postLoginRedirect: postLoginRedirect,
+	}
+}
+
+// Login은 로그인 상태를 저장하고 ID 공급자의 로그인 페이지로 이동시키는 메서드
+// state는 쿠키 세션에도 저장하여 로그인을 시작한 브라우저에서만 콜백을 처리함
+func (c *oidcController) Login(ctx *gin.Context) {
+	authURL, loginState, status, err := c.oidcService.BeginLogin(ctx.Request.Context(), ctx.Query("device_name"), ctx.Query("login_hint"))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := redis.SetOIDCLoginState(ctx, loginState); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save login state in session store"})
+		return
+	}
+	session := sessions.Default(ctx)
+	session.Set(types.SESSION_OIDC_STATE, loginState.State)
+	if err := session.Save(); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
+	ctx.Redirect(http.StatusFound, authURL)
+}
+
+// Callback은 ID 공급자에서 돌아온 인가 코드로 로그인하고 postLoginRedirect로 이동시키는 메서드
+// 2단계 인증을 켠 계정은 로그인을 보류하고 postLoginRedirect?two_factor_required=true로 이동시키며, POST /oidc/2fa로 코드를 확인해야 함
+func (c *oidcController) Callback(ctx *gin.Context) {
+	session := sessions.Default(ctx)
+	expectedState, _ := session.Get(types.SESSION_OIDC_STATE).(string)
+	session.Delete(types.SESSION_OIDC_STATE)
+
+	if providerError := ctx.Query("error"); providerError != "" {
+		_ = session.Save()
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "identity provider returned an error: " + providerError, "description": ctx.Query("error_description")})
+		return
+	}
+
+	state := ctx.Query("state")
+	if state == "" || state != expectedState {
+		_ = session.Save()
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "login state does not match, start the login again"})
+		return
+	}
+	loginState, err := redis.ConsumeOIDCLoginState(ctx, state)
+	if errors.Is(err, redis.ErrSessionNotFound) {
+		_ = session.Save()
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "login state has expired, start the login again"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login state"})
+		return
+	}
+
+	user, token, status, err := c.oidcService.CompleteLogin(ctx.Request.Context(), loginState, ctx.Query("code"))
+	if errors.Is(err, service.ErrTwoFactorRequired) {
+		c.beginTwoFactor(ctx, user.ID, loginState.DeviceName)
+		return
+	}
+	if err != nil {
+		log.Printf("OIDC login failed: %v", err)
+		_ = session.Save()
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := startSession(ctx, user.ID, token, loginState.DeviceName); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Redirect(http.StatusFound, c.postLoginRedirect)
+}
+
+// beginTwoFactor는 2단계 인증 코드를 기다리는 로그인을 저장하고 코드 입력을 요청하도록 postLoginRedirect로 이동시키는 메서드
+func (c *oidcController) beginTwoFactor(ctx *gin.Context, userID int, deviceName string) {
+	pendingID, _, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate login ID"})
+		return
+	}
+	pending := &model.OIDCPendingLogin{ID: pendingID, UserID: userID, DeviceName: deviceName, CreatedAt: time.Now().UTC()}
+	if err := redis.SetOIDCPendingLogin(ctx, pending); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save login state in session store"})
+		return
+	}
+
+	session := sessions.Default(ctx)
+	session.Set(types.SESSION_OIDC_PENDING, pendingID)
+	if err := session.Save(); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
+
+	redirect, err := url.Parse(c.postLoginRedirect)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid post login redirect"})
+		return
+	}
+	query := redirect.Query()
+	query.Set("two_factor_required", "true")
+	redirect.RawQuery = query.Encode()
+	ctx.Redirect(http.StatusFound, redirect.String())
+}
+
+// CompleteTwoFactor는 OIDC 로그인 후 보류 된 로그인의 totp_code 또는 recovery_code를 확인하고 세션을 시작하는 메서드
+// 코드가 틀려도 보류 된 로그인은 만료될 때까지 남아 다시 시도할 수 있음
+func (c *oidcController) CompleteTwoFactor(ctx *gin.Context) {
+	var req model.TwoFactorCode
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	session := sessions.Default(ctx)
+	pendingID, _ := session.Get(types.SESSION_OIDC_PENDING).(string)
+	if pendingID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "no login is waiting for a two-factor code, start the login again"})
+		return
+	}
+	pending, err := redis.GetOIDCPendingLogin(ctx, pendingID)
+	if errors.Is(err, redis.ErrSessionNotFound) {
+		session.Delete(types.SESSION_OIDC_PENDING)
+		_ = session.Save()
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "login has expired, start the login again"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login state"})
+		return
+	}
+
+	// 비밀번호 로그인과 같이 계정별 실패 횟수를 제한함
+	accountLimit := newUserLoginLimit(pending.UserID)
+	if accountLimit.exceeded(ctx) {
+		return
+	}
+
+	user, token, status, err := c.oidcService.CompleteTwoFactorLogin(ctx.Request.Context(), pending, &req)
+	if status == http.StatusUnauthorized {
+		accountLimit.recordFailure(ctx)
+	}
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountLimit.reset(ctx)
+	if err := redis.DeleteOIDCPendingLogin(ctx, pending.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete login state"})
+		return
+	}
+	session.Delete(types.SESSION_OIDC_PENDING)
+	if err := startSession(ctx, user.ID, token, pending.DeviceName); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "User Logged In", "user": user})
+}
+
+// GetIdentities는 요청 사용자에게 연결 된 외부 계정 목록을 반환하는 메서드
+func (c *oidcController) GetIdentities(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	identities, status, err := c.oidcService.GetIdentities(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"identities": identities})
+}
+
+// UnlinkIdentity는 요청 사용자에게 연결 된 외부 계정을 해제하는 메서드
+func (c *oidcController) UnlinkIdentity(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	identityID, err := strconv.Atoi(ctx.Param("identityID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		return
+	}
+
+	status, err := c.oidcService.UnlinkIdentity(ctx.Request.Context(), userID, identityID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Identity Unlinked"})
+}
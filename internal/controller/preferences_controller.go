@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"lux-list/internal/middleware"
+	"lux-list/internal/model"
+	"lux-list/internal/realtime"
+	"lux-list/internal/service"
+	"lux-list/pkg/patch"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreferencesController는 사용자 설정 관련 메서드를 정의하는 인터페이스
+type PreferencesController interface {
+	GetPreferences(c *gin.Context)
+	PatchPreferences(c *gin.Context)
+}
+
+// preferencesController는 PreferencesController 인터페이스를 구현하는 구조체
+type preferencesController struct {
+	preferencesService service.PreferencesService
+	publisher          realtime.Publisher
+}
+
+// RegisterPreferencesRoutes는 사용자 설정 라우트를 등록하는 함수
+func RegisterPreferencesRoutes(router *gin.RouterGroup, preferencesController PreferencesController) {
+	preferences := router.Group("/preferences", middleware.AuthMiddleware())
+	preferences.GET("", preferencesController.GetPreferences)
+	preferences.PATCH("", preferencesController.PatchPreferences)
+}
+
+// NewPreferencesController는 PreferencesController의 인스턴스를 생성하는 함수
+func NewPreferencesController(preferencesService service.PreferencesService, publisher realtime.Publisher) PreferencesController {
+	return &preferencesController{
+		preferencesService: preferencesService,
+		publisher:          publisher,
+	}
+}
+
+// GetPreferences는 기본값이 적용된 사용자 설정을 조회하는 메서드
+func (c *preferencesController) GetPreferences(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	result, status, err := c.preferencesService.GetPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if utils.CheckIfNoneMatch(ctx, utils.VersionETag(result.Version)) {
+		return
+	}
+	ctx.JSON(status, result)
+}
+
+// PatchPreferences는 JSON Merge Patch 또는 JSON Patch로 사용자 설정을 수정하는 메서드
+// 기본값이 적용된 현재 설정에 패치를 적용하므로, merge patch의 null(또는 JSON Patch의 remove)은 해당 필드를 기본값으로 되돌림
+func (c *preferencesController) PatchPreferences(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	contentType := ctx.ContentType()
+	if contentType != patch.CONTENT_TYPE_MERGE_PATCH && contentType != patch.CONTENT_TYPE_JSON_PATCH {
+		ctx.Header("Accept-Patch", patch.CONTENT_TYPE_MERGE_PATCH+", "+patch.CONTENT_TYPE_JSON_PATCH)
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be application/merge-patch+json or application/json-patch+json"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MAX_PATCH_BODY_SIZE))
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Patch document is too large"})
+		return
+	}
+
+	current, status, err := c.preferencesService.GetPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(current.Version)) {
+		return
+	}
+
+	doc, err := json.Marshal(current.Preferences)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var patched []byte
+	if contentType == patch.CONTENT_TYPE_MERGE_PATCH {
+		patched, err = patch.MergePatch(doc, body)
+	} else {
+		patched, err = patch.JSONPatch(doc, body)
+	}
+	if err != nil {
+		if err == patch.ErrTestFailed {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 패치로 제거 된 필드는 기본값으로 채워짐
+	preferences := model.DefaultUserPreferences()
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&preferences); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch result: " + err.Error()})
+		return
+	}
+
+	result, status, err := c.preferencesService.UpdatePreferences(ctx.Request.Context(), userID, &preferences, current.Version)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	// 같은 사용자의 다른 기기에 테마 등 변경 사항을 알림
+	c.publisher.Publish(realtime.EVENT_PREFERENCES_UPDATED, result, realtime.UserTopic(userID))
+	ctx.Header("ETag", utils.VersionETag(result.Version))
+	ctx.JSON(status, result)
+}
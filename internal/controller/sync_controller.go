@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+
+	"lux-list/internal/model"
+	"lux-list/internal/realtime"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncController는 오프라인 클라이언트 동기화 관련 메서드를 정의하는 인터페이스
+type SyncController interface {
+	GetChanges(c *gin.Context)
+	ApplyMutations(c *gin.Context)
+}
+
+// syncController는 SyncController 인터페이스를 구현하는 구조체
+type syncController struct {
+	syncService service.SyncService
+	publisher   realtime.Publisher
+}
+
+// RegisterSyncRoutes는 동기화 관련 라우트를 등록하는 함수
+func RegisterSyncRoutes(router *gin.RouterGroup, syncController SyncController) {
+	router.GET("", syncController.GetChanges)
+	router.POST("", syncController.ApplyMutations)
+}
+
+// NewSyncController는 SyncController의 인스턴스를 생성하는 함수
+func NewSyncController(syncService service.SyncService, publisher realtime.Publisher) SyncController {
+	return &syncController{
+		syncService: syncService,
+		publisher:   publisher,
+	}
+}
+
+// GetChanges는 since 토큰 이후의 변경 내역과 tombstone을 반환하는 메서드
+func (c *syncController) GetChanges(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	changes, status, err := c.syncService.GetChanges(ctx.Request.Context(), userID, workspaceID, ctx.Query("since"))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(status, changes)
+}
+
+// ApplyMutations는 클라이언트 변경 사항 묶음을 적용하고 충돌 내역을 반환하는 메서드
+func (c *syncController) ApplyMutations(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	var req model.SyncRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format or missing fields"})
+		return
+	}
+
+	response, status, err := c.syncService.ApplyMutations(ctx.Request.Context(), userID, workspaceID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 작업 공간의 다른 구성원과 같은 사용자의 다른 기기가 다시 동기화하도록 알림
+	c.publisher.Publish(realtime.EVENT_SYNC_CHANGED, gin.H{"next_token": response.NextToken}, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(userID))
+	ctx.JSON(status, response)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"lux-list/internal/model"
+	"lux-list/internal/realtime"
 	"lux-list/internal/service"
 	"lux-list/pkg/utils"
 	"net/http"
@@ -22,6 +23,7 @@ type TagController interface {
 // tagController는 TagController 인터페이스를 구현하는 구조체
 type tagController struct {
 	tagService service.TagService
+	publisher  realtime.Publisher
 }
 
 // RegisterTagRoutes는 태그 관련 라우트를 등록하는 함수
@@ -35,9 +37,10 @@ func RegisterTagRoutes(router *gin.RouterGroup, tagController TagController) {
 }
 
 // NewTagController는 TagController의 인스턴스를 생성하는 함수
-func NewTagController(tagService service.TagService) TagController {
+func NewTagController(tagService service.TagService, publisher realtime.Publisher) TagController {
 	return &tagController{
 		tagService: tagService,
+		publisher:  publisher,
 	}
 }
 
@@ -48,6 +51,11 @@ func (c *tagController) GetTagsByTagID(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	tagID := ctx.Param("tagID")
 	if tagID == "" {
@@ -55,30 +63,42 @@ func (c *tagController) GetTagsByTagID(ctx *gin.Context) {
 		return
 	}
 
-	tag, status, err := c.tagService.GetTagsByTagID(userID, utils.InterfaceToInt(tagID))
+	tag, status, err := c.tagService.GetTagsByTagID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(tagID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	if utils.CheckIfNoneMatch(ctx, utils.VersionETag(tag.Version)) {
+		return
+	}
 	ctx.JSON(status, gin.H{"tag": tag})
 }
 
-// GetTagsByUserID는 사용자의 모든 태그를 조회하는 메서드
+// GetTagsByUserID는 작업 공간의 모든 태그를 조회하는 메서드 (경로의 userID는 이전 버전 호환용으로 사용하지 않음)
 func (c *tagController) GetTagsByUserID(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
-	tags, status, err := c.tagService.GetTagsByUserID(userID)
+	tags, status, err := c.tagService.GetTagsByWorkspaceID(ctx.Request.Context(), userID, workspaceID)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(status, gin.H{"tags": tags})
+	response := gin.H{"tags": tags}
+	if utils.CheckIfNoneMatch(ctx, utils.WeakETag(response)) {
+		return
+	}
+	ctx.JSON(status, response)
 }
 
 // GetTagsByTaskID는 작업 ID로 태그를 조회하는 메서드
@@ -88,6 +108,11 @@ func (c *tagController) GetTagsByTaskID(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -95,22 +120,31 @@ func (c *tagController) GetTagsByTaskID(ctx *gin.Context) {
 		return
 	}
 
-	tags, status, err := c.tagService.GetTagsByTaskID(userID, utils.InterfaceToInt(taskID))
+	tags, status, err := c.tagService.GetTagsByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(status, gin.H{"tags": tags})
+	response := gin.H{"tags": tags}
+	if utils.CheckIfNoneMatch(ctx, utils.WeakETag(response)) {
+		return
+	}
+	ctx.JSON(status, response)
 }
 
-// CreateTags는 사용자의 태그를 생성하는 메서드
+// CreateTags는 작업 공간에 태그를 생성하는 메서드
 func (c *tagController) CreateTags(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	var req model.CreateTagRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -123,12 +157,14 @@ func (c *tagController) CreateTags(ctx *gin.Context) {
 		return
 	}
 
-	createdTag, status, err := c.tagService.CreateTags(userID, req.ToTag(userID))
+	createdTag, status, err := c.tagService.CreateTags(ctx.Request.Context(), userID, workspaceID, req.ToTag(userID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.publisher.Publish(realtime.EVENT_TAG_CREATED, createdTag, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(userID))
+	ctx.Header("ETag", utils.VersionETag(createdTag.Version))
 	ctx.JSON(status, gin.H{"tag": createdTag})
 }
 
@@ -139,6 +175,11 @@ func (c *tagController) DeleteTags(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	tagID := ctx.Param("tagID")
 	if tagID == "" {
@@ -146,12 +187,22 @@ func (c *tagController) DeleteTags(ctx *gin.Context) {
 		return
 	}
 
-	status, err := c.tagService.DeleteTags(userID, utils.InterfaceToInt(tagID))
+	findTag, status, err := c.tagService.GetTagsByTagID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(tagID))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTag.Version)) {
+		return
+	}
+
+	status, err = c.tagService.DeleteTags(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(tagID), findTag.Version)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.publisher.Publish(realtime.EVENT_TAG_DELETED, gin.H{"id": utils.InterfaceToInt(tagID)}, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(userID))
 	ctx.JSON(status, gin.H{"message": "Tag deleted successfully"})
 }
 
@@ -162,6 +213,11 @@ func (c *tagController) UpdateTags(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	tagID := ctx.Param("tagID")
 	if tagID == "" {
@@ -180,17 +236,22 @@ func (c *tagController) UpdateTags(ctx *gin.Context) {
 		return
 	}
 
-	findTag, status, err := c.tagService.GetTagsByTagID(userID, utils.InterfaceToInt(tagID))
+	findTag, status, err := c.tagService.GetTagsByTagID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(tagID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTag.Version)) {
+		return
+	}
 
-	updatedTag, status, err := c.tagService.UpdateTags(userID, utils.InterfaceToInt(tagID), req.ToTag(findTag))
+	updatedTag, status, err := c.tagService.UpdateTags(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(tagID), req.ToTag(findTag))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.publisher.Publish(realtime.EVENT_TAG_UPDATED, updatedTag, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(userID))
+	ctx.Header("ETag", utils.VersionETag(updatedTag.Version))
 	ctx.JSON(status, gin.H{"tag": updatedTag})
 }
@@ -1,14 +1,24 @@
 package controller
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"lux-list/internal/model"
+	"lux-list/internal/realtime"
 	"lux-list/internal/service"
+	"lux-list/pkg/patch"
 	"lux-list/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// PATCH 요청 본문 최대 크기
+	MAX_PATCH_BODY_SIZE = 1 << 20
+)
+
 // TaskController는 작업 관련 메서드를 정의하는 인터페이스
 type TaskController interface {
 	GetTasks(c *gin.Context)
@@ -16,8 +26,10 @@ type TaskController interface {
 	CreateTasks(c *gin.Context)
 	DeleteTasks(c *gin.Context)
 	UpdateTasks(c *gin.Context)
+	PatchTasks(c *gin.Context)
 	CompleteTasks(c *gin.Context)
 	InCompleteTasks(c *gin.Context)
+	BulkTasks(c *gin.Context)
 
 	// Task & Tag Methods
 	AddTagToTask(c *gin.Context)
@@ -28,6 +40,7 @@ type TaskController interface {
 type taskController struct {
 	taskService    service.TaskService
 	taskTagService service.TaskTagService
+	publisher      realtime.Publisher
 }
 
 // RegisterTaskRoutes는 작업 관련 라우트를 등록하는 함수
@@ -35,8 +48,10 @@ func RegisterTaskRoutes(router *gin.RouterGroup, taskController TaskController)
 	router.GET("", taskController.GetTasks)
 	router.GET("/:taskID", taskController.GetTasksByTaskID)
 	router.POST("", taskController.CreateTasks)
+	router.POST("/bulk", taskController.BulkTasks)
 	router.DELETE("/:taskID", taskController.DeleteTasks)
 	router.PUT("/:taskID", taskController.UpdateTasks)
+	router.PATCH("/:taskID", taskController.PatchTasks)
 	router.PATCH("/:taskID/complete", taskController.CompleteTasks)
 	router.PATCH("/:taskID/incomplete", taskController.InCompleteTasks)
 
@@ -46,37 +61,57 @@ func RegisterTaskRoutes(router *gin.RouterGroup, taskController TaskController)
 }
 
 // NewTaskController는 TaskController의 인스턴스를 생성하는 함수
-func NewTaskController(taskService service.TaskService, taskTagService service.TaskTagService) TaskController {
+func NewTaskController(taskService service.TaskService, taskTagService service.TaskTagService, publisher realtime.Publisher) TaskController {
 	return &taskController{
 		taskService:    taskService,
 		taskTagService: taskTagService,
+		publisher:      publisher,
 	}
 }
 
-// GetTasks는 사용자의 모든 작업을 조회하는 메서드
+// publishTaskEvent는 작업 변경 이벤트를 작업 공간 토픽, 사용자 토픽, 작업 토픽에 발행하는 메서드
+func (c *taskController) publishTaskEvent(workspaceID int, userID int, taskID int, event string, data interface{}) {
+	c.publisher.Publish(event, data, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(userID), realtime.TaskTopic(taskID))
+}
+
+// GetTasks는 작업 공간의 모든 작업을 조회하는 메서드
 func (c *taskController) GetTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	search_query := utils.GetTasksSearchQuery(ctx)
-	taskListResult, status, err := c.taskService.GetTasks(userID, search_query)
+	taskListResult, status, err := c.taskService.GetTasks(ctx.Request.Context(), userID, workspaceID, search_query)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(status, gin.H{"tasks": taskListResult.Tasks, "total_count": taskListResult.TotalCount})
+	response := gin.H{"tasks": taskListResult.Tasks, "total_count": taskListResult.TotalCount}
+	if utils.CheckIfNoneMatch(ctx, utils.WeakETag(response)) {
+		return
+	}
+	ctx.JSON(status, response)
 }
 
-// GetTasksByTaskID는 사용자의 특정 작업을 조회하는 메서드
+// GetTasksByTaskID는 작업 공간의 특정 작업을 조회하는 메서드
 func (c *taskController) GetTasksByTaskID(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -84,13 +119,18 @@ func (c *taskController) GetTasksByTaskID(ctx *gin.Context) {
 		return
 	}
 
-	task, status, err := c.taskService.GetTasksByTaskID(userID, utils.InterfaceToInt(taskID))
+	task, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	tags, status, err := c.taskTagService.GetTagsByTaskID(utils.InterfaceToInt(taskID))
+	// 작업의 버전은 연결 된 태그 변경 시에도 증가하므로 태그 조회 전에 비교
+	if utils.CheckIfNoneMatch(ctx, utils.VersionETag(task.Version)) {
+		return
+	}
+
+	tags, status, err := c.taskTagService.GetTagsByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
@@ -100,13 +140,18 @@ func (c *taskController) GetTasksByTaskID(ctx *gin.Context) {
 	ctx.JSON(status, gin.H{"task": task})
 }
 
-// CreateTasks는 사용자의 작업을 생성하는 메서드
+// CreateTasks는 작업 공간의 작업을 생성하는 메서드
 func (c *taskController) CreateTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	var req model.CreateTaskRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -120,21 +165,33 @@ func (c *taskController) CreateTasks(ctx *gin.Context) {
 		return
 	}
 
-	createdTask, status, err := c.taskService.CreateTasks(userID, req.ToTask(userID))
+	loc, status, err := c.taskService.GetUserLocation(ctx.Request.Context(), userID)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	createdTask, status, err := c.taskService.CreateTasks(ctx.Request.Context(), userID, workspaceID, req.ToTask(userID, loc), req.TagIDs)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.publishTaskEvent(workspaceID, userID, createdTask.ID, realtime.EVENT_TASK_CREATED, createdTask)
+	ctx.Header("ETag", utils.VersionETag(createdTask.Version))
 	ctx.JSON(status, gin.H{"task": createdTask})
 }
 
-// DeleteTasks는 사용자의 작업을 삭제하는 메서드
+// DeleteTasks는 작업 공간의 작업을 삭제하는 메서드
 func (c *taskController) DeleteTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -142,22 +199,37 @@ func (c *taskController) DeleteTasks(ctx *gin.Context) {
 		return
 	}
 
-	status, err := c.taskService.DeleteTasks(userID, utils.InterfaceToInt(taskID))
+	findTask, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTask.Version)) {
+		return
+	}
+
+	status, err = c.taskService.DeleteTasks(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), findTask.Version)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	c.publishTaskEvent(workspaceID, userID, utils.InterfaceToInt(taskID), realtime.EVENT_TASK_DELETED, gin.H{"id": utils.InterfaceToInt(taskID)})
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
 
-// UpdateTasks는 사용자의 작업을 업데이트하는 메서드
+// UpdateTasks는 작업 공간의 작업을 업데이트하는 메서드
 func (c *taskController) UpdateTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -177,27 +249,136 @@ func (c *taskController) UpdateTasks(ctx *gin.Context) {
 		return
 	}
 
-	findTask, status, err := c.taskService.GetTasksByTaskID(userID, utils.InterfaceToInt(taskID))
+	findTask, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTask.Version)) {
+		return
+	}
+	loc, status, err := c.taskService.GetUserLocation(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 조회한 버전 그대로 저장하므로 그 사이에 다른 요청이 수정했다면 412를 반환
+	updatedTask, status, err := c.taskService.UpdateTasks(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), req.ToTask(findTask, loc))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.publishTaskEvent(workspaceID, userID, updatedTask.ID, realtime.EVENT_TASK_UPDATED, updatedTask)
+	ctx.Header("ETag", utils.VersionETag(updatedTask.Version))
+	ctx.JSON(status, gin.H{"task": updatedTask})
+}
+
+// PatchTasks는 JSON Merge Patch(RFC 7396) 또는 JSON Patch(RFC 6902)로 작업을 부분 수정하는 메서드
+func (c *taskController) PatchTasks(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	taskID := ctx.Param("taskID")
+	if taskID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		return
+	}
+
+	contentType := ctx.ContentType()
+	if contentType != patch.CONTENT_TYPE_MERGE_PATCH && contentType != patch.CONTENT_TYPE_JSON_PATCH {
+		ctx.Header("Accept-Patch", patch.CONTENT_TYPE_MERGE_PATCH+", "+patch.CONTENT_TYPE_JSON_PATCH)
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be application/merge-patch+json or application/json-patch+json"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MAX_PATCH_BODY_SIZE))
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Patch document is too large"})
+		return
+	}
+
+	findTask, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTask.Version)) {
+		return
+	}
+
+	loc, status, err := c.taskService.GetUserLocation(ctx.Request.Context(), userID)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	updatedTask, status, err := c.taskService.UpdateTasks(userID, utils.InterfaceToInt(taskID), req.ToTask(findTask))
+	// 현재 작업을 문서로 만든 뒤 패치를 적용
+	doc, err := json.Marshal(model.NewTaskPatchDocument(findTask, loc))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var patched []byte
+	if contentType == patch.CONTENT_TYPE_MERGE_PATCH {
+		patched, err = patch.MergePatch(doc, body)
+	} else {
+		patched, err = patch.JSONPatch(doc, body)
+	}
+	if err != nil {
+		if err == patch.ErrTestFailed {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var patchedDoc model.TaskPatchDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patchedDoc); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch result: " + err.Error()})
+		return
+	}
+
+	// 입력 값이 유효한지 검사 (UpdateTaskRequest와 같은 규칙)
+	if err := patchedDoc.CheckValidTaskPatchDocument(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedTask, status, err := c.taskService.UpdateTasks(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), patchedDoc.ToTask(findTask, loc))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	c.publishTaskEvent(workspaceID, userID, updatedTask.ID, realtime.EVENT_TASK_UPDATED, updatedTask)
+	ctx.Header("ETag", utils.VersionETag(updatedTask.Version))
 	ctx.JSON(status, gin.H{"task": updatedTask})
 }
 
-// CompleteTasks는 사용자의 작업을 완료 상태로 업데이트하는 메서드
+// CompleteTasks는 작업 공간의 작업을 완료 상태로 업데이트하는 메서드
 func (c *taskController) CompleteTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -205,22 +386,38 @@ func (c *taskController) CompleteTasks(ctx *gin.Context) {
 		return
 	}
 
-	// 사용자의 작업을 완료 상태로 업데이트
-	updatedTask, status, err := c.taskService.CompleteTasks(userID, utils.InterfaceToInt(taskID))
+	findTask, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTask.Version)) {
+		return
+	}
+
+	// 작업 공간의 작업을 완료 상태로 업데이트
+	updatedTask, status, err := c.taskService.CompleteTasks(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), findTask.Version)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.publishTaskEvent(workspaceID, userID, updatedTask.ID, realtime.EVENT_TASK_UPDATED, updatedTask)
+	ctx.Header("ETag", utils.VersionETag(updatedTask.Version))
 	ctx.JSON(status, gin.H{"task": updatedTask})
 }
 
-// InCompleteTasks는 사용자의 작업을 미완료 상태로 업데이트하는 메서드
+// InCompleteTasks는 작업 공간의 작업을 미완료 상태로 업데이트하는 메서드
 func (c *taskController) InCompleteTasks(ctx *gin.Context) {
 	userID, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
 
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
@@ -228,17 +425,89 @@ func (c *taskController) InCompleteTasks(ctx *gin.Context) {
 		return
 	}
 
-	// 사용자의 작업을 미완료 상태로 업데이트
-	updatedTask, status, err := c.taskService.InCompleteTasks(userID, utils.InterfaceToInt(taskID))
+	findTask, status, err := c.taskService.GetTasksByTaskID(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID))
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !utils.CheckIfMatch(ctx, utils.VersionETag(findTask.Version)) {
+		return
+	}
+
+	// 작업 공간의 작업을 미완료 상태로 업데이트
+	updatedTask, status, err := c.taskService.InCompleteTasks(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), findTask.Version)
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
+	c.publishTaskEvent(workspaceID, userID, updatedTask.ID, realtime.EVENT_TASK_UPDATED, updatedTask)
+	ctx.Header("ETag", utils.VersionETag(updatedTask.Version))
 	ctx.JSON(status, gin.H{"task": updatedTask})
 }
 
+// BulkTasks는 작업 ID 목록 또는 필터에 해당하는 작업들에 같은 변경을 한 번에 적용하는 메서드
+func (c *taskController) BulkTasks(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	var req model.BulkTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format or missing fields"})
+		return
+	}
+
+	// 입력 값이 유효한지 검사
+	if err := req.CheckValidBulkTaskRequest(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, status, err := c.taskService.BulkTasks(ctx.Request.Context(), userID, workspaceID, &req)
+	if err != nil {
+		if response != nil {
+			ctx.JSON(status, gin.H{"error": err.Error(), "committed": response.Committed, "results": response.Results})
+			return
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, result := range response.Results {
+		switch {
+		case result.Status == model.BULK_STATUS_DELETED:
+			c.publishTaskEvent(workspaceID, userID, result.TaskID, realtime.EVENT_TASK_DELETED, gin.H{"id": result.TaskID})
+		case result.Status == model.BULK_STATUS_UPDATED && req.Action == model.BULK_ACTION_ADD_TAG:
+			c.publishTaskEvent(workspaceID, userID, result.TaskID, realtime.EVENT_TASK_TAG_ADDED, gin.H{"task_id": result.TaskID, "tag_id": *req.TagID})
+		case result.Status == model.BULK_STATUS_UPDATED && req.Action == model.BULK_ACTION_REMOVE_TAG:
+			c.publishTaskEvent(workspaceID, userID, result.TaskID, realtime.EVENT_TASK_TAG_REMOVED, gin.H{"task_id": result.TaskID, "tag_id": *req.TagID})
+		case result.Status == model.BULK_STATUS_UPDATED:
+			c.publishTaskEvent(workspaceID, userID, result.TaskID, realtime.EVENT_TASK_UPDATED, result.Task)
+		}
+	}
+	ctx.JSON(status, response)
+}
+
 // AddTagToTask는 작업에 태그를 추가하는 메서드
 func (c *taskController) AddTagToTask(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
@@ -251,17 +520,29 @@ func (c *taskController) AddTagToTask(ctx *gin.Context) {
 		return
 	}
 
-	status, err := c.taskTagService.AddTagToTask(utils.InterfaceToInt(taskID), utils.InterfaceToInt(tagID))
+	status, err := c.taskTagService.AddTagToTask(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), utils.InterfaceToInt(tagID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.publishTaskEvent(workspaceID, userID, utils.InterfaceToInt(taskID), realtime.EVENT_TASK_TAG_ADDED, gin.H{"task_id": utils.InterfaceToInt(taskID), "tag_id": utils.InterfaceToInt(tagID)})
 	ctx.JSON(status, gin.H{"message": "Tag added to task successfully"})
 }
 
 // RemoveTagFromTask는 작업에서 태그를 제거하는 메서드
 func (c *taskController) RemoveTagFromTask(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	workspaceID, err := utils.GetWorkspaceIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
 	taskID := ctx.Param("taskID")
 	if taskID == "" {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
@@ -274,11 +555,12 @@ func (c *taskController) RemoveTagFromTask(ctx *gin.Context) {
 		return
 	}
 
-	status, err := c.taskTagService.RemoveTagFromTask(utils.InterfaceToInt(taskID), utils.InterfaceToInt(tagID))
+	status, err := c.taskTagService.RemoveTagFromTask(ctx.Request.Context(), userID, workspaceID, utils.InterfaceToInt(taskID), utils.InterfaceToInt(tagID))
 	if err != nil {
 		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.publishTaskEvent(workspaceID, userID, utils.InterfaceToInt(taskID), realtime.EVENT_TASK_TAG_REMOVED, gin.H{"task_id": utils.InterfaceToInt(taskID), "tag_id": utils.InterfaceToInt(tagID)})
 	ctx.JSON(status, gin.H{"message": "Tag removed from task successfully"})
 }
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"lux-list/internal/model"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TokenController는 개인 액세스 토큰 관련 메서드를 정의하는 인터페이스
+type TokenController interface {
+	GetTokens(c *gin.Context)
+	CreateToken(c *gin.Context)
+	DeleteToken(c *gin.Context)
+}
+
+// tokenController는 TokenController 인터페이스를 구현하는 구조체
+type tokenController struct {
+	tokenService service.TokenService
+}
+
+// RegisterTokenRoutes는 개인 액세스 토큰 관련 라우트를 등록하는 함수
+// 토큰으로 다른 토큰을 만들 수 없도록 쿠키 세션으로만 접근해야 함
+func RegisterTokenRoutes(router *gin.RouterGroup, tokenController TokenController) {
+	router.GET("", tokenController.GetTokens)
+	router.POST("", tokenController.CreateToken)
+	router.DELETE("/:tokenID", tokenController.DeleteToken)
+}
+
+// NewTokenController는 TokenController의 인스턴스를 생성하는 함수
+func NewTokenController(tokenService service.TokenService) TokenController {
+	return &tokenController{
+		tokenService: tokenService,
+	}
+}
+
+// GetTokens는 사용자의 개인 액세스 토큰 목록을 조회하는 메서드 (토큰 원문은 포함하지 않음)
+func (c *tokenController) GetTokens(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	tokens, status, err := c.tokenService.GetTokens(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"tokens": tokens})
+}
+
+// CreateToken은 개인 액세스 토큰을 발급하는 메서드, 응답의 token은 다시 확인할 수 없음
+func (c *tokenController) CreateToken(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.CreatePersonalAccessTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	token, status, err := c.tokenService.CreateToken(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"token": token})
+}
+
+// DeleteToken은 개인 액세스 토큰을 폐기하는 메서드
+func (c *tokenController) DeleteToken(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	tokenID, err := strconv.Atoi(ctx.Param("tokenID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	status, err := c.tokenService.DeleteToken(ctx.Request.Context(), userID, tokenID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Status(status)
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"net/http"
+
+	"lux-list/internal/model"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorController는 TOTP 2단계 인증 관련 메서드를 정의하는 인터페이스
+type TwoFactorController interface {
+	GetStatus(c *gin.Context)
+	Enroll(c *gin.Context)
+	Confirm(c *gin.Context)
+	Disable(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+}
+
+// twoFactorController는 TwoFactorController 인터페이스를 구현하는 구조체
+type twoFactorController struct {
+	twoFactorService service.TwoFactorService
+}
+
+// RegisterTwoFactorRoutes는 2단계 인증 관련 라우트를 등록하는 함수 (쿠키 세션 인증 필요)
+func RegisterTwoFactorRoutes(router *gin.RouterGroup, twoFactorController TwoFactorController) {
+	router.GET("", twoFactorController.GetStatus)
+	router.POST("/enroll", twoFactorController.Enroll)
+	router.POST("/confirm", twoFactorController.Confirm)
+	router.POST("/disable", twoFactorController.Disable)
+	router.POST("/recovery-codes", twoFactorController.RegenerateRecoveryCodes)
+}
+
+// NewTwoFactorController는 TwoFactorController의 인스턴스를 생성하는 함수
+func NewTwoFactorController(twoFactorService service.TwoFactorService) TwoFactorController {
+	return &twoFactorController{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// GetStatus는 요청 사용자의 2단계 인증 상태를 반환하는 메서드
+func (c *twoFactorController) GetStatus(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	response, status, err := c.twoFactorService.GetStatus(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, response)
+}
+
+// Enroll은 인증 앱에 등록할 secret과 otpauth URI를 발급하는 메서드
+func (c *twoFactorController) Enroll(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	response, status, err := c.twoFactorService.Enroll(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, response)
+}
+
+// Confirm은 인증 앱의 코드로 2단계 인증을 켜고 복구 코드를 반환하는 메서드
+func (c *twoFactorController) Confirm(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.ConfirmTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	codes, status, err := c.twoFactorService.Confirm(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Two-Factor Authentication Enabled", "recovery_codes": codes})
+}
+
+// Disable은 비밀번호와 2단계 인증 코드로 다시 인증한 뒤 2단계 인증을 끄는 메서드
+func (c *twoFactorController) Disable(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.ReauthenticateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	status, err := c.twoFactorService.Disable(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Two-Factor Authentication Disabled"})
+}
+
+// RegenerateRecoveryCodes는 비밀번호와 2단계 인증 코드로 다시 인증한 뒤 복구 코드를 새로 발급하는 메서드
+// 기존 복구 코드는 모두 사용할 수 없게 됨
+func (c *twoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.ReauthenticateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	codes, status, err := c.twoFactorService.RegenerateRecoveryCodes(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"message": "Recovery Codes Regenerated", "recovery_codes": codes})
+}
@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"lux-list/internal/model"
+	"lux-list/internal/realtime"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceController는 작업 공간 관련 메서드를 정의하는 인터페이스
+type WorkspaceController interface {
+	GetWorkspaces(c *gin.Context)
+	GetWorkspace(c *gin.Context)
+	CreateWorkspace(c *gin.Context)
+	UpdateWorkspace(c *gin.Context)
+	DeleteWorkspace(c *gin.Context)
+	GetMembers(c *gin.Context)
+	UpdateMember(c *gin.Context)
+	RemoveMember(c *gin.Context)
+}
+
+// workspaceController는 WorkspaceController 인터페이스를 구현하는 구조체
+type workspaceController struct {
+	workspaceService service.WorkspaceService
+	publisher        realtime.Publisher
+	revoker          realtime.Revoker
+}
+
+// RegisterWorkspaceRoutes는 작업 공간 관련 라우트를 등록하는 함수
+func RegisterWorkspaceRoutes(router *gin.RouterGroup, workspaceController WorkspaceController) {
+	router.GET("", workspaceController.GetWorkspaces)
+	router.POST("", workspaceController.CreateWorkspace)
+	router.GET("/:workspaceID", workspaceController.GetWorkspace)
+	router.PATCH("/:workspaceID", workspaceController.UpdateWorkspace)
+	router.DELETE("/:workspaceID", workspaceController.DeleteWorkspace)
+	router.GET("/:workspaceID/members", workspaceController.GetMembers)
+	router.PATCH("/:workspaceID/members/:userID", workspaceController.UpdateMember)
+	router.DELETE("/:workspaceID/members/:userID", workspaceController.RemoveMember)
+}
+
+// NewWorkspaceController는 WorkspaceController의 인스턴스를 생성하는 함수
+func NewWorkspaceController(workspaceService service.WorkspaceService, publisher realtime.Publisher, revoker realtime.Revoker) WorkspaceController {
+	return &workspaceController{
+		workspaceService: workspaceService,
+		publisher:        publisher,
+		revoker:          revoker,
+	}
+}
+
+// GetWorkspaces는 사용자가 구성원인 작업 공간 목록을 조회하는 메서드
+func (c *workspaceController) GetWorkspaces(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaces, status, err := c.workspaceService.GetWorkspaces(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"workspaces": workspaces})
+}
+
+// GetWorkspace는 작업 공간을 조회하는 메서드
+func (c *workspaceController) GetWorkspace(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, status, err := c.workspaceService.GetWorkspace(ctx.Request.Context(), userID, workspaceID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"workspace": workspace})
+}
+
+// CreateWorkspace는 공유 작업 공간을 만드는 메서드
+func (c *workspaceController) CreateWorkspace(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.CreateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	workspace, status, err := c.workspaceService.CreateWorkspace(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"workspace": workspace})
+}
+
+// UpdateWorkspace는 작업 공간 이름을 바꾸는 메서드
+func (c *workspaceController) UpdateWorkspace(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	var req model.UpdateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	workspace, status, err := c.workspaceService.UpdateWorkspace(ctx.Request.Context(), userID, workspaceID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publisher.Publish(realtime.EVENT_WORKSPACE_UPDATED, gin.H{"id": workspace.ID, "name": workspace.Name}, realtime.WorkspaceTopic(workspaceID))
+	ctx.JSON(status, gin.H{"workspace": workspace})
+}
+
+// DeleteWorkspace는 작업 공간을 삭제하는 메서드
+func (c *workspaceController) DeleteWorkspace(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	status, err := c.workspaceService.DeleteWorkspace(ctx.Request.Context(), userID, workspaceID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publisher.Publish(realtime.EVENT_WORKSPACE_DELETED, gin.H{"id": workspaceID}, realtime.WorkspaceTopic(workspaceID))
+	ctx.Status(status)
+}
+
+// GetMembers는 작업 공간의 구성원 목록을 조회하는 메서드
+func (c *workspaceController) GetMembers(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	members, status, err := c.workspaceService.GetMembers(ctx.Request.Context(), userID, workspaceID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"members": members})
+}
+
+// UpdateMember는 구성원의 역할을 바꾸는 메서드, role이 owner이면 소유권을 이전
+func (c *workspaceController) UpdateMember(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	memberUserID, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.UpdateWorkspaceMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	member, status, err := c.workspaceService.UpdateMember(ctx.Request.Context(), userID, workspaceID, memberUserID, &req)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publisher.Publish(realtime.EVENT_MEMBER_UPDATED, member, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(memberUserID))
+	ctx.JSON(status, gin.H{"member": member})
+}
+
+// RemoveMember는 구성원을 내보내거나, 자기 자신이면 작업 공간을 떠나는 메서드
+func (c *workspaceController) RemoveMember(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	workspaceID, err := strconv.Atoi(ctx.Param("workspaceID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	memberUserID, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	status, err := c.workspaceService.RemoveMember(ctx.Request.Context(), userID, workspaceID, memberUserID)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.publisher.Publish(realtime.EVENT_MEMBER_REMOVED, gin.H{"workspace_id": workspaceID, "user_id": memberUserID}, realtime.WorkspaceTopic(workspaceID), realtime.UserTopic(memberUserID))
+	c.revoker.Revoke(memberUserID, realtime.WorkspaceTopic(workspaceID))
+	ctx.Status(status)
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"lux-list/internal/database"
+	"lux-list/internal/middleware"
+	"lux-list/internal/realtime"
+	"lux-list/internal/service"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// WSController는 WebSocket 연결 관련 메서드를 정의하는 인터페이스
+type WSController interface {
+	Connect(c *gin.Context)
+}
+
+// wsController는 WSController 인터페이스를 구현하는 구조체
+type wsController struct {
+	hub              *realtime.Hub
+	taskService      service.TaskService
+	workspaceService service.WorkspaceService
+	upgrader         websocket.Upgrader
+}
+
+// RegisterWSRoutes는 WebSocket 관련 라우트를 등록하는 함수
+func RegisterWSRoutes(router *gin.RouterGroup, wsController WSController) {
+	router.GET("", wsController.Connect)
+}
+
+// NewWSController는 WSController의 인스턴스를 생성하는 함수
+func NewWSController(hub *realtime.Hub, taskService service.TaskService, workspaceService service.WorkspaceService) WSController {
+	return &wsController{
+		hub:              hub,
+		taskService:      taskService,
+		workspaceService: workspaceService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// 브라우저는 WebSocket에 CORS를 적용하지 않으므로 CORS 설정과 같은 Origin만 허용
+			CheckOrigin: checkWSOrigin,
+		},
+	}
+}
+
+// checkWSOrigin은 WebSocket 연결 요청의 Origin을 확인하는 함수
+// Origin 헤더가 없는 요청(브라우저가 아닌 클라이언트)과 같은 호스트의 요청, CORS_ALLOWED_ORIGINS의 Origin을 허용
+func checkWSOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || middleware.AllowedOrigin(origin) {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+// Connect는 HTTP 연결을 WebSocket으로 업그레이드하는 메서드
+func (c *wsController) Connect(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	conn, err := c.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// Upgrade가 이미 에러 응답을 작성함
+		return
+	}
+
+	c.hub.Register(conn, userID, c.authorizeTopic)
+}
+
+// authorizeTopic은 사용자가 토픽을 구독할 수 있는지 검사하는 메서드
+func (c *wsController) authorizeTopic(userID int, topic string) error {
+	kind, topicID, err := realtime.ParseTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	// 업그레이드 이후에는 요청 컨텍스트가 끝나므로 구독 요청마다 새 컨텍스트를 사용
+	queryCtx, cancel := database.WithQueryTimeout(context.Background())
+	defer cancel()
+
+	switch kind {
+	case realtime.TOPIC_USER:
+		if topicID != userID {
+			return errors.New("forbidden topic")
+		}
+		return nil
+	case realtime.TOPIC_WORKSPACE:
+		if _, _, err := c.workspaceService.GetWorkspace(queryCtx, userID, topicID); err != nil {
+			return err
+		}
+		return nil
+	case realtime.TOPIC_TASK:
+		// 작업이 속한 작업 공간을 찾은 뒤 구성원인지 확인
+		workspaceID, _, err := c.taskService.GetTaskWorkspaceID(queryCtx, topicID)
+		if err != nil {
+			return err
+		}
+		if _, _, err := c.taskService.GetTasksByTaskID(queryCtx, userID, workspaceID, topicID); err != nil {
+			return err
+		}
+		return nil
+	default:
+		return realtime.ErrInvalidTopic
+	}
+}
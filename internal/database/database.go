@@ -1,81 +1,154 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"lux-list/internal/config"
-	"path/filepath"
+	"net/url"
 	"strings"
 	"sync"
 
 	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// 저장소 드라이버 (DB_DRIVER)
+const (
+	DRIVER_POSTGRES = "postgres"
+	DRIVER_SQLITE   = "sqlite" // 단일 파일 (SQLITE_PATH)
+	DRIVER_MEMORY   = "memory" // 프로세스 메모리의 SQLite, 종료 시 데이터가 사라짐
+)
+
+// SQL 방언 (repository.NewDB, NewMigrator에 전달)
+const (
+	DIALECT_POSTGRES = "postgres"
+	DIALECT_SQLITE   = "sqlite"
 )
 
 var (
 	db_instance *sql.DB
+	db_err      error
 	once        sync.Once
 )
 
-// 데이터베이스 초기화 함수, 데이터베이스 연결 및 마이그레이션 수행
-func InitDB() error {
-	config := config.GetConfig()
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
-		config.Database.DB_HOST,
-		config.Database.DB_PORT,
-		config.Database.DB_USER,
-		config.Database.DB_PASSWORD,
-		config.Database.DB_NAME,
-		config.Database.SSL_MODE,
-		config.Database.TIMEZONE,
-	)
-
-	var err error
-	db_instance, err = sql.Open("postgres", dsn)
-	if err != nil {
-		return fmt.Errorf("failed to open db: %w", err)
+// Connect는 설정 된 드라이버로 데이터베이스에 연결하는 함수, 여러 번 호출해도 한 번만 연결함
+func Connect() error {
+	once.Do(func() {
+		db, err := Open(config.GetConfig().Database)
+		if err != nil {
+			db_err = err
+			return
+		}
+
+		if err := db.Ping(); err != nil {
+			db.Close()
+			db_err = fmt.Errorf("failed to ping db: %w", err)
+			return
+		}
+		db_instance = db
+	})
+	return db_err
+}
+
+// Open은 드라이버에 맞게 *sql.DB를 생성하는 함수, 전역 연결과 별개로 연결이 필요할 때(테스트 등) 사용
+func Open(cfg config.PostgresConfig) (*sql.DB, error) {
+	switch cfg.DRIVER {
+	case DRIVER_POSTGRES:
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
+			cfg.DB_HOST,
+			cfg.DB_PORT,
+			cfg.DB_USER,
+			cfg.DB_PASSWORD,
+			cfg.DB_NAME,
+			cfg.SSL_MODE,
+			cfg.TIMEZONE,
+		)
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open db: %w", err)
+		}
+		return db, nil
+	case DRIVER_SQLITE:
+		return openSQLite("file:" + cfg.SQLITE_PATH)
+	case DRIVER_MEMORY:
+		return openSQLite("file:lux-list?mode=memory")
 	}
+	return nil, fmt.Errorf("unknown DB_DRIVER: %s", cfg.DRIVER)
+}
+
+// openSQLite는 SQLite 데이터베이스를 여는 함수
+// 쓰기 트랜잭션끼리 SQLITE_BUSY로 충돌하지 않도록 커넥션을 하나만 사용함
+// 메모리 데이터베이스는 마지막 커넥션이 닫히면 사라지므로 커넥션을 계속 유지함
+func openSQLite(dsn string) (*sql.DB, error) {
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "foreign_keys(1)")
+	pragmas.Add("_pragma", "busy_timeout(5000)")
+	pragmas.Add("_pragma", "journal_mode(WAL)")
 
-	if err = db_instance.Ping(); err != nil {
-		return fmt.Errorf("failed to ping db: %w", err)
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
 	}
 
-	// 마이그레이션 sql 파일 경로 찾기
-	schemaPath := filepath.Join("internal", "database", "schema.sql")
-	schema, err := ioutil.ReadFile(schemaPath)
+	db, err := sql.Open("sqlite", dsn+separator+pragmas.Encode())
 	if err != nil {
-		return fmt.Errorf("failed to read schema.sql: %w", err)
+		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	return db, nil
+}
 
-	// 마이그레이션 sql 실행
-	stmts := strings.Split(string(schema), ";")
-	for _, stmt := range stmts {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
-		}
-		_, err := db_instance.Exec(stmt)
-		if err != nil {
-			// "already exists" 에러는 무시
-			if strings.Contains(err.Error(), "already exists") {
-				continue
-			}
-			log.Printf("Migration error: %v\nSQL: %s\n", err, stmt)
-			return fmt.Errorf("migration failed: %w", err)
-		}
+// Dialect는 설정 된 드라이버의 SQL 방언을 반환하는 함수
+func Dialect() string {
+	if config.GetConfig().Database.DRIVER == DRIVER_POSTGRES {
+		return DIALECT_POSTGRES
+	}
+	return DIALECT_SQLITE
+}
+
+// 데이터베이스 초기화 함수, 데이터베이스 연결 및 마이그레이션 수행
+// DB_AUTO_MIGRATE가 false면 연결만 하고 마이그레이션은 `migrate up` 명령으로 수행해야 함
+func InitDB() error {
+	if err := Connect(); err != nil {
+		return err
+	}
+	if !config.GetConfig().Database.AUTO_MIGRATE {
+		return nil
 	}
 
+	migrator, err := NewMigrator(db_instance, Dialect())
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+	}
 	return nil
 }
 
 // Returns the global DB connection
 func GetDB() *sql.DB {
-	once.Do(func() {
-		InitDB()
-		if db_instance == nil {
-			log.Fatal("DB instance is not initialized")
-		}
-	})
+	if err := Connect(); err != nil {
+		log.Fatalf("DB instance is not initialized: %v", err)
+	}
 	return db_instance
 }
+
+// WithQueryTimeout은 설정 된 DB 작업 제한 시간을 parent 컨텍스트에 적용하는 함수
+// 제한 시간이 0이면 parent를 취소 가능한 컨텍스트로만 감싸서 반환
+func WithQueryTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	timeout := config.GetConfig().Database.QUERY_TIMEOUT
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
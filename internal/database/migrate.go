@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFS embed.FS
+
+const (
+	// 동시에 실행 된 여러 인스턴스가 같은 마이그레이션을 적용하지 않도록 잡는 advisory lock 키
+	MIGRATION_LOCK_KEY = 7_146_110_033
+
+	CREATE_SCHEMA_MIGRATIONS_QUERY = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+	GET_APPLIED_MIGRATIONS_QUERY = "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version"
+	INSERT_MIGRATION_QUERY       = "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)"
+	DELETE_MIGRATION_QUERY       = "DELETE FROM schema_migrations WHERE version = $1"
+	ADVISORY_LOCK_QUERY          = "SELECT pg_advisory_lock($1)"
+	ADVISORY_UNLOCK_QUERY        = "SELECT pg_advisory_unlock($1)"
+)
+
+// 마이그레이션 상태
+const (
+	MIGRATION_STATE_APPLIED  = "applied"
+	MIGRATION_STATE_PENDING  = "pending"
+	MIGRATION_STATE_MODIFIED = "modified" // 적용 이후 파일 내용이 바뀜
+	MIGRATION_STATE_MISSING  = "missing"  // 데이터베이스에는 적용되어 있지만 파일이 없음
+)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrMissingDown      = errors.New("migration has no down script")
+	ErrUnknownDialect   = errors.New("unknown migration dialect")
+
+	// 파일 이름 형식: <version>_<name>.<up|down>.sql
+	migrationFileRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+)
+
+// Migration은 버전 하나에 해당하는 up/down 스크립트를 나타내는 구조체
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // up 스크립트의 sha256
+}
+
+// MigrationStatus는 마이그레이션 하나의 적용 상태를 나타내는 구조체
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// appliedMigration은 schema_migrations 테이블의 행을 나타내는 구조체
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator는 내장 된 마이그레이션을 적용하고 되돌리는 구조체
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []Migration
+}
+
+// NewMigrator는 방언(postgres, sqlite)에 맞는 내장 마이그레이션 파일을 읽어 Migrator를 생성하는 함수
+// 방언 별 마이그레이션은 같은 버전 번호가 같은 스키마 변경을 의미하도록 관리함
+func NewMigrator(db *sql.DB, dialect string) (*Migrator, error) {
+	if dialect != DIALECT_POSTGRES && dialect != DIALECT_SQLITE {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDialect, dialect)
+	}
+
+	migrations, err := loadMigrations(migrationFS, path.Join("migrations", dialect))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// loadMigrations는 디렉터리의 마이그레이션 파일을 버전 순으로 읽는 함수
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names: %s, %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up은 아직 적용되지 않은 마이그레이션을 버전 순으로 모두 적용하고, 적용 된 목록을 반환하는 메서드
+// 적용 된 마이그레이션의 파일 내용이 바뀐 경우 아무것도 적용하지 않고 ErrChecksumMismatch를 반환
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := getAppliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verifyChecksums(done); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := runMigration(ctx, conn, migration.Up, INSERT_MIGRATION_QUERY, migration.Version, migration.Name, migration.Checksum); err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down은 마지막으로 적용 된 마이그레이션부터 steps개를 되돌리고, 되돌린 목록을 반환하는 메서드
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := getAppliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verifyChecksums(done); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %d_%s", ErrMissingDown, migration.Version, migration.Name)
+			}
+			if err := runMigration(ctx, conn, migration.Down, DELETE_MIGRATION_QUERY, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status는 내장 된 마이그레이션과 데이터베이스에 적용 된 마이그레이션의 상태를 버전 순으로 반환하는 메서드
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := getAppliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[int64]struct{}, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = struct{}{}
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MIGRATION_STATE_PENDING}
+			if record, ok := done[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				status.AppliedAt = &appliedAt
+				status.State = MIGRATION_STATE_APPLIED
+				if record.Checksum != migration.Checksum {
+					status.State = MIGRATION_STATE_MODIFIED
+				}
+			}
+			statuses = append(statuses, status)
+		}
+
+		for version, record := range done {
+			if _, ok := known[version]; ok {
+				continue
+			}
+			appliedAt := record.AppliedAt
+			statuses = append(statuses, MigrationStatus{Version: version, Name: record.Name, State: MIGRATION_STATE_MISSING, AppliedAt: &appliedAt})
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Version < statuses[j].Version
+		})
+		return nil
+	})
+	return statuses, err
+}
+
+// verifyChecksums는 적용 된 마이그레이션의 파일 내용이 바뀌지 않았는지 확인하는 메서드
+func (m *Migrator) verifyChecksums(done map[int64]appliedMigration) error {
+	for _, migration := range m.migrations {
+		record, ok := done[migration.Version]
+		if ok && record.Checksum != migration.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// withLock은 전용 커넥션에서 advisory lock을 잡은 상태로 fn을 실행하는 메서드
+// advisory lock은 세션 단위이므로 커넥션 풀이 아닌 하나의 커넥션에서 잠금과 해제를 해야 함
+// SQLite는 하나의 프로세스만 파일을 사용하고 쓰기가 직렬화되므로 잠금 없이 실행
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect != DIALECT_POSTGRES {
+		if _, err := conn.ExecContext(ctx, CREATE_SCHEMA_MIGRATIONS_QUERY); err != nil {
+			return err
+		}
+		return fn(conn)
+	}
+
+	if _, err := conn.ExecContext(ctx, ADVISORY_LOCK_QUERY, MIGRATION_LOCK_KEY); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// ctx가 취소되었더라도 잠금은 해제해야 하므로 새 컨텍스트 사용
+		if _, unlockErr := conn.ExecContext(context.Background(), ADVISORY_UNLOCK_QUERY, MIGRATION_LOCK_KEY); unlockErr != nil && err == nil {
+			err = fmt.Errorf("failed to release migration lock: %w", unlockErr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, CREATE_SCHEMA_MIGRATIONS_QUERY); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// getAppliedMigrations는 schema_migrations 테이블에 기록 된 마이그레이션을 버전 별로 조회하는 함수
+func getAppliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, GET_APPLIED_MIGRATIONS_QUERY)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var record appliedMigration
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		done[record.Version] = record
+	}
+	return done, rows.Err()
+}
+
+// runMigration은 스크립트 실행과 schema_migrations 기록을 하나의 트랜잭션으로 처리하는 함수
+// 스크립트는 인자 없이 실행되므로 여러 문장을 그대로 실행할 수 있음 (PL/pgSQL 포함)
+func runMigration(ctx context.Context, conn *sql.Conn, script string, recordQuery string, recordArgs ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, recordQuery, recordArgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
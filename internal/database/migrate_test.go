@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"lux-list/internal/config"
+)
+
+// newTestMigrator는 임시 디렉터리의 빈 sqlite 데이터베이스로 Migrator를 만드는 함수
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	t.Helper()
+	db, err := Open(config.PostgresConfig{DRIVER: DRIVER_SQLITE, SQLITE_PATH: filepath.Join(t.TempDir(), "lux-list.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrator, err := NewMigrator(db, DIALECT_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrator.migrations) < 2 {
+		t.Fatalf("expected embedded migrations, got %d", len(migrator.migrations))
+	}
+	return migrator, db
+}
+
+// migrationVersions는 마이그레이션 목록의 버전을 반환하는 함수
+func migrationVersions(migrations []Migration) []int64 {
+	versions := make([]int64, 0, len(migrations))
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+// expectStates는 Status의 버전별 상태가 want와 같은지 확인하는 함수
+func expectStates(t *testing.T, migrator *Migrator, want map[int64]string) {
+	t.Helper()
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("expected %d statuses, got %+v", len(want), statuses)
+	}
+	for i, status := range statuses {
+		if i > 0 && statuses[i-1].Version >= status.Version {
+			t.Fatalf("expected statuses in version order, got %+v", statuses)
+		}
+		if status.State != want[status.Version] {
+			t.Fatalf("expected migration %d to be %s, got %s", status.Version, want[status.Version], status.State)
+		}
+		if (status.AppliedAt == nil) != (status.State == MIGRATION_STATE_PENDING) {
+			t.Fatalf("unexpected AppliedAt for migration %d: %v", status.Version, status.AppliedAt)
+		}
+	}
+}
+
+// allStates는 모든 내장 마이그레이션이 state인 상태 목록을 만드는 함수
+func allStates(migrator *Migrator, state string) map[int64]string {
+	states := make(map[int64]string, len(migrator.migrations))
+	for _, migration := range migrator.migrations {
+		states[migration.Version] = state
+	}
+	return states
+}
+
+// schemaSnapshot은 schema_migrations를 뺀 sqlite 스키마 정의를 이름 순으로 이어 붙여 반환하는 함수
+func schemaSnapshot(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	var schema sql.NullString
+	if err := db.QueryRow("SELECT group_concat(sql, ';') FROM (SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT IN ('schema_migrations', 'sqlite_sequence') ORDER BY type, name)").Scan(&schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema.String
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t)
+	migrations := migrator.migrations
+	last := migrations[len(migrations)-1]
+	expectStates(t, migrator, allStates(migrator, MIGRATION_STATE_PENDING))
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) != len(migrations) {
+		t.Fatalf("Up = (%v, %v), want all %d migrations", migrationVersions(applied), err, len(migrations))
+	}
+	expectStates(t, migrator, allStates(migrator, MIGRATION_STATE_APPLIED))
+	upSchema := schemaSnapshot(t, db)
+
+	// 다시 실행해도 아무것도 적용하지 않음
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("Up(again) = (%v, %v), want nothing applied", migrationVersions(applied), err)
+	}
+
+	// Down N은 마지막 버전부터 N개를 되돌림
+	reverted, err := migrator.Down(ctx, 2)
+	if err != nil || len(reverted) != 2 || reverted[0].Version != last.Version || reverted[1].Version != migrations[len(migrations)-2].Version {
+		t.Fatalf("Down(2) = (%v, %v), want the last 2 migrations in reverse order", migrationVersions(reverted), err)
+	}
+	states := allStates(migrator, MIGRATION_STATE_APPLIED)
+	states[last.Version] = MIGRATION_STATE_PENDING
+	states[migrations[len(migrations)-2].Version] = MIGRATION_STATE_PENDING
+	expectStates(t, migrator, states)
+	if schemaSnapshot(t, db) == upSchema {
+		t.Fatal("expected Down(2) to change the schema")
+	}
+
+	// 되돌린 마이그레이션만 다시 적용함
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 2 || applied[0].Version != migrations[len(migrations)-2].Version || applied[1].Version != last.Version {
+		t.Fatalf("Up(after Down) = (%v, %v), want the 2 reverted migrations", migrationVersions(applied), err)
+	}
+	if schemaSnapshot(t, db) != upSchema {
+		t.Fatal("expected the same schema after Down(2) and Up")
+	}
+
+	// 적용 된 수보다 많이 되돌리면 모두 되돌리고, 빈 스키마에서 다시 적용할 수 있음
+	reverted, err = migrator.Down(ctx, len(migrations)+1)
+	if err != nil || len(reverted) != len(migrations) {
+		t.Fatalf("Down(all) = (%v, %v), want all %d migrations", migrationVersions(reverted), err, len(migrations))
+	}
+	if schema := schemaSnapshot(t, db); schema != "" {
+		t.Fatalf("expected an empty schema after Down(all), got %s", schema)
+	}
+	expectStates(t, migrator, allStates(migrator, MIGRATION_STATE_PENDING))
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != len(migrations) {
+		t.Fatalf("Up(after Down all) = (%v, %v), want all %d migrations", migrationVersions(applied), err, len(migrations))
+	}
+	if schemaSnapshot(t, db) != upSchema {
+		t.Fatal("expected the same schema after Down(all) and Up")
+	}
+}
+
+func TestMigratorChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t)
+	migrations := migrator.migrations
+	first, last := migrations[0], migrations[len(migrations)-1]
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Down(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	// 적용 이후 파일 내용이 바뀐 것과 같음
+	if _, err := db.Exec("UPDATE schema_migrations SET checksum = 'modified' WHERE version = ?", first.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	// 바뀐 마이그레이션이 있으면 남은 마이그레이션도 적용하지 않고, 되돌리지도 않음
+	applied, err := migrator.Up(ctx)
+	if !errors.Is(err, ErrChecksumMismatch) || len(applied) != 0 {
+		t.Fatalf("Up = (%v, %v), want ErrChecksumMismatch", migrationVersions(applied), err)
+	}
+	reverted, err := migrator.Down(ctx, 1)
+	if !errors.Is(err, ErrChecksumMismatch) || len(reverted) != 0 {
+		t.Fatalf("Down = (%v, %v), want ErrChecksumMismatch", migrationVersions(reverted), err)
+	}
+
+	// 데이터베이스에만 있는 버전은 missing으로 표시
+	if _, err := db.Exec("INSERT INTO schema_migrations (version, name, checksum) VALUES (?, 'removed', 'checksum')", last.Version+1); err != nil {
+		t.Fatal(err)
+	}
+	states := allStates(migrator, MIGRATION_STATE_APPLIED)
+	states[first.Version] = MIGRATION_STATE_MODIFIED
+	states[last.Version] = MIGRATION_STATE_PENDING
+	states[last.Version+1] = MIGRATION_STATE_MISSING
+	expectStates(t, migrator, states)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+
+	migrations, err := loadMigrations(fstest.MapFS{
+		"m/0002_second.up.sql":   file("CREATE TABLE b (id INTEGER);"),
+		"m/0001_first.up.sql":    file("CREATE TABLE a (id INTEGER);"),
+		"m/0001_first.down.sql":  file("DROP TABLE a;"),
+		"m/0002_second.down.sql": file("DROP TABLE b;"),
+	}, "m")
+	if err != nil || len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("loadMigrations = (%+v, %v), want 2 migrations in version order", migrations, err)
+	}
+	// checksum은 up 스크립트의 sha256
+	sum := sha256.Sum256([]byte("CREATE TABLE a (id INTEGER);"))
+	if migrations[0].Name != "first" || migrations[0].Down != "DROP TABLE a;" || migrations[0].Checksum != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected migration %+v", migrations[0])
+	}
+
+	invalid := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"invalid file name", fstest.MapFS{"m/first.up.sql": file("SELECT 1;")}},
+		{"conflicting names", fstest.MapFS{"m/0001_first.up.sql": file("SELECT 1;"), "m/0001_other.down.sql": file("SELECT 1;")}},
+		{"missing up script", fstest.MapFS{"m/0001_first.down.sql": file("SELECT 1;")}},
+	}
+	for _, c := range invalid {
+		t.Run(c.name, func(t *testing.T) {
+			if migrations, err := loadMigrations(c.files, "m"); err == nil {
+				t.Fatalf("expected error, got %+v", migrations)
+			}
+		})
+	}
+}
@@ -1,3 +1,5 @@
+-- 기존 schema.sql로 생성 된 데이터베이스도 그대로 이어받을 수 있도록 IF NOT EXISTS 사용
+
 -- 사용자 테이블
 CREATE TABLE IF NOT EXISTS users (
     id SERIAL PRIMARY KEY,
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS sync_tombstones;
+
+DROP INDEX IF EXISTS idx_tags_user_change_seq;
+DROP INDEX IF EXISTS idx_tasks_user_change_seq;
+
+ALTER TABLE task_tags DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE tags DROP COLUMN IF EXISTS field_updated_at;
+ALTER TABLE tags DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE tasks DROP COLUMN IF EXISTS field_updated_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE users DROP COLUMN IF EXISTS change_seq;
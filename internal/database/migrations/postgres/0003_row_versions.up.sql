@@ -0,0 +1,3 @@
+-- 낙관적 동시성 제어용 버전 (ETag / If-Match)
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
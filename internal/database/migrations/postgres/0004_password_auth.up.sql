@@ -0,0 +1,18 @@
+-- 이메일 + 비밀번호 계정
+-- 이름만으로 가입한 기존 사용자는 email / password_hash가 NULL이며, 로그인 후 계정을 등록(claim)해야 함
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_updated_at TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+-- 비밀번호 재설정용 일회성 토큰 (원문이 아닌 SHA-256 해시만 저장)
+CREATE TABLE IF NOT EXISTS password_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('reset')),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_password_tokens_user ON password_tokens (user_id);
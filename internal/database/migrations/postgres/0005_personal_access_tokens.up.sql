@@ -0,0 +1,15 @@
+-- 스크립트와 외부 연동용 개인 액세스 토큰 (Authorization: Bearer)
+-- 토큰 원문은 생성 시 한 번만 보여주고 SHA-256 해시만 저장
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL, -- 목록에서 토큰을 구분하기 위한 앞부분
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT NOT NULL, -- 쉼표로 구분 된 권한 (예: 'tasks:read,tasks:write')
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    last_used_ip VARCHAR(45),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user ON personal_access_tokens (user_id);
@@ -0,0 +1,20 @@
+-- TOTP 2단계 인증
+-- secret은 로그인 검증에 원문이 필요하므로 TOTP_ENCRYPTION_KEY로 암호화하여 저장
+-- enabled_at이 NULL이면 등록 후 아직 코드를 확인하지 않은 상태 (로그인에 사용하지 않음)
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_encrypted TEXT NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- 같은 코드를 다시 사용하지 못하도록 마지막으로 사용한 시간 단계
+    enabled_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 인증 앱을 사용할 수 없을 때 쓰는 일회성 복구 코드 (원문이 아닌 SHA-256 해시만 저장)
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
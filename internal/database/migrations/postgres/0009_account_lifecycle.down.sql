@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS data_exports;
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS all_day;
+ALTER TABLE tasks ALTER COLUMN due_date TYPE TIMESTAMP USING due_date AT TIME ZONE 'UTC';
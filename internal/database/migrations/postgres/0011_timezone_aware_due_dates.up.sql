@@ -0,0 +1,6 @@
+-- 마감일을 시간대가 있는 시각으로 저장 (사용자 시간대로 해석/표시)
+-- 기존 값은 서버가 UTC로 읽어 응답해 왔으므로 UTC 기준으로 변환
+ALTER TABLE tasks ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date AT TIME ZONE 'UTC';
+
+-- 하루 종일 작업, due_date는 날짜만 의미하며 해당 날짜의 UTC 자정으로 저장
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS all_day BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+-- 개인 작업 공간의 변경 시퀀스를 사용자에게 돌려줌, 공유 작업 공간의 작업과 태그는 만든 사용자의 것으로 남음
+UPDATE users SET change_seq = (SELECT change_seq FROM workspaces WHERE personal_user_id = users.id)
+WHERE EXISTS (SELECT 1 FROM workspaces WHERE personal_user_id = users.id);
+
+DROP INDEX IF EXISTS idx_sync_tombstones_workspace_change_seq;
+DROP INDEX IF EXISTS idx_tags_workspace_change_seq;
+DROP INDEX IF EXISTS idx_tasks_workspace_change_seq;
+
+ALTER TABLE sync_tombstones DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tags DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
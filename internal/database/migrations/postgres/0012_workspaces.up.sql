@@ -0,0 +1,44 @@
+-- 작업 공간(팀)과 구성원, 작업과 태그는 사용자가 아닌 작업 공간에 속함
+-- 사용자마다 개인 작업 공간(personal_user_id)이 하나씩 있으며, 다른 구성원을 둘 수 없음
+-- 여러 사용자가 같은 데이터를 수정하므로 변경 시퀀스(delta sync)는 작업 공간 단위로 발급함 (users.change_seq는 더 이상 사용하지 않음)
+CREATE TABLE IF NOT EXISTS workspaces (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    personal_user_id INTEGER UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    change_seq BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 작업 공간 구성원, 작업 공간마다 owner는 한 명
+-- owner / admin은 구성원과 작업 공간을 관리하고, member는 작업과 태그를 수정하며, viewer는 조회만 할 수 있음
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'viewer')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user ON workspace_members (user_id);
+
+-- 기존 사용자의 개인 작업 공간, 사용자의 변경 시퀀스를 이어받아 발급한 동기화 토큰이 그대로 유효함
+INSERT INTO workspaces (name, personal_user_id, change_seq)
+SELECT 'Personal', id, change_seq FROM users
+WHERE NOT EXISTS (SELECT 1 FROM workspaces WHERE workspaces.personal_user_id = users.id);
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, personal_user_id, 'owner' FROM workspaces WHERE personal_user_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- 기존 작업, 태그, tombstone은 만든 사용자의 개인 작업 공간으로 옮김 (user_id는 만든 사용자로 남음)
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE sync_tombstones ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+
+UPDATE tasks SET workspace_id = (SELECT id FROM workspaces WHERE personal_user_id = tasks.user_id) WHERE workspace_id IS NULL;
+UPDATE tags SET workspace_id = (SELECT id FROM workspaces WHERE personal_user_id = tags.user_id) WHERE workspace_id IS NULL;
+UPDATE sync_tombstones SET workspace_id = (SELECT id FROM workspaces WHERE personal_user_id = sync_tombstones.user_id) WHERE workspace_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_change_seq ON tasks (workspace_id, change_seq);
+CREATE INDEX IF NOT EXISTS idx_tags_workspace_change_seq ON tags (workspace_id, change_seq);
+CREATE INDEX IF NOT EXISTS idx_sync_tombstones_workspace_change_seq ON sync_tombstones (workspace_id, change_seq);
@@ -0,0 +1,19 @@
+-- 작업 공간 초대, 사용자 이름으로 초대하면 invitee_user_id, 이메일로 초대하면 email(가입한 사용자면 invitee_user_id도)을 저장
+-- 가입하지 않은 이메일로 받은 초대는 같은 이메일로 가입한 뒤 수락할 수 있음
+-- 수락 / 거절 / 취소 된 초대는 기록으로 남기며, 만료는 expires_at으로 판단함
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    inviter_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    invitee_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'member', 'viewer')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'revoked')),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP,
+    CHECK (invitee_user_id IS NOT NULL OR email IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_workspace ON workspace_invitations (workspace_id, status);
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_invitee ON workspace_invitations (invitee_user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_email ON workspace_invitations (email) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+DELETE FROM password_tokens WHERE purpose <> 'reset';
+ALTER TABLE password_tokens DROP CONSTRAINT IF EXISTS password_tokens_purpose_check;
+ALTER TABLE password_tokens ADD CONSTRAINT password_tokens_purpose_check CHECK (purpose IN ('reset'));
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
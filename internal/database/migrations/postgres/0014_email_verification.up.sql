@@ -0,0 +1,11 @@
+-- 이메일 인증, 인증하지 않은 이메일은 외부 ID 공급자 계정 연결과 이메일 초대 수락에 사용하지 않음
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+-- 외부 ID 공급자가 인증한 이메일로 가입하거나 연결한 기존 사용자는 인증 된 것으로 처리
+UPDATE users SET email_verified_at = CURRENT_TIMESTAMP
+WHERE email IS NOT NULL
+  AND EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id AND user_identities.email = users.email);
+
+-- 관리자가 발급하는 계정 등록(claim) 토큰과 이메일 인증 토큰도 일회성 토큰으로 저장
+ALTER TABLE password_tokens DROP CONSTRAINT IF EXISTS password_tokens_purpose_check;
+ALTER TABLE password_tokens ADD CONSTRAINT password_tokens_purpose_check CHECK (purpose IN ('reset', 'claim', 'verify_email'));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS task_templates;
+DROP TABLE IF EXISTS users;
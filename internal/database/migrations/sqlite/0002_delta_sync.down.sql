@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS sync_tombstones;
+
+DROP INDEX IF EXISTS idx_tags_user_change_seq;
+DROP INDEX IF EXISTS idx_tasks_user_change_seq;
+
+ALTER TABLE task_tags DROP COLUMN change_seq;
+ALTER TABLE tags DROP COLUMN field_updated_at;
+ALTER TABLE tags DROP COLUMN change_seq;
+ALTER TABLE tasks DROP COLUMN field_updated_at;
+ALTER TABLE tasks DROP COLUMN change_seq;
+ALTER TABLE users DROP COLUMN change_seq;
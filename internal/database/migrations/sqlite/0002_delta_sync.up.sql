@@ -0,0 +1,22 @@
+-- 동기화용 사용자 단위 변경 시퀀스 (delta sync)
+ALTER TABLE users ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN field_updated_at TEXT NOT NULL DEFAULT '{}'; -- 필드 별 마지막 수정 시각 (last-writer-wins, JSON)
+ALTER TABLE tags ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tags ADD COLUMN field_updated_at TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE task_tags ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_tasks_user_change_seq ON tasks (user_id, change_seq);
+CREATE INDEX idx_tags_user_change_seq ON tags (user_id, change_seq);
+
+-- 삭제 된 엔티티 기록 테이블 (delta sync)
+CREATE TABLE sync_tombstones (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    entity VARCHAR(20) NOT NULL, -- 'task', 'tag', 'task_tag'
+    entity_id VARCHAR(50) NOT NULL, -- task_tag는 '<task_id>:<tag_id>'
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sync_tombstones_user_change_seq ON sync_tombstones (user_id, change_seq);
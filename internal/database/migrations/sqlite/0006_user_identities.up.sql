@@ -0,0 +1,13 @@
+-- 외부 ID 공급자(OpenID Connect) 계정과 사용자의 연결
+-- 같은 공급자(issuer)의 같은 계정(subject)은 한 사용자에게만 연결됨
+CREATE TABLE user_identities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255), -- 마지막 로그인 시 ID 공급자가 알려준 이메일 (표시용)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP,
+    UNIQUE (issuer, subject)
+);
+CREATE INDEX idx_user_identities_user ON user_identities (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_audit_logs;
+ALTER TABLE users DROP COLUMN disabled_at;
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,18 @@
+-- 사용자 역할과 계정 사용 중지
+-- admin은 /admin API를 사용할 수 있으며, disabled_at이 있는 계정은 로그인과 토큰 인증이 거부됨
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
+ALTER TABLE users ADD COLUMN disabled_at TIMESTAMP;
+
+-- 관리자 작업 기록 (사용자 사용 중지 / 해제, 강제 로그아웃, 대리 로그인 등)
+-- 사용자가 삭제 되어도 기록은 남도록 사용자 ID는 NULL로 바꿈
+CREATE TABLE admin_audit_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    target_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_admin_audit_logs_target ON admin_audit_logs (target_user_id, id);
+CREATE INDEX idx_admin_audit_logs_actor ON admin_audit_logs (actor_user_id, id);
@@ -0,0 +1,17 @@
+-- 계정 삭제 예약, 유예 기간이 지나면 정리 작업이 사용자와 모든 데이터를 삭제함
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMP;
+CREATE INDEX idx_users_deletion_scheduled_at ON users (deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- 사용자 데이터 내보내기 작업, 만든 ZIP 파일은 expires_at까지 content에 보관
+CREATE TABLE data_exports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    content BLOB,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+CREATE INDEX idx_data_exports_user ON data_exports (user_id, id);
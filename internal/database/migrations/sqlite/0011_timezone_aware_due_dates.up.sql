@@ -0,0 +1,15 @@
+-- SQLite는 시각을 문자열로 비교하므로 모든 마감일을 UTC 표기로 맞춤 ("2006-01-02 15:04:05 -0700 MST" 형식)
+-- 소수점 이하 초는 버림
+UPDATE tasks
+SET due_date = strftime(
+        '%Y-%m-%d %H:%M:%S',
+        substr(due_date, 1, 19),
+        printf('%+d minutes',
+            (CASE WHEN substr(due_date, instr(substr(due_date, 20), ' ') + 20, 1) = '-' THEN 1 ELSE -1 END)
+            * (CAST(substr(due_date, instr(substr(due_date, 20), ' ') + 21, 2) AS INTEGER) * 60
+               + CAST(substr(due_date, instr(substr(due_date, 20), ' ') + 23, 2) AS INTEGER)))
+    ) || ' +0000 UTC'
+WHERE due_date GLOB '* [+-][0-9][0-9][0-9][0-9]*' AND due_date NOT LIKE '% +0000 UTC';
+
+-- 하루 종일 작업, due_date는 날짜만 의미하며 해당 날짜의 UTC 자정으로 저장
+ALTER TABLE tasks ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,16 @@
+CREATE TABLE password_tokens_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('reset')),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO password_tokens_old (id, user_id, token_hash, purpose, expires_at, used_at, created_at)
+SELECT id, user_id, token_hash, purpose, expires_at, used_at, created_at FROM password_tokens WHERE purpose = 'reset';
+DROP TABLE password_tokens;
+ALTER TABLE password_tokens_old RENAME TO password_tokens;
+CREATE INDEX idx_password_tokens_user ON password_tokens (user_id);
+
+ALTER TABLE users DROP COLUMN email_verified_at;
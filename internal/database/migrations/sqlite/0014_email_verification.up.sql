@@ -0,0 +1,24 @@
+-- 이메일 인증, 인증하지 않은 이메일은 외부 ID 공급자 계정 연결과 이메일 초대 수락에 사용하지 않음
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+-- 외부 ID 공급자가 인증한 이메일로 가입하거나 연결한 기존 사용자는 인증 된 것으로 처리
+UPDATE users SET email_verified_at = CURRENT_TIMESTAMP
+WHERE email IS NOT NULL
+  AND EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id AND user_identities.email = users.email);
+
+-- 관리자가 발급하는 계정 등록(claim) 토큰과 이메일 인증 토큰도 일회성 토큰으로 저장
+-- sqlite는 CHECK 제약을 바꿀 수 없으므로 테이블을 다시 만듦
+CREATE TABLE password_tokens_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('reset', 'claim', 'verify_email')),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO password_tokens_new (id, user_id, token_hash, purpose, expires_at, used_at, created_at)
+SELECT id, user_id, token_hash, purpose, expires_at, used_at, created_at FROM password_tokens;
+DROP TABLE password_tokens;
+ALTER TABLE password_tokens_new RENAME TO password_tokens;
+CREATE INDEX idx_password_tokens_user ON password_tokens (user_id);
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"lux-list/internal/database"
+	"lux-list/pkg/types"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuthorizer는 사용자가 관리자인지 확인하는 인터페이스
+type AdminAuthorizer interface {
+	IsAdmin(ctx context.Context, userID int) (bool, error)
+}
+
+// AdminMiddleware는 관리자만 요청할 수 있게 하는 미들웨어, AuthMiddleware 다음에 사용해야 함
+// 역할을 바꾸면 바로 적용되도록 요청마다 저장소에서 확인하며, 대리 로그인 세션은 거부함
+func AdminMiddleware(authorizer AdminAuthorizer) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := utils.GetUserIDFromContext(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요한 서비스입니다."})
+			ctx.Abort()
+			return
+		}
+		if _, impersonating := ctx.Get(types.CONTEXT_IMPERSONATOR_ID); impersonating {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "administrator privileges are required"})
+			ctx.Abort()
+			return
+		}
+
+		authCtx, cancel := database.WithQueryTimeout(ctx.Request.Context())
+		defer cancel()
+
+		admin, err := authorizer.IsAdmin(authCtx, userID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			ctx.Abort()
+			return
+		}
+		if !admin {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "administrator privileges are required"})
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// NoImpersonationMiddleware는 관리자가 대리 로그인한 세션에서 계정 보안 설정(비밀번호, 2단계 인증, 토큰 등)을
+// 바꾸지 못하게 하는 미들웨어, AuthMiddleware 다음에 사용해야 함
+func NoImpersonationMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, impersonating := ctx.Get(types.CONTEXT_IMPERSONATOR_ID); impersonating {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "not allowed while impersonating a user", "code": "impersonation_forbidden"})
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
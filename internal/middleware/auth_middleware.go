@@ -1,31 +1,103 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
+	"strings"
+
+	"lux-list/internal/database"
+	"lux-list/internal/model"
 	"lux-list/pkg/auth"
 	"lux-list/pkg/redis"
 	"lux-list/pkg/types"
 	"lux-list/pkg/utils"
-	"net/http"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware는 인증 미들웨어를 정의하는 함수
+// TokenAuthenticator는 Authorization: Bearer 헤더의 개인 액세스 토큰을 검증하는 인터페이스
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, rawToken string, ip string) (*model.PersonalAccessToken, int, error)
+}
+
+// APIAuthMiddleware는 개인 액세스 토큰 또는 쿠키 세션으로 인증하는 미들웨어
+// Authorization: Bearer 헤더가 있으면 토큰으로 인증하고 resource에 대한 권한을 확인함
+// (GET / HEAD는 <resource>:read, 그 밖의 메서드는 <resource>:write)
+// 헤더가 없으면 AuthMiddleware와 같이 쿠키 세션으로 인증함
+func APIAuthMiddleware(authenticator TokenAuthenticator, resource string) gin.HandlerFunc {
+	sessionAuth := AuthMiddleware()
+	return func(ctx *gin.Context) {
+		rawToken, ok := bearerToken(ctx)
+		if !ok {
+			sessionAuth(ctx)
+			return
+		}
+
+		authCtx, cancel := database.WithQueryTimeout(ctx.Request.Context())
+		defer cancel()
+
+		token, status, err := authenticator.Authenticate(authCtx, rawToken, ctx.ClientIP())
+		if err != nil {
+			ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			ctx.Abort()
+			return
+		}
+
+		scope := model.Scope(resource, requiredAccess(ctx.Request.Method))
+		if !token.HasScope(scope) {
+			ctx.Header("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "access token does not have the " + scope + " scope"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(types.CONTEXT_USERID, token.UserID)
+		ctx.Set(types.CONTEXT_TOKEN_ID, token.ID)
+		ctx.Next()
+	}
+}
+
+// bearerToken은 Authorization: Bearer 헤더의 토큰을 가져오는 함수
+func bearerToken(ctx *gin.Context) (string, bool) {
+	header := ctx.GetHeader("Authorization")
+	if header == "" {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// requiredAccess는 HTTP 메서드에 필요한 권한 종류를 반환하는 함수
+func requiredAccess(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return model.SCOPE_ACCESS_READ
+	}
+	return model.SCOPE_ACCESS_WRITE
+}
+
+// AuthMiddleware는 인증 미들웨어를 정의하는 함수 (쿠키 세션 전용)
 func AuthMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		session := sessions.Default(ctx)
-		userID := session.Get(types.SESSION_USERID)
-		accessToken := session.Get(types.SESSION_ACCESS_TOKEN)
-		if userID == "" || accessToken == "" {
+		userID, _ := session.Get(types.SESSION_USERID).(int)
+		accessToken, _ := session.Get(types.SESSION_ACCESS_TOKEN).(string)
+		sessionID, _ := session.Get(types.SESSION_ID).(string)
+		if userID == 0 || accessToken == "" || sessionID == "" {
 			utils.ClearSession(ctx)
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요한 서비스입니다."})
 			ctx.Abort()
 			return
 		}
 
-		// Redis 세션에 저장 된 access_token과 비교하여 검증 ( 중복 로그인 방지 )
-		if auth_key, err := redis.GetAuthSession(ctx, userID); err != nil || auth_key == "" || auth_key != accessToken {
+		// 인증 세션 저장소의 세션과 비교하여 검증 ( 로그아웃 / 다른 기기에서 해제 된 세션 차단 )
+		authSession, err := redis.GetAuthSession(ctx, sessionID)
+		if err != nil || authSession.UserID != userID || authSession.TokenHash != auth.HashOpaqueToken(accessToken) {
 			utils.ClearSession(ctx)
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 세션이 만료되었습니다."})
 			ctx.Abort()
@@ -33,16 +105,23 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// JWT 검증 로직 추가
-		claims, err := auth.ValidateAndParseJWT(accessToken.(string))
+		// access token만 만료 된 경우 /auth/refresh로 갱신할 수 있도록 쿠키 세션(refresh token)은 유지
+		claims, err := auth.ValidateAndParseJWT(accessToken)
 		if err != nil {
-			utils.ClearSession(ctx)
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 세션이 만료되었습니다."})
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 세션이 만료되었습니다.", "code": "access_token_expired"})
 			ctx.Abort()
 			return
 		}
 
 		ctx.Set(types.CONTEXT_USERID, claims.UserID)
 		ctx.Set(types.CONTEXT_ACCESS_TOKEN, accessToken)
+		ctx.Set(types.CONTEXT_SESSION_ID, sessionID)
+		if authSession.ImpersonatorID != 0 {
+			ctx.Set(types.CONTEXT_IMPERSONATOR_ID, authSession.ImpersonatorID)
+		}
+
+		// 세션 목록에 표시할 마지막 사용 시각 갱신 (실패해도 요청은 계속 처리)
+		_ = redis.TouchAuthSession(ctx, sessionID, ctx.ClientIP())
 		ctx.Next()
 	}
 }
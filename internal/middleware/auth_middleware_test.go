@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/database"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/internal/service"
+	"lux-list/pkg/auth"
+	"lux-list/pkg/types"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+)
+
+// apiAuthTest는 sqlite 데이터베이스의 토큰 서비스와 APIAuthMiddleware를 사용하는 테스트 라우터
+type apiAuthTest struct {
+	router          *gin.Engine
+	tokenService    service.TokenService
+	tokenRepository repository.TokenRepository
+	userID          int
+}
+
+// newAPIAuthTest는 마이그레이션한 sqlite 데이터베이스와 사용자 한 명으로 테스트 라우터를 만드는 함수
+// /tasks와 /tags는 각각의 권한을 확인하고, 인증 된 사용자 ID로 응답함
+func newAPIAuthTest(t *testing.T) *apiAuthTest {
+	t.Helper()
+	sqlDB, err := database.Open(config.PostgresConfig{DRIVER: database.DRIVER_SQLITE, SQLITE_PATH: filepath.Join(t.TempDir(), "lux-list.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	migrator, err := database.NewMigrator(sqlDB, database.DIALECT_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	db := repository.NewDB(sqlDB, database.DIALECT_SQLITE)
+	user, err := repository.NewAuthRepository(db).CreateUserWithPassword(context.Background(), "token-user", "token-user@example.com", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRepository := repository.NewTokenRepository(db)
+	tokenService := service.NewTokenService(tokenRepository)
+	router := gin.New()
+	router.Use(sessions.Sessions(types.SESSION_COOKIE_NAME, cookie.NewStore([]byte("api-auth-test-session-key"))))
+	respond := func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, strconv.Itoa(ctx.GetInt(types.CONTEXT_USERID)))
+	}
+	for _, resource := range []string{model.SCOPE_RESOURCE_TASKS, model.SCOPE_RESOURCE_TAGS} {
+		group := router.Group("/" + resource)
+		group.Use(APIAuthMiddleware(tokenService, resource))
+		group.GET("", respond)
+		group.HEAD("", respond)
+		group.POST("", respond)
+		group.DELETE("", respond)
+	}
+	return &apiAuthTest{router: router, tokenService: tokenService, tokenRepository: tokenRepository, userID: user.ID}
+}
+
+// createToken은 scopes 권한을 가진 토큰을 발급하고 ID와 원문을 반환하는 메서드
+func (a *apiAuthTest) createToken(t *testing.T, scopes ...string) (int, string) {
+	t.Helper()
+	created, status, err := a.tokenService.CreateToken(context.Background(), a.userID, &model.CreatePersonalAccessTokenRequest{Name: "test", Scopes: scopes})
+	if err != nil {
+		t.Fatalf("CreateToken = (%d, %v)", status, err)
+	}
+	return created.ID, created.Token
+}
+
+// serve는 rawToken을 Bearer 헤더로 보내는 메서드, rawToken이 비어 있으면 헤더 없이 보냄
+func (a *apiAuthTest) serve(method string, path string, rawToken string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	if rawToken != "" {
+		request.Header.Set("Authorization", "Bearer "+rawToken)
+	}
+	recorder := httptest.NewRecorder()
+	a.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAPIAuthMiddlewareScopes(t *testing.T) {
+	a := newAPIAuthTest(t)
+	_, readToken := a.createToken(t, "tasks:read")
+	_, writeToken := a.createToken(t, "tasks:write", "tags:read")
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+		scope  string
+	}{
+		{"read token GET tasks", http.MethodGet, "/tasks", readToken, http.StatusOK, ""},
+		{"read token HEAD tasks", http.MethodHead, "/tasks", readToken, http.StatusOK, ""},
+		{"read token POST tasks", http.MethodPost, "/tasks", readToken, http.StatusForbidden, "tasks:write"},
+		{"read token DELETE tasks", http.MethodDelete, "/tasks", readToken, http.StatusForbidden, "tasks:write"},
+		{"read token GET tags", http.MethodGet, "/tags", readToken, http.StatusForbidden, "tags:read"},
+		{"read token POST tags", http.MethodPost, "/tags", readToken, http.StatusForbidden, "tags:write"},
+		// write 권한이 read 권한을 포함하지 않음
+		{"write token GET tasks", http.MethodGet, "/tasks", writeToken, http.StatusForbidden, "tasks:read"},
+		{"write token POST tasks", http.MethodPost, "/tasks", writeToken, http.StatusOK, ""},
+		{"write token GET tags", http.MethodGet, "/tags", writeToken, http.StatusOK, ""},
+		{"write token POST tags", http.MethodPost, "/tags", writeToken, http.StatusForbidden, "tags:write"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := a.serve(c.method, c.path, c.token)
+			if recorder.Code != c.status {
+				t.Fatalf("expected %d, got %d (%s)", c.status, recorder.Code, recorder.Body.String())
+			}
+			if c.status == http.StatusOK {
+				if c.method != http.MethodHead && recorder.Body.String() != strconv.Itoa(a.userID) {
+					t.Fatalf("expected user %d in context, got %s", a.userID, recorder.Body.String())
+				}
+				return
+			}
+			want := `Bearer error="insufficient_scope", scope="` + c.scope + `"`
+			if got := recorder.Header().Get("WWW-Authenticate"); got != want {
+				t.Fatalf("expected WWW-Authenticate %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestAPIAuthMiddlewareRejectsInvalidTokens(t *testing.T) {
+	a := newAPIAuthTest(t)
+	ctx := context.Background()
+
+	// 만료 된 토큰은 발급 API로 만들 수 없으므로 저장소에 직접 저장함
+	secret, _, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredToken := model.PERSONAL_ACCESS_TOKEN_PREFIX + secret
+	expiredAt := time.Now().UTC().Add(-time.Minute)
+	if _, err := a.tokenRepository.CreateToken(ctx, &model.PersonalAccessToken{
+		UserID:      a.userID,
+		Name:        "expired",
+		TokenPrefix: expiredToken[:12],
+		Scopes:      []string{"tasks:read"},
+		ExpiresAt:   &expiredAt,
+	}, auth.HashOpaqueToken(expiredToken)); err != nil {
+		t.Fatal(err)
+	}
+
+	revokedID, revokedToken := a.createToken(t, "tasks:read")
+	if recorder := a.serve(http.MethodGet, "/tasks", revokedToken); recorder.Code != http.StatusOK {
+		t.Fatalf("expected token to work before it is revoked, got %d", recorder.Code)
+	}
+	if status, err := a.tokenService.DeleteToken(ctx, a.userID, revokedID); err != nil {
+		t.Fatalf("DeleteToken = (%d, %v)", status, err)
+	}
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"expired", expiredToken},
+		{"revoked", revokedToken},
+		{"unknown", model.PERSONAL_ACCESS_TOKEN_PREFIX + "unknown"},
+		{"without prefix", "not-a-personal-access-token"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := a.serve(http.MethodGet, "/tasks", c.token)
+			if recorder.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d (%s)", recorder.Code, recorder.Body.String())
+			}
+			if got := recorder.Header().Get("WWW-Authenticate"); got != `Bearer error="invalid_token"` {
+				t.Fatalf("unexpected WWW-Authenticate %q", got)
+			}
+		})
+	}
+
+	// Bearer 헤더도 쿠키 세션도 없으면 401
+	if recorder := a.serve(http.MethodGet, "/tasks", ""); recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without credentials, got %d", recorder.Code)
+	}
+}
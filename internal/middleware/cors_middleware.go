@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"time"
+
+	"lux-list/internal/config"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSMiddleware는 CORS_ALLOWED_ORIGINS의 Origin에서만 쿠키를 포함한 요청을 허용하는 미들웨어
+func CORSMiddleware() gin.HandlerFunc {
+	return cors.New(cors.Config{
+		AllowOriginFunc:  AllowedOrigin,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "If-Match", "If-None-Match", CSRF_HEADER, WORKSPACE_HEADER},
+		ExposeHeaders:    []string{"Content-Length", "ETag", CSRF_HEADER, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	})
+}
+
+// AllowedOrigin은 origin이 CORS_ALLOWED_ORIGINS에 있는지 확인하는 함수 ("*"이면 모든 Origin 허용)
+func AllowedOrigin(origin string) bool {
+	for _, allowed := range config.GetConfig().CORS.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"lux-list/internal/config"
+	"lux-list/pkg/auth"
+	"lux-list/pkg/types"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// CSRF_HEADER는 상태를 바꾸는 요청에 CSRF 토큰을 담는 헤더
+const CSRF_HEADER = "X-CSRF-Token"
+
+// CSRFMiddleware는 쿠키 세션으로 보내는 상태 변경 요청(POST, PUT, PATCH, DELETE)에 CSRF 토큰을 요구하는 미들웨어
+// 토큰은 쿠키 세션에 저장하고(synchronizer token), 요청의 X-CSRF-Token 헤더와 비교함
+// 토큰은 GET /auth/csrf로 발급 받으며, 토큰이 있는 세션의 GET 응답에도 X-CSRF-Token 헤더로 보내줌
+// sessions 미들웨어 다음에 사용해야 함
+func CSRFMiddleware() gin.HandlerFunc {
+	csrfConfig := config.GetConfig().CSRF
+	if !csrfConfig.Enabled {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	return func(ctx *gin.Context) {
+		token, _ := sessions.Default(ctx).Get(types.SESSION_CSRF_TOKEN).(string)
+		if safeMethod(ctx.Request.Method) {
+			if token != "" {
+				ctx.Header(CSRF_HEADER, token)
+			}
+			ctx.Next()
+			return
+		}
+		if csrfExempt(ctx, csrfConfig) {
+			ctx.Next()
+			return
+		}
+
+		header := ctx.GetHeader(CSRF_HEADER)
+		if token == "" || subtle.ConstantTimeCompare([]byte(header), []byte(token)) != 1 {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "CSRF token is missing or invalid", "code": "csrf_token_invalid"})
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// EnsureCSRFToken은 쿠키 세션의 CSRF 토큰을 반환하는 함수, 없으면 새로 만들어 저장함
+func EnsureCSRFToken(ctx *gin.Context) (string, error) {
+	session := sessions.Default(ctx)
+	if token, _ := session.Get(types.SESSION_CSRF_TOKEN).(string); token != "" {
+		return token, nil
+	}
+
+	token, _, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	session.Set(types.SESSION_CSRF_TOKEN, token)
+	if err := session.Save(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// safeMethod는 상태를 바꾸지 않는 HTTP 메서드인지 확인하는 함수
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// csrfExempt는 CSRF 검사를 건너뛸 요청인지 확인하는 함수
+// 세션 쿠키 없이 Bearer 토큰으로 인증하는 요청은 브라우저가 자동으로 보낸 요청이 아니므로 제외할 수 있음
+func csrfExempt(ctx *gin.Context, csrfConfig config.CSRFConfig) bool {
+	for _, prefix := range csrfConfig.ExemptPaths {
+		if strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+			return true
+		}
+	}
+	if csrfConfig.ExemptBearer {
+		if _, ok := bearerToken(ctx); ok {
+			_, err := ctx.Request.Cookie(types.SESSION_COOKIE_NAME)
+			return err != nil
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/pkg/redis"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 요청 제한 대상 (RateLimitMiddleware의 scope)
+const (
+	RATE_LIMIT_SCOPE_IP   = "ip"   // 클라이언트 IP별
+	RATE_LIMIT_SCOPE_USER = "user" // 인증 된 사용자별, 인증 미들웨어 다음에 사용해야 함
+)
+
+// RateLimitMiddleware는 group 규칙(RATE_LIMIT_<GROUP>)으로 요청 수를 제한하는 미들웨어
+// 제한을 넘으면 429와 Retry-After 헤더를 반환하며, 모든 응답에 RateLimit-* 헤더를 붙임
+// 요청 제한이 꺼져 있거나 규칙의 limit이 0이면 아무것도 하지 않음
+// 저장소에 접근할 수 없으면 요청을 막지 않고 로그만 남김
+func RateLimitMiddleware(group string, scope string) gin.HandlerFunc {
+	rateLimitConfig := config.GetConfig().RateLimit
+	rule, ok := rateLimitConfig.Rules[group]
+	if !rateLimitConfig.Enabled || !ok || rule.Limit == 0 {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	return func(ctx *gin.Context) {
+		subject := ctx.ClientIP()
+		if scope == RATE_LIMIT_SCOPE_USER {
+			userID, err := utils.GetUserIDFromContext(ctx)
+			if err != nil {
+				ctx.Next()
+				return
+			}
+			subject = utils.InterfaceToString(userID)
+		}
+
+		limiter, err := redis.GetRateLimiter()
+		if err != nil {
+			log.Printf("rate limit %s: %v", group, err)
+			ctx.Next()
+			return
+		}
+		result, err := limiter.Allow(ctx.Request.Context(), redis.RateLimitKey(group+":"+scope, subject), rule)
+		if err != nil {
+			log.Printf("rate limit %s: %v", group, err)
+			ctx.Next()
+			return
+		}
+
+		if !RateLimitExceeded(ctx, result) {
+			ctx.Next()
+		}
+	}
+}
+
+// RateLimitExceeded는 요청 제한 결과를 RateLimit-* 헤더로 쓰고, 거부 된 요청이면 429로 응답하는 함수
+// 429로 응답했으면 true를 반환
+func RateLimitExceeded(ctx *gin.Context, result *redis.RateLimitResult) bool {
+	ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	if result.Allowed {
+		return false
+	}
+
+	ctx.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+	ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, try again later", "code": "rate_limited"})
+	ctx.Abort()
+	return true
+}
+
+// ceilSeconds는 시간을 초 단위로 올림하는 함수 (헤더 값은 정수 초)
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"lux-list/pkg/redis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitRouter는 새 메모리 요청 제한 저장소와 RateLimitMiddleware를 사용하는 테스트 라우터를 만드는 함수
+// X-User-ID 헤더가 있으면 인증 된 사용자로 취급함
+func newRateLimitRouter(t *testing.T, group string, scope string) *gin.Engine {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	redis.SetRateLimiter(redis.NewMemoryRateLimiter(ctx))
+
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		switch ctx.GetHeader("X-User-ID") {
+		case "1":
+			ctx.Set("userID", 1)
+		case "2":
+			ctx.Set("userID", 2)
+		}
+	})
+	router.Use(RateLimitMiddleware(group, scope))
+	router.GET("/resource", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	return router
+}
+
+// serveRateLimited는 remoteAddr와 userID로 요청을 보내는 함수
+func serveRateLimited(router *gin.Engine, remoteAddr string, userID string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/resource", nil)
+	request.RemoteAddr = remoteAddr
+	if userID != "" {
+		request.Header.Set("X-User-ID", userID)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// expectRateLimitHeaders는 응답 코드와 RateLimit-*, Retry-After 헤더 값을 확인하는 함수
+func expectRateLimitHeaders(t *testing.T, recorder *httptest.ResponseRecorder, status int, limit, remaining, reset, retryAfter string) {
+	t.Helper()
+	if recorder.Code != status {
+		t.Fatalf("expected %d, got %d (%s)", status, recorder.Code, recorder.Body.String())
+	}
+	headers := map[string]string{
+		"RateLimit-Limit":     limit,
+		"RateLimit-Remaining": remaining,
+		"RateLimit-Reset":     reset,
+		"Retry-After":         retryAfter,
+	}
+	for name, want := range headers {
+		if got := recorder.Header().Get(name); got != want {
+			t.Fatalf("expected %s %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareIP(t *testing.T) {
+	// RATE_LIMIT_TASKS=2/1m
+	router := newRateLimitRouter(t, "tasks", RATE_LIMIT_SCOPE_IP)
+
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1000", ""), http.StatusOK, "2", "1", "60", "")
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1001", ""), http.StatusOK, "2", "0", "60", "")
+	recorder := serveRateLimited(router, "192.0.2.1:1002", "")
+	expectRateLimitHeaders(t, recorder, http.StatusTooManyRequests, "2", "0", "60", "60")
+	if recorder.Body.String() != `{"code":"rate_limited","error":"Too many requests, try again later"}` {
+		t.Fatalf("unexpected body %s", recorder.Body.String())
+	}
+
+	// 다른 IP는 따로 셈
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.2:1000", ""), http.StatusOK, "2", "1", "60", "")
+}
+
+func TestRateLimitMiddlewareUser(t *testing.T) {
+	router := newRateLimitRouter(t, "tasks", RATE_LIMIT_SCOPE_USER)
+
+	// 같은 사용자는 IP가 달라도 함께 셈
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1000", "1"), http.StatusOK, "2", "1", "60", "")
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.2:1000", "1"), http.StatusOK, "2", "0", "60", "")
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.3:1000", "1"), http.StatusTooManyRequests, "2", "0", "60", "60")
+
+	// 같은 IP의 다른 사용자는 따로 셈
+	expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1000", "2"), http.StatusOK, "2", "1", "60", "")
+
+	// 인증 되지 않은 요청은 제한하지 않음 (인증 미들웨어가 거부함)
+	for i := 0; i < 3; i++ {
+		expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1000", ""), http.StatusOK, "", "", "", "")
+	}
+}
+
+func TestRateLimitMiddlewareDisabledRule(t *testing.T) {
+	// limit이 0인 규칙(RATE_LIMIT_TAGS=0/1m)과 규칙이 없는 그룹은 제한하지 않음
+	for _, group := range []string{"tags", "unknown"} {
+		router := newRateLimitRouter(t, group, RATE_LIMIT_SCOPE_IP)
+		for i := 0; i < 3; i++ {
+			expectRateLimitHeaders(t, serveRateLimited(router, "192.0.2.1:1000", ""), http.StatusOK, "", "", "", "")
+		}
+	}
+}
+
+func TestRateLimitExceededHeaders(t *testing.T) {
+	// 헤더 값은 정수 초로 올림함
+	cases := []struct {
+		name   string
+		result redis.RateLimitResult
+		status int
+		reset  string
+		retry  string
+	}{
+		{"allowed", redis.RateLimitResult{Allowed: true, Limit: 10, Remaining: 4, ResetAfter: 1500 * time.Millisecond}, http.StatusOK, "2", ""},
+		{"empty window", redis.RateLimitResult{Allowed: true, Limit: 10, Remaining: 10}, http.StatusOK, "0", ""},
+		{"denied", redis.RateLimitResult{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 30*time.Second + time.Microsecond, RetryAfter: 30*time.Second + time.Microsecond}, http.StatusTooManyRequests, "31", "31"},
+		{"denied on the second", redis.RateLimitResult{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 5 * time.Second, RetryAfter: 5 * time.Second}, http.StatusTooManyRequests, "5", "5"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			result := c.result
+			if exceeded := RateLimitExceeded(ctx, &result); exceeded != !c.result.Allowed || ctx.IsAborted() != exceeded {
+				t.Fatalf("RateLimitExceeded = %v (aborted %v), want %v", exceeded, ctx.IsAborted(), !c.result.Allowed)
+			}
+			expectRateLimitHeaders(t, recorder, c.status, "10", strconv.Itoa(c.result.Remaining), c.reset, c.retry)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"lux-list/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryTimeoutMiddleware는 요청 컨텍스트에 DB 작업 제한 시간을 적용하는 미들웨어
+// 클라이언트 연결이 끊기거나 제한 시간이 지나면 진행 중인 쿼리가 취소됨
+func QueryTimeoutMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		queryCtx, cancel := database.WithQueryTimeout(ctx.Request.Context())
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(queryCtx)
+		ctx.Next()
+	}
+}
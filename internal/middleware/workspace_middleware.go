@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lux-list/internal/database"
+	"lux-list/internal/model"
+	"lux-list/pkg/types"
+	"lux-list/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WORKSPACE_HEADER는 요청이 적용될 작업 공간을 고르는 헤더
+const WORKSPACE_HEADER = "X-Workspace-ID"
+
+// WorkspaceResolver는 사용자가 구성원인 작업 공간을 조회하는 인터페이스
+type WorkspaceResolver interface {
+	GetWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, error)
+	GetPersonalWorkspace(ctx context.Context, userID int) (*model.Workspace, error)
+}
+
+// WorkspaceMiddleware는 X-Workspace-ID 헤더의 작업 공간(없으면 개인 작업 공간)을 Context에 설정하는 미들웨어,
+// AuthMiddleware / APIAuthMiddleware 다음에 사용해야 함
+// 구성원이 아니면 403, viewer가 조회 이외의 요청을 보내면 403을 반환
+func WorkspaceMiddleware(resolver WorkspaceResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := utils.GetUserIDFromContext(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요한 서비스입니다."})
+			ctx.Abort()
+			return
+		}
+
+		authCtx, cancel := database.WithQueryTimeout(ctx.Request.Context())
+		defer cancel()
+
+		var workspace *model.Workspace
+		if header := ctx.GetHeader(WORKSPACE_HEADER); header != "" {
+			workspaceID, convErr := strconv.Atoi(header)
+			if convErr != nil || workspaceID <= 0 {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + WORKSPACE_HEADER + " header"})
+				ctx.Abort()
+				return
+			}
+			workspace, err = resolver.GetWorkspace(authCtx, userID, workspaceID)
+		} else {
+			workspace, err = resolver.GetPersonalWorkspace(authCtx, userID)
+		}
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusForbidden, gin.H{"error": "workspace access denied"})
+			} else {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			ctx.Abort()
+			return
+		}
+
+		if requiredAccess(ctx.Request.Method) == model.SCOPE_ACCESS_WRITE && !model.CanEditWorkspace(workspace.Role) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "workspace access denied", "code": "workspace_read_only"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(types.CONTEXT_WORKSPACE_ID, workspace.ID)
+		ctx.Set(types.CONTEXT_WORKSPACE_ROLE, workspace.Role)
+		ctx.Next()
+	}
+}
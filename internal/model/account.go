@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// 데이터 내보내기 작업 상태
+const (
+	DATA_EXPORT_STATUS_PENDING   = "pending"
+	DATA_EXPORT_STATUS_RUNNING   = "running"
+	DATA_EXPORT_STATUS_COMPLETED = "completed"
+	DATA_EXPORT_STATUS_FAILED    = "failed"
+)
+
+// DataExport는 사용자 데이터 내보내기 작업 (ZIP 파일 내용은 포함하지 않음)
+type DataExport struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"-"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	SizeBytes   int64      `json:"size_bytes"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"` // 완료 된 파일을 내려받을 수 있는 마지막 시각
+}
+
+// IsActive는 대기 중이거나 만들고 있는 작업인지 확인하는 메서드
+func (e *DataExport) IsActive() bool {
+	return e.Status == DATA_EXPORT_STATUS_PENDING || e.Status == DATA_EXPORT_STATUS_RUNNING
+}
+
+// IsStale은 제한 시간이 지나도 끝나지 않은 작업인지 확인하는 메서드 (작업 중 서버가 종료 된 경우)
+func (e *DataExport) IsStale(now time.Time, timeout time.Duration) bool {
+	return e.IsActive() && now.Sub(e.CreatedAt) > timeout
+}
+
+// IsDownloadable은 완료 되어 아직 내려받을 수 있는 작업인지 확인하는 메서드
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DATA_EXPORT_STATUS_COMPLETED && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// AccountExportData는 내보내기 ZIP 파일에 담을 사용자의 모든 데이터
+type AccountExportData struct {
+	Profile       AccountExportProfile
+	Workspaces    []Workspace // 사용자가 구성원인 작업 공간, 작업과 태그는 이 작업 공간들의 것
+	Tasks         []Task      // 각 작업의 Tags 포함
+	Tags          []Tag
+	TaskTemplates []TaskTemplate
+	Preferences   UserPreferences
+}
+
+// AccountExportProfile은 내보내기 파일의 profile.json 내용
+type AccountExportProfile struct {
+	ID                   int                   `json:"id"`
+	Name                 string                `json:"name"`
+	Email                string                `json:"email"`
+	Role                 string                `json:"role"`
+	CreatedAt            time.Time             `json:"created_at"`
+	TwoFactorEnabled     bool                  `json:"two_factor_enabled"`
+	Identities           []UserIdentity        `json:"identities"`
+	PersonalAccessTokens []PersonalAccessToken `json:"personal_access_tokens"` // 토큰 값은 저장하지 않으므로 포함 되지 않음
+	ExportedAt           time.Time             `json:"exported_at"`
+}
+
+// DeleteAccountRequest는 계정 삭제 요청 구조체
+// 확인을 위해 사용자 이름을 그대로 입력해야 하며, 비밀번호를 등록한 계정은 비밀번호,
+// 2단계 인증을 켠 계정은 TOTP 코드 또는 복구 코드도 필요함
+type DeleteAccountRequest struct {
+	Confirm  string `json:"confirm"`
+	Password string `json:"password"`
+	TwoFactorCode
+}
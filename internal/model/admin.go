@@ -0,0 +1,105 @@
+package model
+
+import "time"
+
+// 관리자 작업 기록의 action
+const (
+	ADMIN_ACTION_USER_DISABLE        = "user_disable"
+	ADMIN_ACTION_USER_ENABLE         = "user_enable"
+	ADMIN_ACTION_USER_LOGOUT         = "user_logout" // 모든 세션 강제 로그아웃
+	ADMIN_ACTION_ROLE_CHANGE         = "role_change"
+	ADMIN_ACTION_IMPERSONATION_START = "impersonation_start"
+	ADMIN_ACTION_IMPERSONATION_END   = "impersonation_end"
+	ADMIN_ACTION_CLAIM_TOKEN_ISSUE   = "claim_token_issue" // 기존 사용자의 계정 등록 토큰 발급
+)
+
+// 관리자 목록 조회 제한
+const (
+	ADMIN_LIST_DEFAULT_LIMIT = 50
+	ADMIN_LIST_MAX_LIMIT     = 200
+	// 대리 로그인 사유 최대 길이
+	IMPERSONATION_REASON_MAX_LENGTH = 500
+)
+
+// AdminUser는 관리자 API의 사용자 응답
+type AdminUser struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	DisabledAt *time.Time `json:"disabled_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Usage      *UserUsage `json:"usage,omitempty"` // 사용자 상세 조회에만 포함
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+}
+
+// NewAdminUser는 사용자를 관리자 API 응답으로 변환하는 함수
+func NewAdminUser(user *User) AdminUser {
+	return AdminUser{
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		Role:       user.Role,
+		DisabledAt: user.DisabledAt,
+		CreatedAt:  user.CreatedAt,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+	}
+}
+
+// ClaimTokenResponse는 관리자가 발급한 계정 등록 토큰 응답
+// 토큰은 이 응답에서만 볼 수 있으며, 관리자가 앱 밖의 수단으로 사용자에게 전달해야 함
+type ClaimTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AdminUserFilter는 관리자 사용자 목록 검색 조건
+type AdminUserFilter struct {
+	Query    string // 이름 또는 이메일에 포함 된 문자열 (대소문자 무시)
+	Role     string
+	Disabled *bool
+	Limit    int
+	Page     int
+}
+
+// AdminUserListResult는 관리자 사용자 목록 조회 결과
+type AdminUserListResult struct {
+	Users      []AdminUser `json:"users"`
+	TotalCount int         `json:"total_count"`
+}
+
+// UserUsage는 사용자별 사용량
+type UserUsage struct {
+	Tasks                int  `json:"tasks"`
+	CompletedTasks       int  `json:"completed_tasks"`
+	Tags                 int  `json:"tags"`
+	PersonalAccessTokens int  `json:"personal_access_tokens"`
+	Identities           int  `json:"identities"`
+	ActiveSessions       int  `json:"active_sessions"`
+	TwoFactorEnabled     bool `json:"two_factor_enabled"`
+}
+
+// AdminAuditLog는 관리자 작업 기록 하나
+type AdminAuditLog struct {
+	ID           int       `json:"id"`
+	ActorUserID  *int      `json:"actor_user_id"`  // 작업한 관리자, 삭제 된 사용자면 null
+	TargetUserID *int      `json:"target_user_id"` // 대상 사용자, 삭제 된 사용자면 null
+	Action       string    `json:"action"`
+	Detail       string    `json:"detail"`
+	IP           string    `json:"ip"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SetUserRoleRequest는 사용자 역할 변경 요청 구조체
+type SetUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ImpersonateRequest는 대리 로그인 요청 구조체, 사유는 작업 기록에 남음
+type ImpersonateRequest struct {
+	Reason string `json:"reason"`
+}
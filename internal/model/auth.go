@@ -1,13 +1,177 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// 비밀번호 길이 제한
+const (
+	PASSWORD_MIN_LENGTH = 8
+	PASSWORD_MAX_LENGTH = 256
+)
+
+// 일회성 비밀번호 토큰 용도
+const (
+	PASSWORD_TOKEN_RESET        = "reset"
+	PASSWORD_TOKEN_CLAIM        = "claim"        // 관리자가 발급하는 기존 사용자의 계정 등록 토큰
+	PASSWORD_TOKEN_VERIFY_EMAIL = "verify_email" // 이메일 인증 토큰
+)
+
+// 사용자 역할
+const (
+	USER_ROLE_USER  = "user"
+	USER_ROLE_ADMIN = "admin" // /admin API 사용 가능
+)
 
 type User struct {
-	ID        int       `db:"id"`
-	Name      string    `db:"name"`
-	CreatedAt time.Time `db:"created_at"`
+	ID         int        `db:"id"`
+	Name       string     `db:"name"`
+	Email      string     `db:"email"` // 이름만으로 가입한 기존 사용자는 계정 등록 전까지 비어있음
+	Role       string     `db:"role"`
+	DisabledAt *time.Time `db:"disabled_at"` // 관리자가 사용 중지한 계정이면 중지한 시각
+	CreatedAt  time.Time  `db:"created_at"`
+
+	DeletionScheduledAt *time.Time `db:"deletion_scheduled_at"` // 계정 삭제를 요청했으면 실제로 삭제 될 시각
+	EmailVerifiedAt     *time.Time `db:"email_verified_at"`     // 이메일 인증을 마쳤으면 인증한 시각
+}
+
+// IsAdmin은 관리자 역할인지 확인하는 메서드
+func (u *User) IsAdmin() bool {
+	return u.Role == USER_ROLE_ADMIN
+}
+
+// IsDisabled는 관리자가 사용 중지한 계정인지 확인하는 메서드
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// IsEmailVerified는 이메일 인증을 마친 사용자인지 확인하는 메서드
+func (u *User) IsEmailVerified() bool {
+	return u.Email != "" && u.EmailVerifiedAt != nil
 }
 
+// ValidUserRole은 사용자 역할이 올바른지 확인하는 함수
+func ValidUserRole(role string) bool {
+	return role == USER_ROLE_USER || role == USER_ROLE_ADMIN
+}
+
+// UserCredentials는 로그인 검증에 필요한 사용자 정보와 비밀번호 해시
+type UserCredentials struct {
+	User
+	PasswordHash string // 비어있으면 아직 계정을 등록하지 않은 기존 사용자 또는 외부 ID 공급자로만 가입한 사용자
+	HasIdentity  bool   // 외부 ID 공급자 계정이 연결 되어 있는지 여부
+}
+
+// HasPassword는 비밀번호가 설정 된 사용자인지 확인하는 메서드
+func (c *UserCredentials) HasPassword() bool {
+	return c.PasswordHash != ""
+}
+
+// LoginRequest는 로그인 요청 구조체
+// 비밀번호 계정은 email + password, 계정 등록 전의 기존 사용자는 name만 사용
 type LoginRequest struct {
-	Name string `json:"name"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"` // 세션 목록에 표시할 기기 이름 (선택)
+
+	// 2단계 인증을 켠 계정은 TOTP 코드 또는 복구 코드가 필요함
+	TwoFactorCode
+}
+
+// RegisterRequest는 이메일 + 비밀번호 회원가입 요청 구조체
+type RegisterRequest struct {
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"` // 세션 목록에 표시할 기기 이름 (선택)
+}
+
+// ClaimAccountRequest는 이름만으로 가입한 기존 사용자가 관리자에게 받은 토큰으로 이메일과 비밀번호를 등록하는 요청 구조체
+type ClaimAccountRequest struct {
+	Token      string `json:"token"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"` // 세션 목록에 표시할 기기 이름 (선택)
+}
+
+// VerifyEmailRequest는 이메일 인증 토큰으로 이메일을 인증하는 요청 구조체
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// ChangePasswordRequest는 비밀번호 변경 요청 구조체
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// PasswordResetRequest는 비밀번호 재설정 메일 요청 구조체
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// ConfirmPasswordResetRequest는 재설정 토큰으로 비밀번호를 바꾸는 요청 구조체
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// NormalizeEmail은 비교와 저장을 위해 이메일을 정규화하는 함수
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail은 이메일 형식을 검사하는 함수
+func ValidateEmail(email string) error {
+	if email == "" {
+		return errors.New("email is required")
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 255 {
+		return errors.New("email must be a valid address")
+	}
+	return nil
+}
+
+// ValidatePassword는 비밀번호 길이를 검사하는 함수
+func ValidatePassword(password string) error {
+	if len(password) < PASSWORD_MIN_LENGTH {
+		return errors.New("password must be at least 8 characters")
+	}
+	if len(password) > PASSWORD_MAX_LENGTH {
+		return errors.New("password must be at most 256 characters")
+	}
+	return nil
+}
+
+// CheckValidRegisterRequest는 RegisterRequest의 유효성을 검사하고 이메일을 정규화하는 메서드
+func (r *RegisterRequest) CheckValidRegisterRequest() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Email = NormalizeEmail(r.Email)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if err := ValidateEmail(r.Email); err != nil {
+		return err
+	}
+	return ValidatePassword(r.Password)
+}
+
+// CheckValidClaimAccountRequest는 ClaimAccountRequest의 유효성을 검사하고 이메일을 정규화하는 메서드
+func (r *ClaimAccountRequest) CheckValidClaimAccountRequest() error {
+	if r.Token == "" {
+		return errors.New("token is required")
+	}
+	r.Email = NormalizeEmail(r.Email)
+	if err := ValidateEmail(r.Email); err != nil {
+		return err
+	}
+	return ValidatePassword(r.Password)
 }
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+)
+
+// 일괄 작업 종류
+const (
+	BULK_ACTION_COMPLETE     = "complete"
+	BULK_ACTION_INCOMPLETE   = "incomplete"
+	BULK_ACTION_DELETE       = "delete"
+	BULK_ACTION_SET_PRIORITY = "set_priority"
+	BULK_ACTION_ADD_TAG      = "add_tag"
+	BULK_ACTION_REMOVE_TAG   = "remove_tag"
+)
+
+// 일괄 작업 항목 별 결과
+const (
+	BULK_STATUS_UPDATED   = "updated"
+	BULK_STATUS_DELETED   = "deleted"
+	BULK_STATUS_UNCHANGED = "unchanged"
+	BULK_STATUS_NOT_FOUND = "not_found"
+)
+
+const (
+	// 한 번에 처리할 수 있는 최대 작업 수
+	MAX_BULK_TASKS = 1000
+)
+
+// BulkTaskFilter는 GetTasks와 같은 조건으로 일괄 작업 대상을 선택하기 위한 구조체
+type BulkTaskFilter struct {
+	Title       *string `json:"title"`
+	IsCompleted *bool   `json:"is_completed"`
+	Priority    *string `json:"priority"`
+	DueDate     *string `json:"due_date"` // YYYY-MM-DD (사용자 시간대 기준 하루)
+	Due         *string `json:"due"`      // "today", "overdue"
+}
+
+// BulkTaskRequest는 작업 일괄 처리를 위한 요청 구조체
+type BulkTaskRequest struct {
+	Action   string          `json:"action"`
+	TaskIDs  []int           `json:"task_ids"`
+	Filter   *BulkTaskFilter `json:"filter"`
+	Priority *string         `json:"priority"` // set_priority 전용
+	TagID    *int            `json:"tag_id"`   // add_tag, remove_tag 전용
+}
+
+// BulkTaskResult는 작업 하나의 일괄 처리 결과를 나타내는 구조체
+type BulkTaskResult struct {
+	TaskID int    `json:"task_id"`
+	Status string `json:"status"`
+	Task   *Task  `json:"task,omitempty"`
+}
+
+// BulkTaskResponse는 일괄 처리 결과를 담는 구조체
+type BulkTaskResponse struct {
+	Committed bool             `json:"committed"`
+	Results   []BulkTaskResult `json:"results"`
+}
+
+// CheckValidBulkTaskRequest는 BulkTaskRequest의 유효성을 검사하는 메서드
+func (r *BulkTaskRequest) CheckValidBulkTaskRequest() error {
+	switch r.Action {
+	case BULK_ACTION_COMPLETE, BULK_ACTION_INCOMPLETE, BULK_ACTION_DELETE:
+	case BULK_ACTION_SET_PRIORITY:
+		if r.Priority == nil || (*r.Priority != PRIORITY_LOW && *r.Priority != PRIORITY_MEDIUM && *r.Priority != PRIORITY_HIGH) {
+			return errors.New("priority must be 'low', 'medium', or 'high'")
+		}
+	case BULK_ACTION_ADD_TAG, BULK_ACTION_REMOVE_TAG:
+		if r.TagID == nil || *r.TagID <= 0 {
+			return errors.New("tag_id is required")
+		}
+	default:
+		return errors.New("unknown action: " + r.Action)
+	}
+
+	if (len(r.TaskIDs) == 0) == (r.Filter == nil) {
+		return errors.New("exactly one of task_ids or filter is required")
+	}
+	if len(r.TaskIDs) > MAX_BULK_TASKS {
+		return errors.New("too many task_ids (max " + strconv.Itoa(MAX_BULK_TASKS) + ")")
+	}
+	if r.Filter != nil && len(r.Filter.ToSearchQuery()) == 0 {
+		return errors.New("filter must contain at least one condition")
+	}
+	return nil
+}
+
+// ToSearchQuery는 필터를 GetTasks와 같은 형식의 검색 쿼리로 변환하는 메서드
+func (f *BulkTaskFilter) ToSearchQuery() map[string]interface{} {
+	query := make(map[string]interface{})
+	if f.Title != nil && *f.Title != "" {
+		query["title"] = *f.Title
+	}
+	if f.IsCompleted != nil {
+		query["is_completed"] = *f.IsCompleted
+	}
+	if f.Priority != nil && *f.Priority != "" {
+		query["priority"] = *f.Priority
+	}
+	if f.DueDate != nil && *f.DueDate != "" {
+		query["due_date"] = *f.DueDate
+	}
+	if f.Due != nil && *f.Due != "" {
+		query["due"] = *f.Due
+	}
+	return query
+}
@@ -0,0 +1,171 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 마감일 필터 (GET /tasks?due=)
+const (
+	TASK_DUE_TODAY   = "today"
+	TASK_DUE_OVERDUE = "overdue"
+)
+
+// due_date 입력 형식
+const (
+	DUE_DATE_LAYOUT          = "2006-01-02"          // 날짜만 (하루 종일 작업)
+	DUE_DATE_FLOATING_LAYOUT = "2006-01-02T15:04:05" // 시간대 없는 시각 (사용자 시간대로 해석)
+)
+
+var errInvalidDueDate = errors.New("due_date must be an RFC 3339 time, a local time (YYYY-MM-DDTHH:MM:SS) or a date (YYYY-MM-DD)")
+
+// DueDateInput은 요청의 due_date 값
+// RFC 3339 시각, 시간대 없는 시각(사용자 설정 시간대로 해석), 날짜(하루 종일 작업)를 받음
+type DueDateInput struct {
+	wall     time.Time // 입력한 날짜와 시각 (시간대가 없으면 UTC로 파싱한 벽시계 값)
+	floating bool      // 시간대 없이 입력 됨
+	dateOnly bool      // 날짜만 입력 됨
+}
+
+// NewDueDateInput은 저장된 작업의 마감일을 입력 값으로 변환하는 함수 (PATCH 문서용)
+// 하루 종일 작업은 날짜로, 그 외에는 사용자 시간대의 시각으로 표현함
+func NewDueDateInput(dueDate time.Time, allDay bool, loc *time.Location) DueDateInput {
+	if allDay {
+		return DueDateInput{wall: dueDate.UTC(), floating: true, dateOnly: true}
+	}
+	return DueDateInput{wall: dueDate.In(loc)}
+}
+
+// UnmarshalJSON은 세 가지 형식 중 하나로 due_date를 파싱하는 메서드
+func (d *DueDateInput) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return errInvalidDueDate
+	}
+	return d.parse(value)
+}
+
+// MarshalJSON은 입력 받은 형식 그대로 due_date를 문자열로 만드는 메서드
+func (d DueDateInput) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.dateOnly:
+		return json.Marshal(d.wall.Format(DUE_DATE_LAYOUT))
+	case d.floating:
+		return json.Marshal(d.wall.Format(DUE_DATE_FLOATING_LAYOUT))
+	}
+	return json.Marshal(d.wall.Format(time.RFC3339Nano))
+}
+
+// parse는 문자열 due_date를 파싱하는 메서드
+func (d *DueDateInput) parse(value string) error {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		*d = DueDateInput{wall: t}
+		return nil
+	}
+	if t, err := time.Parse(DUE_DATE_FLOATING_LAYOUT, value); err == nil {
+		*d = DueDateInput{wall: t, floating: true}
+		return nil
+	}
+	if t, err := time.Parse(DUE_DATE_LAYOUT, value); err == nil {
+		*d = DueDateInput{wall: t, floating: true, dateOnly: true}
+		return nil
+	}
+	return errInvalidDueDate
+}
+
+// IsZero는 값이 입력되지 않았는지 확인하는 메서드
+func (d *DueDateInput) IsZero() bool {
+	return d == nil || d.wall.IsZero()
+}
+
+// IsDateOnly는 시각 없이 날짜만 입력 되었는지 확인하는 메서드
+func (d *DueDateInput) IsDateOnly() bool {
+	return d.dateOnly
+}
+
+// IsFloating은 시간대 없이 입력 되었는지 확인하는 메서드
+func (d *DueDateInput) IsFloating() bool {
+	return d.floating
+}
+
+// Resolve는 입력 값을 저장할 UTC 시각으로 변환하는 메서드
+// 하루 종일 작업은 입력한 날짜의 UTC 자정, 시간대 없는 시각은 loc 기준으로 해석함
+func (d *DueDateInput) Resolve(loc *time.Location, allDay bool) time.Time {
+	if allDay {
+		return DueDateOf(d.wall)
+	}
+	if d.floating {
+		year, month, day := d.wall.Date()
+		hour, min, sec := d.wall.Clock()
+		return time.Date(year, month, day, hour, min, sec, d.wall.Nanosecond(), loc).UTC()
+	}
+	return d.wall.UTC()
+}
+
+// DueDateOf는 t의 벽시계 날짜를 하루 종일 작업의 저장 값(해당 날짜의 UTC 자정)으로 변환하는 함수
+func DueDateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TaskDueRange는 사용자 시간대 기준 하루에 마감인 작업을 찾기 위한 범위
+// 시각이 있는 작업은 [Start, End), 하루 종일 작업은 [DateStart, DateEnd)로 비교
+type TaskDueRange struct {
+	Start     time.Time
+	End       time.Time
+	DateStart time.Time
+	DateEnd   time.Time
+}
+
+// NewTaskDueRange는 date의 날짜를 loc 기준 하루로 해석한 범위를 만드는 함수
+func NewTaskDueRange(date time.Time, loc *time.Location) TaskDueRange {
+	year, month, day := date.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	dateStart := DueDateOf(date)
+	return TaskDueRange{
+		Start:     start.UTC(),
+		End:       start.AddDate(0, 0, 1).UTC(), // 일광 절약 시간으로 하루가 24시간이 아닐 수 있음
+		DateStart: dateStart,
+		DateEnd:   dateStart.AddDate(0, 0, 1),
+	}
+}
+
+// TaskOverdue는 사용자 시간대 기준으로 마감이 지난 미완료 작업을 찾기 위한 기준
+// 시각이 있는 작업은 Now 이전, 하루 종일 작업은 오늘(Today) 이전 날짜이면 마감이 지난 것
+type TaskOverdue struct {
+	Now   time.Time
+	Today time.Time
+}
+
+// ResolveTaskDueFilters는 검색 쿼리의 due, due_date 값을 loc 기준 범위로 바꾸는 함수
+// due_date는 YYYY-MM-DD 날짜, due는 "today" 또는 "overdue"만 허용
+func ResolveTaskDueFilters(search_query map[string]interface{}, loc *time.Location, now time.Time) error {
+	if value, ok := search_query["due_date"]; ok {
+		date, err := time.Parse(DUE_DATE_LAYOUT, value.(string))
+		if err != nil {
+			return errors.New("due_date filter must be a date (YYYY-MM-DD)")
+		}
+		search_query["due_date"] = NewTaskDueRange(date, loc)
+	}
+
+	value, ok := search_query["due"]
+	if !ok {
+		return nil
+	}
+	delete(search_query, "due")
+
+	today := now.In(loc)
+	switch value.(string) {
+	case TASK_DUE_TODAY:
+		if _, ok := search_query["due_date"]; ok {
+			return errors.New("due and due_date filters cannot be used together")
+		}
+		search_query["due_date"] = NewTaskDueRange(today, loc)
+	case TASK_DUE_OVERDUE:
+		search_query["overdue"] = TaskOverdue{Now: now.UTC(), Today: DueDateOf(today)}
+	default:
+		return errors.New("due filter must be 'today' or 'overdue'")
+	}
+	return nil
+}
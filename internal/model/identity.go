@@ -0,0 +1,75 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// OIDC 로그인 시작부터 콜백까지 상태를 보관하는 시간
+	OIDC_LOGIN_STATE_TTL = 10 * time.Minute
+	// OIDC 로그인 후 2단계 인증 코드를 기다리는 시간
+	OIDC_TWO_FACTOR_TTL = 5 * time.Minute
+)
+
+// UserIdentity는 외부 ID 공급자(OpenID Connect) 계정과 사용자의 연결
+type UserIdentity struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"-"`
+	Issuer      string     `json:"issuer"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// OIDCLoginState는 ID 공급자로 이동한 뒤 콜백에서 검증할 로그인 상태
+// state로 찾으며, 한 번 사용하면 삭제됨
+type OIDCLoginState struct {
+	State        string    `json:"state"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"` // PKCE
+	DeviceName   string    `json:"device_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OIDCPendingLogin은 ID 공급자 로그인은 끝났지만 2단계 인증 코드를 기다리는 로그인
+// ID는 쿠키 세션에 저장하며, 코드를 확인하면 삭제됨
+type OIDCPendingLogin struct {
+	ID         string    `json:"id"`
+	UserID     int       `json:"user_id"`
+	DeviceName string    `json:"device_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OIDCClaims는 ID 토큰에서 계정 연결에 사용하는 클레임
+type OIDCClaims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// VerifiedEmail은 ID 공급자가 인증한 이메일을 정규화하여 반환하는 메서드, 인증되지 않았으면 빈 문자열
+func (c *OIDCClaims) VerifiedEmail() string {
+	if !c.EmailVerified {
+		return ""
+	}
+	email := NormalizeEmail(c.Email)
+	if ValidateEmail(email) != nil {
+		return ""
+	}
+	return email
+}
+
+// UserNameCandidate는 새로 가입하는 사용자의 이름 후보를 반환하는 메서드
+// preferred_username, name, 이메일 앞부분 순서로 사용
+func (c *OIDCClaims) UserNameCandidate() string {
+	for _, candidate := range []string{c.PreferredUsername, c.Name, strings.Split(c.Email, "@")[0]} {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			return candidate
+		}
+	}
+	return "user"
+}
@@ -0,0 +1,237 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"time"
+	_ "time/tzdata" // 시스템에 시간대 데이터가 없는 환경(컨테이너 등)에서도 IANA 시간대를 검사하기 위해 포함
+)
+
+// 화면 테마
+const (
+	THEME_SYSTEM = "system" // 운영체제 설정을 따름
+	THEME_LIGHT  = "light"
+	THEME_DARK   = "dark"
+)
+
+// 작업 목록 기본 정렬
+const (
+	SORT_DUE_DATE_ASC    = "due_date_asc"
+	SORT_DUE_DATE_DESC   = "due_date_desc"
+	SORT_CREATED_AT_ASC  = "created_at_asc"
+	SORT_CREATED_AT_DESC = "created_at_desc"
+	SORT_PRIORITY_DESC   = "priority_desc"
+	SORT_TITLE_ASC       = "title_asc"
+)
+
+// 사용자 설정 기본값과 제한
+const (
+	DEFAULT_PREFERENCES_TIMEZONE       = "UTC"
+	DEFAULT_PREFERENCES_LOCALE         = "ko-KR"
+	DEFAULT_PREFERENCES_WEEK_START_DAY = "sunday"
+	DEFAULT_REMINDER_MINUTES_BEFORE    = 30
+	DEFAULT_DAILY_DIGEST_TIME          = "08:00"
+	// 마감 알림은 최대 7일 전까지 설정 가능
+	MAX_REMINDER_MINUTES_BEFORE = 7 * 24 * 60
+	LOCALE_MAX_LENGTH           = 35
+)
+
+var (
+	preferenceThemes = []string{THEME_SYSTEM, THEME_LIGHT, THEME_DARK}
+	preferenceSorts  = []string{SORT_DUE_DATE_ASC, SORT_DUE_DATE_DESC, SORT_CREATED_AT_ASC, SORT_CREATED_AT_DESC, SORT_PRIORITY_DESC, SORT_TITLE_ASC}
+	weekDays         = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	// BCP 47 언어 태그의 간단한 형식 (예: ko, ko-KR, zh-Hant-TW)
+	localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+)
+
+// UserPreferences는 사용자별 설정 문서
+// 저장된 문서에 없는 필드는 서버의 기본값으로 채워서 응답함
+type UserPreferences struct {
+	Theme           string                  `json:"theme"`            // "system", "light", "dark"
+	Timezone        string                  `json:"timezone"`         // IANA 시간대 (예: Asia/Seoul)
+	Locale          string                  `json:"locale"`           // BCP 47 언어 태그 (예: ko-KR)
+	WeekStartDay    string                  `json:"week_start_day"`   // "sunday" ~ "saturday"
+	DefaultPriority string                  `json:"default_priority"` // 새 작업의 기본 우선순위
+	DefaultSort     string                  `json:"default_sort"`     // 작업 목록 기본 정렬
+	Notifications   NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences는 알림 설정
+type NotificationPreferences struct {
+	Email                 bool   `json:"email"`
+	Push                  bool   `json:"push"`
+	DueReminders          bool   `json:"due_reminders"`           // 마감 전 알림
+	ReminderMinutesBefore int    `json:"reminder_minutes_before"` // 마감 몇 분 전에 알릴지 (0이면 마감 시각)
+	DailyDigest           bool   `json:"daily_digest"`            // 오늘 할 일 요약
+	DailyDigestTime       string `json:"daily_digest_time"`       // 요약을 보낼 시각 (사용자 시간대 기준 HH:MM)
+}
+
+// UserPreferencesResult는 사용자 설정 조회/수정 응답
+type UserPreferencesResult struct {
+	Preferences UserPreferences `json:"preferences"`
+	Version     int             `json:"-"`          // 낙관적 동시성 제어용 버전 (ETag), 저장한 적이 없으면 0
+	UpdatedAt   *time.Time      `json:"updated_at"` // 저장한 적이 없으면 null
+}
+
+// StoredPreferences는 저장된 사용자 설정 문서 (기본값이 적용되지 않은 원본 JSON)
+type StoredPreferences struct {
+	Document  []byte
+	Version   int
+	UpdatedAt time.Time
+}
+
+// DefaultUserPreferences는 서버 기본 설정을 반환하는 함수
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		Theme:           THEME_SYSTEM,
+		Timezone:        DEFAULT_PREFERENCES_TIMEZONE,
+		Locale:          DEFAULT_PREFERENCES_LOCALE,
+		WeekStartDay:    DEFAULT_PREFERENCES_WEEK_START_DAY,
+		DefaultPriority: PRIORITY_MEDIUM,
+		DefaultSort:     SORT_DUE_DATE_DESC,
+		Notifications: NotificationPreferences{
+			Email:                 true,
+			Push:                  false,
+			DueReminders:          true,
+			ReminderMinutesBefore: DEFAULT_REMINDER_MINUTES_BEFORE,
+			DailyDigest:           false,
+			DailyDigestTime:       DEFAULT_DAILY_DIGEST_TIME,
+		},
+	}
+}
+
+// NewUserPreferencesResult는 저장된 문서에 기본값을 적용하여 응답을 만드는 함수, 저장한 적이 없으면(stored가 nil) 기본 설정을 반환
+// 알 수 없는 필드는 무시하고, 유효하지 않은 값은 기본값으로 되돌림
+func NewUserPreferencesResult(stored *StoredPreferences) (*UserPreferencesResult, error) {
+	result := &UserPreferencesResult{Preferences: DefaultUserPreferences()}
+	if stored == nil {
+		return result, nil
+	}
+
+	if err := json.Unmarshal(stored.Document, &result.Preferences); err != nil {
+		return nil, err
+	}
+	result.Preferences.Normalize()
+	result.Version = stored.Version
+	result.UpdatedAt = &stored.UpdatedAt
+	return result, nil
+}
+
+// UserLocation은 사용자 설정의 시간대를 반환하는 메서드, 불러올 수 없으면 UTC를 반환
+func (p *UserPreferences) UserLocation() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// CheckValidUserPreferences는 설정 문서의 유효성을 검사하는 메서드
+func (p *UserPreferences) CheckValidUserPreferences() error {
+	for _, check := range p.fieldChecks() {
+		if err := check.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Normalize는 저장된 문서에서 더 이상 유효하지 않은 값(삭제 된 시간대, 없어진 선택지 등)을 기본값으로 되돌리는 메서드
+func (p *UserPreferences) Normalize() {
+	for _, check := range p.fieldChecks() {
+		if check.validate() != nil {
+			check.reset()
+		}
+	}
+}
+
+// preferenceFieldCheck는 설정 필드 하나의 검사와 기본값 복원 함수
+type preferenceFieldCheck struct {
+	validate func() error
+	reset    func()
+}
+
+// fieldChecks는 설정 필드별 검사 규칙을 반환하는 메서드
+func (p *UserPreferences) fieldChecks() []preferenceFieldCheck {
+	defaults := DefaultUserPreferences()
+	return []preferenceFieldCheck{
+		{
+			validate: func() error {
+				if !slices.Contains(preferenceThemes, p.Theme) {
+					return errors.New("theme must be 'system', 'light', or 'dark'")
+				}
+				return nil
+			},
+			reset: func() { p.Theme = defaults.Theme },
+		},
+		{
+			validate: func() error {
+				// time.LoadLocation은 빈 문자열과 "Local"을 서버 시간대로 해석하므로 따로 거부
+				if p.Timezone == "" || p.Timezone == "Local" {
+					return errors.New("timezone must be an IANA time zone name")
+				}
+				if _, err := time.LoadLocation(p.Timezone); err != nil {
+					return errors.New("timezone must be an IANA time zone name")
+				}
+				return nil
+			},
+			reset: func() { p.Timezone = defaults.Timezone },
+		},
+		{
+			validate: func() error {
+				if len(p.Locale) > LOCALE_MAX_LENGTH || !localePattern.MatchString(p.Locale) {
+					return errors.New("locale must be a BCP 47 language tag (e.g. 'ko-KR')")
+				}
+				return nil
+			},
+			reset: func() { p.Locale = defaults.Locale },
+		},
+		{
+			validate: func() error {
+				if !slices.Contains(weekDays, p.WeekStartDay) {
+					return errors.New("week_start_day must be a lowercase day of the week (e.g. 'sunday')")
+				}
+				return nil
+			},
+			reset: func() { p.WeekStartDay = defaults.WeekStartDay },
+		},
+		{
+			validate: func() error {
+				if p.DefaultPriority != PRIORITY_LOW && p.DefaultPriority != PRIORITY_MEDIUM && p.DefaultPriority != PRIORITY_HIGH {
+					return errors.New("default_priority must be 'low', 'medium', or 'high'")
+				}
+				return nil
+			},
+			reset: func() { p.DefaultPriority = defaults.DefaultPriority },
+		},
+		{
+			validate: func() error {
+				if !slices.Contains(preferenceSorts, p.DefaultSort) {
+					return errors.New("default_sort must be one of 'due_date_asc', 'due_date_desc', 'created_at_asc', 'created_at_desc', 'priority_desc', 'title_asc'")
+				}
+				return nil
+			},
+			reset: func() { p.DefaultSort = defaults.DefaultSort },
+		},
+		{
+			validate: func() error {
+				if p.Notifications.ReminderMinutesBefore < 0 || p.Notifications.ReminderMinutesBefore > MAX_REMINDER_MINUTES_BEFORE {
+					return errors.New("notifications.reminder_minutes_before must be between 0 and 10080")
+				}
+				return nil
+			},
+			reset: func() { p.Notifications.ReminderMinutesBefore = defaults.Notifications.ReminderMinutesBefore },
+		},
+		{
+			validate: func() error {
+				if _, err := time.Parse("15:04", p.Notifications.DailyDigestTime); err != nil {
+					return errors.New("notifications.daily_digest_time must be in HH:MM format")
+				}
+				return nil
+			},
+			reset: func() { p.Notifications.DailyDigestTime = defaults.Notifications.DailyDigestTime },
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+const (
+	// 기기 이름 최대 길이
+	SESSION_DEVICE_NAME_MAX_LENGTH = 100
+	// 재사용 감지를 위해 보관하는 이전 refresh token 해시 수
+	SESSION_USED_REFRESH_HASHES_MAX = 20
+)
+
+// AuthSession은 로그인한 기기 하나의 인증 세션
+// 세션 하나가 refresh token family 하나: 로그인할 때마다 새 세션(family)을 만들고, 갱신해도 세션 ID는 그대로이며 토큰만 바뀜
+// 비밀번호 변경처럼 세션을 다시 발급할 때는 이전 세션을 모두 삭제하므로 family가 여러 세션에 걸치지 않음
+type AuthSession struct {
+	ID         string    `json:"id"`
+	UserID     int       `json:"user_id"`
+	TokenHash  string    `json:"token_hash,omitempty"` // access_token의 SHA-256 해시 (응답에는 포함하지 않음)
+	DeviceName string    `json:"device_name"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	// 현재 refresh token의 해시, 갱신할 때마다 바뀜
+	RefreshTokenHash string `json:"refresh_token_hash,omitempty"`
+	// 이미 사용한 refresh token의 해시, 다시 제시되면 탈취로 보고 세션(= family 전체)을 해제함
+	// 최근 SESSION_USED_REFRESH_HASHES_MAX개만 보관하며, 더 오래된 토큰은 해제하지 않고 올바르지 않은 토큰으로 거부
+	UsedRefreshHashes []string `json:"used_refresh_hashes,omitempty"`
+	// 마지막으로 refresh token을 교체한 시각, 직전 토큰을 재사용 유예 시간 동안 허용하는 데 사용
+	RefreshRotatedAt *time.Time `json:"refresh_rotated_at,omitempty"`
+
+	// 관리자가 대리 로그인한 세션이면 관리자의 사용자 ID
+	ImpersonatorID int `json:"impersonator_id,omitempty"`
+	// 갱신해도 늘어나지 않는 세션 만료 시각 (대리 로그인 세션), nil이면 refresh token TTL만 적용
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// IsExpired는 now 기준으로 세션의 고정 만료 시각이 지났는지 확인하는 메서드
+func (s *AuthSession) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// RotateRefreshToken은 현재 refresh token을 사용 처리하고 새 토큰 해시로 교체하는 메서드
+func (s *AuthSession) RotateRefreshToken(refreshTokenHash string, now time.Time) {
+	if s.RefreshTokenHash != "" {
+		s.UsedRefreshHashes = append(s.UsedRefreshHashes, s.RefreshTokenHash)
+		if len(s.UsedRefreshHashes) > SESSION_USED_REFRESH_HASHES_MAX {
+			s.UsedRefreshHashes = s.UsedRefreshHashes[len(s.UsedRefreshHashes)-SESSION_USED_REFRESH_HASHES_MAX:]
+		}
+	}
+	s.RefreshTokenHash = refreshTokenHash
+	s.RefreshRotatedAt = &now
+}
+
+// IsPreviousRefreshToken은 refreshTokenHash가 직전 refresh token이고 교체한 지 grace가 지나지 않았는지 확인하는 메서드
+// 같은 토큰으로 거의 동시에 갱신한 다른 탭의 요청을 재사용으로 보지 않기 위해 사용
+func (s *AuthSession) IsPreviousRefreshToken(refreshTokenHash string, now time.Time, grace time.Duration) bool {
+	if len(s.UsedRefreshHashes) == 0 || s.RefreshRotatedAt == nil {
+		return false
+	}
+	return s.UsedRefreshHashes[len(s.UsedRefreshHashes)-1] == refreshTokenHash && now.Sub(*s.RefreshRotatedAt) < grace
+}
+
+// IsUsedRefreshToken은 refreshTokenHash가 이미 사용한 refresh token인지 확인하는 메서드
+func (s *AuthSession) IsUsedRefreshToken(refreshTokenHash string) bool {
+	for _, used := range s.UsedRefreshHashes {
+		if used == refreshTokenHash {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthSessionResponse는 세션 목록 응답 항목
+type AuthSessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"` // 요청을 보낸 세션인지 여부
+
+	ImpersonatorID int `json:"impersonator_id,omitempty"` // 관리자가 대리 로그인한 세션이면 관리자의 사용자 ID
+}
+
+// NewAuthSessionResponse는 세션을 응답 항목으로 변환하는 함수
+func NewAuthSessionResponse(session *AuthSession, currentSessionID string) AuthSessionResponse {
+	return AuthSessionResponse{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		Current:    session.ID == currentSessionID,
+
+		ImpersonatorID: session.ImpersonatorID,
+	}
+}
@@ -0,0 +1,290 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 동기화 대상 엔티티
+const (
+	SYNC_ENTITY_TASK     = "task"
+	SYNC_ENTITY_TAG      = "tag"
+	SYNC_ENTITY_TASK_TAG = "task_tag"
+)
+
+// 동기화 변경 작업
+const (
+	SYNC_OP_UPSERT = "upsert"
+	SYNC_OP_DELETE = "delete"
+)
+
+// 동기화 변경 적용 결과
+const (
+	SYNC_STATUS_APPLIED  = "applied"
+	SYNC_STATUS_CONFLICT = "conflict"
+	SYNC_STATUS_ERROR    = "error"
+)
+
+// 동기화 토큰 형식 버전, 시퀀스의 의미가 바뀌면 올려서 이전 토큰을 가진 클라이언트가 전체 동기화를 다시 하도록 함
+// 1: 사용자 단위 시퀀스 (버전 없이 숫자만), 2: 작업 공간 단위 시퀀스 (<버전>.<작업 공간 ID>.<시퀀스>)
+const (
+	SYNC_TOKEN_VERSION = 2
+)
+
+var (
+	// ErrInvalidSyncToken은 형식이 올바르지 않은 동기화 토큰일 때 반환되는 에러
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	// ErrSyncTokenExpired는 이전 버전이나 다른 작업 공간의 동기화 토큰일 때 반환되는 에러, since 없이 전체 동기화를 다시 해야 함
+	ErrSyncTokenExpired = errors.New("sync token is no longer valid, perform a full sync without since")
+)
+
+// 클라이언트 updated_at이 서버 시각보다 앞서도 허용하는 범위, 넘으면 요청을 거부함
+const SYNC_MAX_CLOCK_SKEW = time.Minute
+
+// 필드 단위 last-writer-wins 비교 대상 필드
+var (
+	SyncTaskFields = []string{"title", "description", "due_date", "all_day", "is_completed", "priority"}
+	SyncTagFields  = []string{"name", "color"}
+)
+
+// TaskTagLink는 작업-태그 연결 변경 내역을 나타내는 구조체
+type TaskTagLink struct {
+	TaskID    int   `db:"task_id" json:"task_id"`
+	TagID     int   `db:"tag_id" json:"tag_id"`
+	ChangeSeq int64 `db:"change_seq" json:"change_seq"`
+}
+
+// Tombstone은 삭제 된 엔티티의 변경 내역을 나타내는 구조체
+type Tombstone struct {
+	Entity    string    `db:"entity" json:"entity"`
+	EntityID  string    `db:"entity_id" json:"entity_id"`
+	ChangeSeq int64     `db:"change_seq" json:"change_seq"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// SyncChanges는 특정 시퀀스 이후의 모든 변경 내역을 담는 구조체
+type SyncChanges struct {
+	Tasks      []Task        `json:"tasks"`
+	Tags       []Tag         `json:"tags"`
+	TaskTags   []TaskTagLink `json:"task_tags"`
+	Tombstones []Tombstone   `json:"tombstones"`
+	NextToken  string        `json:"next_token"`
+}
+
+// SyncMutation은 클라이언트가 오프라인 상태에서 만든 변경 사항 하나를 나타내는 구조체
+type SyncMutation struct {
+	Entity    string                     `json:"entity"`
+	Op        string                     `json:"op"`
+	ID        int                        `json:"id"`        // 새로 생성하는 경우 0
+	ClientID  string                     `json:"client_id"` // 생성 시 클라이언트 임시 ID
+	TaskID    int                        `json:"task_id"`   // task_tag 전용
+	TagID     int                        `json:"tag_id"`    // task_tag 전용
+	UpdatedAt time.Time                  `json:"updated_at"`
+	Fields    map[string]json.RawMessage `json:"fields"`
+}
+
+// SyncRequest는 클라이언트 변경 사항 묶음을 적용하기 위한 요청 구조체
+type SyncRequest struct {
+	Mutations []SyncMutation `json:"mutations"`
+}
+
+// SyncConflict는 서버 값이 더 최신이라 적용되지 않은 필드를 나타내는 구조체
+type SyncConflict struct {
+	Field       string      `json:"field"`
+	ServerValue interface{} `json:"server_value"`
+}
+
+// SyncResult는 변경 사항 하나의 적용 결과를 나타내는 구조체
+type SyncResult struct {
+	Index     int            `json:"index"`
+	Status    string         `json:"status"`
+	Entity    string         `json:"entity"`
+	ID        int            `json:"id,omitempty"`
+	ClientID  string         `json:"client_id,omitempty"`
+	ChangeSeq int64          `json:"change_seq,omitempty"`
+	Conflicts []SyncConflict `json:"conflicts,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// SyncResponse는 변경 사항 묶음 적용 결과를 담는 구조체
+type SyncResponse struct {
+	Results   []SyncResult `json:"results"`
+	NextToken string       `json:"next_token"`
+}
+
+// CheckValidSyncMutation은 SyncMutation의 유효성을 검사하는 메서드
+func (m *SyncMutation) CheckValidSyncMutation() error {
+	if m.Op != SYNC_OP_UPSERT && m.Op != SYNC_OP_DELETE {
+		return errors.New("op must be 'upsert' or 'delete'")
+	}
+	if m.UpdatedAt.IsZero() {
+		return errors.New("updated_at is required")
+	}
+
+	switch m.Entity {
+	case SYNC_ENTITY_TASK, SYNC_ENTITY_TAG:
+		if m.Op == SYNC_OP_DELETE && m.ID <= 0 {
+			return errors.New("id is required for delete")
+		}
+		if m.Op == SYNC_OP_UPSERT && len(m.Fields) == 0 {
+			return errors.New("fields are required for upsert")
+		}
+	case SYNC_ENTITY_TASK_TAG:
+		if m.TaskID <= 0 || m.TagID <= 0 {
+			return errors.New("task_id and tag_id are required")
+		}
+	default:
+		return errors.New("entity must be 'task', 'tag' or 'task_tag'")
+	}
+	return nil
+}
+
+// ClampUpdatedAt은 클라이언트 updated_at을 서버 시각 now 이하로 맞추는 메서드
+// 미래 시각으로 다른 사용자의 이후 수정을 계속 이기지 못하도록 하며, SYNC_MAX_CLOCK_SKEW보다 앞선 시각이면 에러를 반환
+func (m *SyncMutation) ClampUpdatedAt(now time.Time) error {
+	if m.UpdatedAt.After(now.Add(SYNC_MAX_CLOCK_SKEW)) {
+		return errors.New("updated_at is in the future, check the device clock")
+	}
+	if m.UpdatedAt.After(now) {
+		m.UpdatedAt = now
+	}
+	return nil
+}
+
+// ApplyTaskFields는 변경 필드를 작업에 적용하는 함수, 알 수 없는 필드나 잘못된 값이면 에러를 반환
+// 동기화 요청에는 사용자 시간대를 적용하지 않으므로 due_date는 시간대를 포함한 시각이거나 날짜여야 함
+func ApplyTaskFields(task *Task, fields map[string]json.RawMessage) error {
+	var dueDate *DueDateInput
+	for field, raw := range fields {
+		switch field {
+		case "title":
+			if err := json.Unmarshal(raw, &task.Title); err != nil || task.Title == "" {
+				return errors.New("title is required")
+			}
+		case "description":
+			var description *string
+			if err := json.Unmarshal(raw, &description); err != nil {
+				return errors.New("description must be a string or null")
+			}
+			task.Description = description
+		case "due_date":
+			dueDate = new(DueDateInput)
+			if err := json.Unmarshal(raw, dueDate); err != nil || dueDate.IsZero() {
+				return errors.New("due date is required")
+			}
+			if dueDate.IsFloating() && !dueDate.IsDateOnly() {
+				return errors.New("due_date must include a time zone offset")
+			}
+		case "all_day":
+			if err := json.Unmarshal(raw, &task.AllDay); err != nil {
+				return errors.New("all_day must be a boolean")
+			}
+		case "is_completed":
+			if err := json.Unmarshal(raw, &task.IsCompleted); err != nil {
+				return errors.New("is_completed must be a boolean")
+			}
+		case "priority":
+			if err := json.Unmarshal(raw, &task.Priority); err != nil || (task.Priority != PRIORITY_LOW && task.Priority != PRIORITY_MEDIUM && task.Priority != PRIORITY_HIGH) {
+				return errors.New("priority must be 'low', 'medium', or 'high'")
+			}
+		default:
+			return errors.New("unknown task field: " + field)
+		}
+	}
+	// 하루 종일 작업은 입력한 날짜만 남기므로 all_day를 적용한 뒤에 변환
+	if dueDate != nil {
+		task.DueDate = dueDate.Resolve(time.UTC, task.AllDay)
+	} else if task.AllDay {
+		task.DueDate = DueDateOf(task.DueDate)
+	}
+	return nil
+}
+
+// ApplyTagFields는 변경 필드를 태그에 적용하는 함수, 알 수 없는 필드나 잘못된 값이면 에러를 반환
+func ApplyTagFields(tag *Tag, fields map[string]json.RawMessage) error {
+	for field, raw := range fields {
+		switch field {
+		case "name":
+			if err := json.Unmarshal(raw, &tag.Name); err != nil || tag.Name == "" {
+				return errors.New("name cannot be empty")
+			}
+		case "color":
+			if err := json.Unmarshal(raw, &tag.Color); err != nil || len(tag.Color) != 7 || tag.Color[0] != '#' {
+				return errors.New("color must be a valid hex code (e.g., #FFFFFF)")
+			}
+		default:
+			return errors.New("unknown tag field: " + field)
+		}
+	}
+	return nil
+}
+
+// TaskFieldValue는 작업의 필드 값을 반환하는 함수 (충돌 보고용)
+func TaskFieldValue(task *Task, field string) interface{} {
+	switch field {
+	case "title":
+		return task.Title
+	case "description":
+		return task.Description
+	case "due_date":
+		return task.DueDate
+	case "all_day":
+		return task.AllDay
+	case "is_completed":
+		return task.IsCompleted
+	case "priority":
+		return task.Priority
+	}
+	return nil
+}
+
+// TagFieldValue는 태그의 필드 값을 반환하는 함수 (충돌 보고용)
+func TagFieldValue(tag *Tag, field string) interface{} {
+	switch field {
+	case "name":
+		return tag.Name
+	case "color":
+		return tag.Color
+	}
+	return nil
+}
+
+// FormatSyncToken은 작업 공간의 변경 시퀀스를 동기화 토큰으로 만드는 함수
+func FormatSyncToken(workspaceID int, seq int64) string {
+	return strconv.Itoa(SYNC_TOKEN_VERSION) + "." + strconv.Itoa(workspaceID) + "." + strconv.FormatInt(seq, 10)
+}
+
+// ParseSyncToken은 동기화 토큰에서 변경 시퀀스를 읽는 함수
+// 버전 없는 사용자 단위 토큰, 다른 버전이나 다른 작업 공간의 토큰은 ErrSyncTokenExpired를 반환
+func ParseSyncToken(token string, workspaceID int) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) == 1 {
+		if _, err := strconv.ParseInt(token, 10, 64); err == nil {
+			return 0, ErrSyncTokenExpired
+		}
+		return 0, ErrInvalidSyncToken
+	}
+	if len(parts) != 3 {
+		return 0, ErrInvalidSyncToken
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ErrInvalidSyncToken
+	}
+	tokenWorkspaceID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, ErrInvalidSyncToken
+	}
+	seq, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+	if version != SYNC_TOKEN_VERSION || tokenWorkspaceID != workspaceID {
+		return 0, ErrSyncTokenExpired
+	}
+	return seq, nil
+}
@@ -6,11 +6,14 @@ import (
 )
 
 type Tag struct {
-	ID        int       `db:"id"`
-	UserID    int       `db:"user_id"`
-	Name      string    `db:"name"`
-	Color     string    `db:"color"`
-	CreatedAt time.Time `db:"created_at"`
+	ID          int       `db:"id"`
+	UserID      int       `db:"user_id"`      // 태그를 만든 사용자
+	WorkspaceID int       `db:"workspace_id"` // 태그가 속한 작업 공간
+	Name        string    `db:"name"`
+	Color       string    `db:"color"`
+	CreatedAt   time.Time `db:"created_at"`
+	ChangeSeq   int64     `db:"change_seq"` // 작업 공간 단위 변경 시퀀스 (delta sync)
+	Version     int       `db:"version"`    // 낙관적 동시성 제어용 버전 (ETag)
 }
 
 // CreateTagRequest는 태그 생성을 위한 요청 구조체
@@ -15,38 +15,69 @@ const (
 type Task struct {
 	ID          int       `db:"id"`
 	TemplateID  *int      `db:"template_id"`
-	UserID      int       `db:"user_id"`
+	UserID      int       `db:"user_id"`      // 작업을 만든 사용자
+	WorkspaceID int       `db:"workspace_id"` // 작업이 속한 작업 공간
 	Title       string    `db:"title"`
 	Description *string   `db:"description"`
 	DueDate     time.Time `db:"due_date"`
+	AllDay      bool      `db:"all_day"` // true면 DueDate는 날짜만 의미 (해당 날짜의 UTC 자정으로 저장)
 	IsCompleted bool      `db:"is_completed"`
 	Priority    string    `db:"priority"` // "low", "medium", "high"
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
+	ChangeSeq   int64     `db:"change_seq"` // 작업 공간 단위 변경 시퀀스 (delta sync)
+	Version     int       `db:"version"`    // 낙관적 동시성 제어용 버전 (ETag)
 
 	Tags []Tag `db:"-" json:"tags"` // 태그는 Task와 N:M 관계를 가짐
 }
 
+// LocalizeDueDate는 응답에 표시할 마감일을 loc 기준 시각으로 바꾸는 메서드 (같은 시각), 하루 종일 작업은 UTC 자정 그대로 둠
+func (t *Task) LocalizeDueDate(loc *time.Location) {
+	if t.AllDay {
+		t.DueDate = t.DueDate.UTC()
+		return
+	}
+	t.DueDate = t.DueDate.In(loc)
+}
+
 type TaskListResult struct {
 	Tasks      []Task `json:"tasks"`
 	TotalCount int    `json:"total_count"`
 }
 
 // CreateTaskRequest는 작업 생성을 위한 요청 구조체입니다.
+// due_date를 날짜만 입력하고 all_day를 생략하면 하루 종일 작업으로 생성합니다.
 type CreateTaskRequest struct {
-	Title       string    `json:"title"`
-	Description *string   `json:"description"`
-	DueDate     time.Time `json:"due_date"`
-	IsCompleted bool      `json:"is_completed"`
-	Priority    string    `json:"priority"` // "low", "medium", "high"
+	Title       string       `json:"title"`
+	Description *string      `json:"description"`
+	DueDate     DueDateInput `json:"due_date"`
+	AllDay      *bool        `json:"all_day"`
+	IsCompleted bool         `json:"is_completed"`
+	Priority    string       `json:"priority"` // "low", "medium", "high"
+	TagIDs      []int        `json:"tag_ids"`  // 생성과 함께 연결할 태그 ID 목록
 }
 
 // UpdateTaskRequest는 작업 업데이트를 위한 요청 구조체입니다.
+// due_date만 입력하고 all_day를 생략하면 날짜만 입력했는지에 따라 하루 종일 작업 여부가 정해집니다.
 type UpdateTaskRequest struct {
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	DueDate     *time.Time `json:"due_date"`
-	Priority    *string    `json:"priority"`
+	Title       *string       `json:"title"`
+	Description *string       `json:"description"`
+	DueDate     *DueDateInput `json:"due_date"`
+	AllDay      *bool         `json:"all_day"`
+	IsCompleted *bool         `json:"is_completed"`
+	Priority    *string       `json:"priority"`
+}
+
+// TaskPatchDocument는 PATCH 요청(JSON Merge Patch / JSON Patch)이 적용되는 작업 문서 구조체입니다.
+// 모든 필드를 포함하므로 null 또는 누락은 "변경 없음"이 아니라 "값 비우기"를 의미합니다.
+// due_date는 하루 종일 작업이면 날짜, 그 외에는 사용자 시간대의 시각으로 표현됩니다.
+type TaskPatchDocument struct {
+	Title       *string       `json:"title"`
+	Description *string       `json:"description"`
+	DueDate     *DueDateInput `json:"due_date"`
+	AllDay      *bool         `json:"all_day"`
+	IsCompleted *bool         `json:"is_completed"`
+	Priority    *string       `json:"priority"`
 }
 
 // CheckValidCreateTaskRequest는 CreateTaskRequest의 유효성을 검사하는 메서드
@@ -60,32 +91,41 @@ func (r *CreateTaskRequest) CheckValidCreateTaskRequest() error {
 	if r.Priority != "low" && r.Priority != "medium" && r.Priority != "high" {
 		return errors.New("priority must be 'low', 'medium', or 'high'")
 	}
+	for _, tagID := range r.TagIDs {
+		if tagID <= 0 {
+			return errors.New("tag_ids must contain positive IDs")
+		}
+	}
 	return nil
 }
 
-// ToTask는 CreateTaskRequest를 Task로 변환하는 메서드
-func (r *CreateTaskRequest) ToTask(userID int) *Task {
+// ToTask는 CreateTaskRequest를 Task로 변환하는 메서드, 시간대 없는 due_date는 loc 기준으로 해석
+func (r *CreateTaskRequest) ToTask(userID int, loc *time.Location) *Task {
+	allDay := r.isAllDay()
 	return &Task{
 		UserID:      userID,
 		Title:       r.Title,
 		Description: r.Description,
-		DueDate:     r.DueDate,
+		DueDate:     r.DueDate.Resolve(loc, allDay),
+		AllDay:      allDay,
 		IsCompleted: r.IsCompleted,
 		Priority:    r.Priority,
 	}
 }
 
 // toTaskTemplate는 CreateTaskRequest를 Task로 변환하는 메서드
-func (r *CreateTaskRequest) ToTaskTemplate(templateID int, userID int) *Task {
-	return &Task{
-		TemplateID:  &templateID,
-		UserID:      userID,
-		Title:       r.Title,
-		Description: r.Description,
-		DueDate:     r.DueDate,
-		IsCompleted: r.IsCompleted,
-		Priority:    r.Priority,
+func (r *CreateTaskRequest) ToTaskTemplate(templateID int, userID int, loc *time.Location) *Task {
+	task := r.ToTask(userID, loc)
+	task.TemplateID = &templateID
+	return task
+}
+
+// isAllDay는 하루 종일 작업인지 확인하는 메서드, all_day가 없으면 날짜만 입력했는지로 판단
+func (r *CreateTaskRequest) isAllDay() bool {
+	if r.AllDay != nil {
+		return *r.AllDay
 	}
+	return r.DueDate.IsDateOnly()
 }
 
 // CheckValidUpdateTaskRequest는 UpdateTaskRequest의 유효성을 검사하는 메서드입니다.
@@ -103,7 +143,8 @@ func (r *UpdateTaskRequest) CheckValidUpdateTaskRequest() error {
 }
 
 // ToTask는 UpdateTaskRequest를 받아 Task를 업데이트하는 메서드입니다.
-func (r *UpdateTaskRequest) ToTask(task *Task) *Task {
+// 시간대 없는 due_date와 하루 종일 여부 변경은 loc 기준으로 해석합니다.
+func (r *UpdateTaskRequest) ToTask(task *Task, loc *time.Location) *Task {
 	// 요청에 포함된 필드만 업데이트
 	if r.Title != nil {
 		task.Title = *r.Title
@@ -111,11 +152,82 @@ func (r *UpdateTaskRequest) ToTask(task *Task) *Task {
 	if r.Description != nil {
 		task.Description = r.Description
 	}
-	if r.DueDate != nil {
-		task.DueDate = *r.DueDate
+	if r.DueDate != nil || r.AllDay != nil {
+		dueDate := NewDueDateInput(task.DueDate, task.AllDay, loc)
+		if r.DueDate != nil {
+			dueDate = *r.DueDate
+			task.AllDay = dueDate.IsDateOnly()
+		}
+		if r.AllDay != nil {
+			task.AllDay = *r.AllDay
+		}
+		task.DueDate = dueDate.Resolve(loc, task.AllDay)
+	}
+	if r.IsCompleted != nil {
+		task.IsCompleted = *r.IsCompleted
 	}
 	if r.Priority != nil {
 		task.Priority = *r.Priority
 	}
 	return task
 }
+
+// NewTaskPatchDocument는 Task를 PATCH 적용 대상 문서로 변환하는 함수입니다.
+func NewTaskPatchDocument(task *Task, loc *time.Location) *TaskPatchDocument {
+	dueDate := NewDueDateInput(task.DueDate, task.AllDay, loc)
+	allDay := task.AllDay
+	isCompleted := task.IsCompleted
+	return &TaskPatchDocument{
+		Title:       &task.Title,
+		Description: task.Description,
+		DueDate:     &dueDate,
+		AllDay:      &allDay,
+		IsCompleted: &isCompleted,
+		Priority:    &task.Priority,
+	}
+}
+
+// toUpdateTaskRequest는 문서를 UpdateTaskRequest로 변환하는 메서드입니다.
+// 비울 수 없는 필드가 비어 있으면 빈 값으로 채워 CheckValidUpdateTaskRequest에서 거부되도록 합니다.
+func (d *TaskPatchDocument) toUpdateTaskRequest() *UpdateTaskRequest {
+	req := &UpdateTaskRequest{
+		Title:       d.Title,
+		Description: d.Description,
+		DueDate:     d.DueDate,
+		AllDay:      d.AllDay,
+		IsCompleted: d.IsCompleted,
+		Priority:    d.Priority,
+	}
+	if req.Title == nil {
+		req.Title = new(string)
+	}
+	if req.DueDate == nil {
+		req.DueDate = new(DueDateInput)
+	}
+	if req.Priority == nil {
+		req.Priority = new(string)
+	}
+	return req
+}
+
+// CheckValidTaskPatchDocument는 PATCH가 적용된 문서의 유효성을 CheckValidUpdateTaskRequest와 같은 규칙으로 검사하는 메서드입니다.
+func (d *TaskPatchDocument) CheckValidTaskPatchDocument() error {
+	if err := d.toUpdateTaskRequest().CheckValidUpdateTaskRequest(); err != nil {
+		return err
+	}
+	if d.AllDay == nil {
+		return errors.New("all_day must be a boolean")
+	}
+	if d.IsCompleted == nil {
+		return errors.New("is_completed must be a boolean")
+	}
+	return nil
+}
+
+// ToTask는 PATCH가 적용된 문서로 Task를 업데이트하는 메서드입니다.
+func (d *TaskPatchDocument) ToTask(task *Task, loc *time.Location) *Task {
+	task = d.toUpdateTaskRequest().ToTask(task, loc)
+	// UpdateTaskRequest와 달리 description의 null은 값을 비우는 것을 의미
+	task.Description = d.Description
+	return task
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 개인 액세스 토큰 접두사, 쿠키 세션과 구분하고 유출 된 토큰을 검색하기 쉽게 함
+const PERSONAL_ACCESS_TOKEN_PREFIX = "lux_pat_"
+
+// 개인 액세스 토큰 제한
+const (
+	PERSONAL_ACCESS_TOKEN_NAME_MAX_LENGTH = 100
+	PERSONAL_ACCESS_TOKEN_MAX_PER_USER    = 50
+)
+
+// 개인 액세스 토큰 권한 대상
+const (
+	SCOPE_RESOURCE_TASKS      = "tasks"
+	SCOPE_RESOURCE_TAGS       = "tags"
+	SCOPE_RESOURCE_SYNC       = "sync"
+	SCOPE_RESOURCE_WORKSPACES = "workspaces"
+)
+
+// 개인 액세스 토큰 권한 종류 (read: 조회, write: 생성 / 수정 / 삭제, write는 read를 포함하지 않음)
+const (
+	SCOPE_ACCESS_READ  = "read"
+	SCOPE_ACCESS_WRITE = "write"
+)
+
+// validScopes는 발급할 수 있는 권한 목록
+var validScopes = map[string]bool{
+	SCOPE_RESOURCE_TASKS + ":" + SCOPE_ACCESS_READ:       true,
+	SCOPE_RESOURCE_TASKS + ":" + SCOPE_ACCESS_WRITE:      true,
+	SCOPE_RESOURCE_TAGS + ":" + SCOPE_ACCESS_READ:        true,
+	SCOPE_RESOURCE_TAGS + ":" + SCOPE_ACCESS_WRITE:       true,
+	SCOPE_RESOURCE_SYNC + ":" + SCOPE_ACCESS_READ:        true,
+	SCOPE_RESOURCE_SYNC + ":" + SCOPE_ACCESS_WRITE:       true,
+	SCOPE_RESOURCE_WORKSPACES + ":" + SCOPE_ACCESS_READ:  true,
+	SCOPE_RESOURCE_WORKSPACES + ":" + SCOPE_ACCESS_WRITE: true,
+}
+
+// PersonalAccessToken은 사용자가 발급한 API 토큰
+type PersonalAccessToken struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"-"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIP  string     `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatePersonalAccessTokenRequest는 개인 액세스 토큰 생성 요청 구조체
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"` // 없으면 만료되지 않음
+}
+
+// CreatePersonalAccessTokenResponse는 생성한 토큰 응답, token 원문은 이 응답에서만 확인할 수 있음
+type CreatePersonalAccessTokenResponse struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// Scope는 resource와 access를 권한 문자열로 만드는 함수 (예: "tasks:read")
+func Scope(resource string, access string) string {
+	return resource + ":" + access
+}
+
+// HasScope는 토큰에 권한이 있는지 확인하는 메서드
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired는 토큰이 now 기준으로 만료 되었는지 확인하는 메서드
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// CheckValidCreatePersonalAccessTokenRequest는 토큰 생성 요청의 유효성을 검사하고 권한을 정렬하는 메서드
+func (r *CreatePersonalAccessTokenRequest) CheckValidCreatePersonalAccessTokenRequest(now time.Time) error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Name) > PERSONAL_ACCESS_TOKEN_NAME_MAX_LENGTH {
+		return errors.New("name must be at most 100 characters")
+	}
+
+	if len(r.Scopes) == 0 {
+		return errors.New("at least one scope is required")
+	}
+	unique := make(map[string]bool, len(r.Scopes))
+	for _, scope := range r.Scopes {
+		if !validScopes[scope] {
+			return fmt.Errorf("invalid scope: %s", scope)
+		}
+		unique[scope] = true
+	}
+	r.Scopes = r.Scopes[:0]
+	for scope := range unique {
+		r.Scopes = append(r.Scopes, scope)
+	}
+	sort.Strings(r.Scopes)
+
+	if r.ExpiresAt != nil {
+		if !r.ExpiresAt.After(now) {
+			return errors.New("expires_at must be in the future")
+		}
+		expiresAt := r.ExpiresAt.UTC()
+		r.ExpiresAt = &expiresAt
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// UserTOTP는 사용자의 TOTP 2단계 인증 설정
+type UserTOTP struct {
+	UserID          int
+	SecretEncrypted string
+	LastUsedStep    int64
+	EnabledAt       *time.Time // nil이면 등록 후 아직 확인하지 않은 상태
+	CreatedAt       time.Time
+}
+
+// IsEnabled는 로그인에 2단계 인증이 필요한지 확인하는 메서드
+func (t *UserTOTP) IsEnabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// TwoFactorCode는 2단계 인증 코드 (TOTP 코드 또는 복구 코드 중 하나)
+type TwoFactorCode struct {
+	TOTPCode     string `json:"totp_code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// IsEmpty는 코드가 입력되지 않았는지 확인하는 메서드
+func (c *TwoFactorCode) IsEmpty() bool {
+	return c.TOTPCode == "" && c.RecoveryCode == ""
+}
+
+// ConfirmTwoFactorRequest는 인증 앱에 등록한 뒤 코드로 2단계 인증을 켜는 요청 구조체
+type ConfirmTwoFactorRequest struct {
+	TOTPCode string `json:"totp_code"`
+}
+
+// ReauthenticateRequest는 2단계 인증을 끄거나 복구 코드를 다시 만들 때 비밀번호와 코드로 다시 인증하는 요청 구조체
+type ReauthenticateRequest struct {
+	Password string `json:"password"`
+	TwoFactorCode
+}
+
+// TwoFactorEnrollResponse는 2단계 인증 등록 응답 구조체
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"` // QR 코드로 표시할 값
+}
+
+// TwoFactorStatusResponse는 2단계 인증 상태 응답 구조체
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
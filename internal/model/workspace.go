@@ -0,0 +1,91 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 작업 공간 구성원 역할
+const (
+	WORKSPACE_ROLE_OWNER  = "owner"  // 작업 공간마다 한 명, 작업 공간 삭제와 소유권 이전 가능
+	WORKSPACE_ROLE_ADMIN  = "admin"  // 작업 공간 이름과 구성원 관리
+	WORKSPACE_ROLE_MEMBER = "member" // 작업과 태그 생성 / 수정 / 삭제
+	WORKSPACE_ROLE_VIEWER = "viewer" // 조회만 가능
+)
+
+// 작업 공간 제한
+const (
+	WORKSPACE_NAME_MAX_LENGTH = 100
+	WORKSPACE_MAX_PER_USER    = 50 // 사용자가 만들 수 있는 작업 공간 수 (개인 작업 공간 제외)
+	// 가입할 때 만드는 개인 작업 공간 이름
+	PERSONAL_WORKSPACE_NAME = "Personal"
+)
+
+// Workspace는 작업과 태그를 공유하는 작업 공간 (팀)
+type Workspace struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Personal    bool      `json:"personal"`     // 가입할 때 만든 개인 작업 공간, 다른 구성원을 둘 수 없고 삭제할 수 없음
+	Role        string    `json:"role"`         // 요청 사용자의 역할
+	MemberCount int       `json:"member_count"` // 구성원 수
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WorkspaceMember는 작업 공간 구성원
+type WorkspaceMember struct {
+	WorkspaceID int       `json:"workspace_id"`
+	UserID      int       `json:"user_id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// CreateWorkspaceRequest는 작업 공간 생성 요청 구조체
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateWorkspaceRequest는 작업 공간 수정 요청 구조체
+type UpdateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateWorkspaceMemberRequest는 구성원 역할 변경 요청 구조체
+// role을 owner로 바꾸면 소유권을 이전하며, 기존 owner는 admin이 됨
+type UpdateWorkspaceMemberRequest struct {
+	Role string `json:"role"`
+}
+
+// CheckValidWorkspaceName은 작업 공간 이름의 유효성을 검사하고 앞뒤 공백을 제거한 이름을 반환하는 함수
+func CheckValidWorkspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > WORKSPACE_NAME_MAX_LENGTH {
+		return "", errors.New("name is too long")
+	}
+	return name, nil
+}
+
+// CheckValidWorkspaceRole은 구성원에게 부여할 수 있는 역할인지 검사하는 함수
+func CheckValidWorkspaceRole(role string) error {
+	switch role {
+	case WORKSPACE_ROLE_OWNER, WORKSPACE_ROLE_ADMIN, WORKSPACE_ROLE_MEMBER, WORKSPACE_ROLE_VIEWER:
+		return nil
+	}
+	return errors.New("role must be 'owner', 'admin', 'member', or 'viewer'")
+}
+
+// CanEditWorkspace는 역할이 작업과 태그를 수정할 수 있는지 확인하는 함수
+func CanEditWorkspace(role string) bool {
+	return role == WORKSPACE_ROLE_OWNER || role == WORKSPACE_ROLE_ADMIN || role == WORKSPACE_ROLE_MEMBER
+}
+
+// CanManageWorkspace는 역할이 작업 공간과 구성원을 관리할 수 있는지 확인하는 함수
+func CanManageWorkspace(role string) bool {
+	return role == WORKSPACE_ROLE_OWNER || role == WORKSPACE_ROLE_ADMIN
+}
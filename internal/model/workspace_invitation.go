@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// 작업 공간 초대 상태, 만료는 저장하지 않고 조회할 때 expires_at으로 판단
+const (
+	INVITATION_STATUS_PENDING  = "pending"
+	INVITATION_STATUS_ACCEPTED = "accepted"
+	INVITATION_STATUS_DECLINED = "declined"
+	INVITATION_STATUS_REVOKED  = "revoked"
+	INVITATION_STATUS_EXPIRED  = "expired" // 응답에만 사용
+)
+
+// 작업 공간 초대 제한
+const (
+	WORKSPACE_INVITATION_MAX_PENDING = 100 // 작업 공간마다 대기 중인 초대 수
+)
+
+// WorkspaceInvitation은 작업 공간 초대
+// 이메일로 초대했으면 가입 여부를 드러내지 않도록 초대 받은 사용자의 ID와 이름을 응답에 포함하지 않음
+type WorkspaceInvitation struct {
+	ID            int        `json:"id"`
+	WorkspaceID   int        `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name"`
+	InviterID     *int       `json:"inviter_id"` // 초대한 사용자가 탈퇴했으면 null
+	InviterName   string     `json:"inviter_name"`
+	InviteeUserID *int       `json:"invitee_user_id,omitempty"`
+	InviteeName   string     `json:"invitee_name,omitempty"`
+	Email         string     `json:"email,omitempty"`
+	Role          string     `json:"role"`
+	Status        string     `json:"status"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RespondedAt   *time.Time `json:"responded_at"`
+
+	// 알림을 보낼 가입한 사용자 (이메일 초대도 포함), 응답에는 포함하지 않음
+	RecipientUserID *int `json:"-"`
+}
+
+// CreateWorkspaceInvitationRequest는 작업 공간 초대 요청 구조체, username과 email 중 하나만 지정
+type CreateWorkspaceInvitationRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+// CreateWorkspaceInvitationResponse는 작업 공간 초대 응답 구조체
+// link는 서명 된 초대 주소로, 초대 받은 사용자에게도 알림으로 전달됨
+type CreateWorkspaceInvitationResponse struct {
+	Invitation *WorkspaceInvitation `json:"invitation"`
+	Link       string               `json:"link"`
+}
+
+// RespondWorkspaceInvitationRequest는 초대 링크의 토큰으로 초대를 수락 / 거절하는 요청 구조체
+type RespondWorkspaceInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// CheckValidCreateWorkspaceInvitationRequest는 초대 요청의 유효성을 검사하는 메서드, 이메일은 정규화함
+func (r *CreateWorkspaceInvitationRequest) CheckValidCreateWorkspaceInvitationRequest() error {
+	r.Username = strings.TrimSpace(r.Username)
+	r.Email = NormalizeEmail(r.Email)
+	if (r.Username == "") == (r.Email == "") {
+		return errors.New("exactly one of username or email is required")
+	}
+	if r.Email != "" {
+		if err := ValidateEmail(r.Email); err != nil {
+			return err
+		}
+	}
+	if r.Role == "" {
+		r.Role = WORKSPACE_ROLE_MEMBER
+	}
+	switch r.Role {
+	case WORKSPACE_ROLE_ADMIN, WORKSPACE_ROLE_MEMBER, WORKSPACE_ROLE_VIEWER:
+		return nil
+	}
+	return errors.New("role must be 'admin', 'member', or 'viewer'")
+}
+
+// ResolveStatus는 대기 중이지만 만료 된 초대의 상태를 expired로 바꾸는 메서드
+func (i *WorkspaceInvitation) ResolveStatus(now time.Time) {
+	if i.Status == INVITATION_STATUS_PENDING && !now.Before(i.ExpiresAt) {
+		i.Status = INVITATION_STATUS_EXPIRED
+	}
+}
@@ -0,0 +1,165 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// 클라이언트에게 메시지를 쓰는 데 허용되는 시간
+	WRITE_WAIT = 10 * time.Second
+	// 클라이언트의 pong 응답을 기다리는 시간
+	PONG_WAIT = 60 * time.Second
+	// ping 전송 주기 (PONG_WAIT 보다 짧아야 함)
+	PING_PERIOD = (PONG_WAIT * 9) / 10
+	// 클라이언트 메시지 최대 크기
+	MAX_MESSAGE_SIZE = 4096
+	// 클라이언트 별 전송 대기열 크기, 가득 차면 느린 클라이언트로 보고 연결을 끊음
+	SEND_BUFFER_SIZE = 64
+	// 클라이언트 별 최대 구독 수
+	MAX_SUBSCRIPTIONS = 100
+)
+
+// Client는 하나의 WebSocket 연결을 나타내는 구조체
+type Client struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	userID    int
+	authorize Authorizer
+
+	// topics는 hub.mu로 보호됨
+	topics map[string]struct{}
+
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+// newClient는 Client의 인스턴스를 생성하는 함수
+func newClient(hub *Hub, conn *websocket.Conn, userID int, authorize Authorizer) *Client {
+	return &Client{
+		hub:       hub,
+		conn:      conn,
+		userID:    userID,
+		authorize: authorize,
+		topics:    make(map[string]struct{}),
+		send:      make(chan []byte, SEND_BUFFER_SIZE),
+		done:      make(chan struct{}),
+		closeCode: websocket.CloseNormalClosure,
+	}
+}
+
+// enqueue는 메시지를 전송 대기열에 넣는 메서드, 대기열이 가득 차면 연결을 종료
+func (c *Client) enqueue(payload []byte) {
+	select {
+	case <-c.done:
+	case c.send <- payload:
+	default:
+		c.close(websocket.CloseTryAgainLater, "send buffer full")
+	}
+}
+
+// reply는 클라이언트에게 직접 메시지를 전송하는 메서드
+func (c *Client) reply(msg ServerMessage) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	c.enqueue(payload)
+}
+
+// close는 연결 종료를 요청하는 메서드, 실제 종료는 writePump에서 처리
+func (c *Client) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+// readPump는 클라이언트로부터 메시지를 읽어 처리하는 루프
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.close(websocket.CloseNormalClosure, "")
+	}()
+
+	c.conn.SetReadLimit(MAX_MESSAGE_SIZE)
+	_ = c.conn.SetReadDeadline(time.Now().Add(PONG_WAIT))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(PONG_WAIT))
+	})
+
+	for {
+		var msg ClientMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		c.handle(msg)
+	}
+}
+
+// handle은 클라이언트 메시지 타입에 따라 구독/해제/ping을 처리하는 메서드
+func (c *Client) handle(msg ClientMessage) {
+	switch msg.Type {
+	case MESSAGE_SUBSCRIBE:
+		if err := c.authorize(c.userID, msg.Topic); err != nil {
+			c.reply(ServerMessage{Type: MESSAGE_ERROR, Topic: msg.Topic, Error: err.Error()})
+			return
+		}
+		if !c.hub.subscribe(c, msg.Topic) {
+			c.reply(ServerMessage{Type: MESSAGE_ERROR, Topic: msg.Topic, Error: "too many subscriptions"})
+			return
+		}
+		c.reply(ServerMessage{Type: MESSAGE_ACK, Topic: msg.Topic, Event: MESSAGE_SUBSCRIBE})
+	case MESSAGE_UNSUBSCRIBE:
+		if msg.Topic == UserTopic(c.userID) {
+			c.reply(ServerMessage{Type: MESSAGE_ERROR, Topic: msg.Topic, Error: "cannot unsubscribe from user topic"})
+			return
+		}
+		c.hub.unsubscribe(c, msg.Topic)
+		c.reply(ServerMessage{Type: MESSAGE_ACK, Topic: msg.Topic, Event: MESSAGE_UNSUBSCRIBE})
+	case MESSAGE_PING:
+		c.reply(ServerMessage{Type: MESSAGE_PONG})
+	default:
+		c.reply(ServerMessage{Type: MESSAGE_ERROR, Error: "unknown message type"})
+	}
+}
+
+// writePump는 전송 대기열의 메시지와 heartbeat ping을 클라이언트로 전송하는 루프
+func (c *Client) writePump() {
+	ticker := time.NewTicker(PING_PERIOD)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case payload := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(WRITE_WAIT))
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(WRITE_WAIT))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-c.done:
+			_ = c.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(c.closeCode, c.closeText),
+				time.Now().Add(WRITE_WAIT),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Authorizer는 사용자가 토픽을 구독할 수 있는지 검사하는 함수 타입
+type Authorizer func(userID int, topic string) error
+
+// Publisher는 변경 이벤트를 발행하는 인터페이스
+type Publisher interface {
+	Publish(event string, data interface{}, topics ...string)
+}
+
+// Revoker는 권한이 사라진 사용자의 토픽 구독을 끊는 인터페이스
+type Revoker interface {
+	Revoke(userID int, topic string)
+}
+
+// Hub는 WebSocket 클라이언트와 토픽 구독을 관리하는 구조체
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[*Client]struct{}
+	topics  map[string]map[*Client]struct{}
+	closed  bool
+}
+
+// NewHub는 Hub의 인스턴스를 생성하는 함수
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[*Client]struct{}),
+		topics:  make(map[string]map[*Client]struct{}),
+	}
+}
+
+// Register는 업그레이드 된 연결을 Hub에 등록하고 읽기/쓰기 루프를 시작하는 메서드
+func (h *Hub) Register(conn *websocket.Conn, userID int, authorize Authorizer) {
+	client := newClient(h, conn, userID, authorize)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		_ = conn.Close()
+		return
+	}
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	// 사용자 전용 토픽은 연결 시 자동으로 구독
+	h.subscribe(client, UserTopic(userID))
+
+	go client.writePump()
+	go client.readPump()
+}
+
+// Publish는 토픽들을 구독 중인 클라이언트에게 이벤트를 한 번씩 전송하는 메서드
+// 여러 토픽을 구독한 클라이언트는 topics 순서에서 먼저 구독한 토픽으로 받음
+func (h *Hub) Publish(event string, data interface{}, topics ...string) {
+	sent := make(map[*Client]struct{})
+	for _, topic := range topics {
+		payload, err := json.Marshal(ServerMessage{
+			Type:  MESSAGE_EVENT,
+			Topic: topic,
+			Event: event,
+			Data:  data,
+		})
+		if err != nil {
+			log.Printf("realtime: failed to marshal event %s: %v", event, err)
+			return
+		}
+		h.broadcast(payload, topic, sent)
+	}
+}
+
+// Revoke는 사용자의 모든 연결에서 토픽 구독을 제거하는 메서드 (작업 공간에서 내보낸 구성원 등)
+func (h *Hub) Revoke(userID int, topic string) {
+	h.mu.Lock()
+	revoked := false
+	for client := range h.topics[topic] {
+		if client.userID == userID {
+			h.removeLocked(client, topic)
+			revoked = true
+		}
+	}
+	h.mu.Unlock()
+
+	if revoked {
+		h.broadcastPresence(topic)
+	}
+}
+
+// Close는 모든 클라이언트 연결을 종료하는 메서드 (서버 종료 시 사용)
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		client.close(websocket.CloseGoingAway, "server shutdown")
+	}
+}
+
+// subscribe는 클라이언트를 토픽에 등록하는 메서드
+func (h *Hub) subscribe(client *Client, topic string) bool {
+	h.mu.Lock()
+	if _, ok := client.topics[topic]; ok {
+		h.mu.Unlock()
+		return true
+	}
+	if len(client.topics) >= MAX_SUBSCRIPTIONS {
+		h.mu.Unlock()
+		return false
+	}
+
+	subscribers, ok := h.topics[topic]
+	if !ok {
+		subscribers = make(map[*Client]struct{})
+		h.topics[topic] = subscribers
+	}
+	subscribers[client] = struct{}{}
+	client.topics[topic] = struct{}{}
+	h.mu.Unlock()
+
+	h.broadcastPresence(topic)
+	return true
+}
+
+// unsubscribe는 클라이언트를 토픽에서 제거하는 메서드
+func (h *Hub) unsubscribe(client *Client, topic string) {
+	h.mu.Lock()
+	h.removeLocked(client, topic)
+	h.mu.Unlock()
+
+	h.broadcastPresence(topic)
+}
+
+// unregister는 클라이언트를 Hub와 모든 토픽에서 제거하는 메서드
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	if _, ok := h.clients[client]; !ok {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.clients, client)
+
+	topics := make([]string, 0, len(client.topics))
+	for topic := range client.topics {
+		topics = append(topics, topic)
+		h.removeLocked(client, topic)
+	}
+	h.mu.Unlock()
+
+	for _, topic := range topics {
+		h.broadcastPresence(topic)
+	}
+}
+
+// removeLocked는 h.mu를 잡은 상태에서 토픽 구독을 제거하는 메서드
+func (h *Hub) removeLocked(client *Client, topic string) {
+	delete(client.topics, topic)
+	if subscribers, ok := h.topics[topic]; ok {
+		delete(subscribers, client)
+		if len(subscribers) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+}
+
+// broadcast는 직렬화 된 메시지를 토픽 구독자에게 전송하는 메서드, sent에 있는 클라이언트는 건너뛰고 전송한 클라이언트를 기록
+func (h *Hub) broadcast(payload []byte, topic string, sent map[*Client]struct{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.topics[topic] {
+		if _, ok := sent[client]; ok {
+			continue
+		}
+		sent[client] = struct{}{}
+		client.enqueue(payload)
+	}
+}
+
+// broadcastPresence는 토픽을 보고 있는 사용자 목록을 구독자에게 전송하는 메서드
+func (h *Hub) broadcastPresence(topic string) {
+	// 프레즌스는 작업 토픽에 대해서만 제공
+	if kind, _, err := ParseTopic(topic); err != nil || kind != TOPIC_TASK {
+		return
+	}
+
+	payload, err := json.Marshal(ServerMessage{
+		Type:  MESSAGE_PRESENCE,
+		Topic: topic,
+		Users: h.viewers(topic),
+	})
+	if err != nil {
+		return
+	}
+
+	h.broadcast(payload, topic, make(map[*Client]struct{}))
+}
+
+// viewers는 토픽을 구독 중인 사용자 ID 목록을 반환하는 메서드
+func (h *Hub) viewers(topic string) []int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[int]struct{})
+	users := []int{}
+	for client := range h.topics[topic] {
+		if _, ok := seen[client.userID]; ok {
+			continue
+		}
+		seen[client.userID] = struct{}{}
+		users = append(users, client.userID)
+	}
+	sort.Ints(users)
+	return users
+}
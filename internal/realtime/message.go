@@ -0,0 +1,104 @@
+package realtime
+
+import (
+	"errors"
+	"strings"
+
+	"lux-list/pkg/utils"
+)
+
+// 클라이언트 -> 서버 메시지 타입
+const (
+	MESSAGE_SUBSCRIBE   = "subscribe"
+	MESSAGE_UNSUBSCRIBE = "unsubscribe"
+	MESSAGE_PING        = "ping"
+)
+
+// 서버 -> 클라이언트 메시지 타입
+const (
+	MESSAGE_EVENT    = "event"
+	MESSAGE_PRESENCE = "presence"
+	MESSAGE_ACK      = "ack"
+	MESSAGE_ERROR    = "error"
+	MESSAGE_PONG     = "pong"
+)
+
+// 서버에서 발행하는 변경 이벤트 이름
+const (
+	EVENT_TASK_CREATED        = "task.created"
+	EVENT_TASK_UPDATED        = "task.updated"
+	EVENT_TASK_DELETED        = "task.deleted"
+	EVENT_TAG_CREATED         = "tag.created"
+	EVENT_TAG_UPDATED         = "tag.updated"
+	EVENT_TAG_DELETED         = "tag.deleted"
+	EVENT_TASK_TAG_ADDED      = "task_tag.added"
+	EVENT_TASK_TAG_REMOVED    = "task_tag.removed"
+	EVENT_SYNC_CHANGED        = "sync.changed"
+	EVENT_PREFERENCES_UPDATED = "preferences.updated"
+	EVENT_WORKSPACE_UPDATED   = "workspace.updated"
+	EVENT_WORKSPACE_DELETED   = "workspace.deleted"
+	EVENT_MEMBER_UPDATED      = "workspace_member.updated"
+	EVENT_MEMBER_ADDED        = "workspace_member.added"
+	EVENT_MEMBER_REMOVED      = "workspace_member.removed"
+	EVENT_INVITATION_CREATED  = "workspace_invitation.created"
+	EVENT_INVITATION_REVOKED  = "workspace_invitation.revoked"
+	EVENT_INVITATION_DECLINED = "workspace_invitation.declined"
+)
+
+// 토픽 종류 (topic = "<kind>:<id>")
+// 프로젝트 기능이 없어 프로젝트 토픽은 없으며, 팀 단위 변경은 작업 공간 토픽으로 구독
+const (
+	TOPIC_USER      = "user"
+	TOPIC_TASK      = "task"
+	TOPIC_WORKSPACE = "workspace"
+)
+
+var (
+	ErrInvalidTopic = errors.New("invalid topic")
+)
+
+// ClientMessage는 클라이언트가 전송하는 메시지 구조체
+type ClientMessage struct {
+	Type  string `json:"type"`
+	Topic string `json:"topic,omitempty"`
+}
+
+// ServerMessage는 서버가 클라이언트로 전송하는 메시지 구조체
+type ServerMessage struct {
+	Type  string      `json:"type"`
+	Topic string      `json:"topic,omitempty"`
+	Event string      `json:"event,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	Users []int       `json:"users,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// UserTopic은 사용자 전용 토픽 이름을 반환하는 함수
+func UserTopic(userID int) string {
+	return TOPIC_USER + ":" + utils.InterfaceToString(userID)
+}
+
+// TaskTopic은 작업 토픽 이름을 반환하는 함수
+func TaskTopic(taskID int) string {
+	return TOPIC_TASK + ":" + utils.InterfaceToString(taskID)
+}
+
+// WorkspaceTopic은 작업 공간 토픽 이름을 반환하는 함수, 작업 공간의 모든 구성원이 구독할 수 있음
+func WorkspaceTopic(workspaceID int) string {
+	return TOPIC_WORKSPACE + ":" + utils.InterfaceToString(workspaceID)
+}
+
+// ParseTopic은 토픽 문자열을 종류와 ID로 분리하는 함수
+func ParseTopic(topic string) (string, int, error) {
+	kind, id, ok := strings.Cut(topic, ":")
+	if !ok || kind == "" || id == "" {
+		return "", 0, ErrInvalidTopic
+	}
+
+	topicID := utils.InterfaceToInt(id)
+	if topicID <= 0 {
+		return "", 0, ErrInvalidTopic
+	}
+
+	return kind, topicID, nil
+}
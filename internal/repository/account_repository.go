@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	DATA_EXPORT_COLUMNS           = "id, user_id, status, error, size_bytes, created_at, completed_at, expires_at"
+	INSERT_DATA_EXPORT_QUERY      = "INSERT INTO data_exports (user_id, status, created_at) VALUES ($1, 'pending', $2) RETURNING " + DATA_EXPORT_COLUMNS
+	GET_DATA_EXPORT_QUERY         = "SELECT " + DATA_EXPORT_COLUMNS + " FROM data_exports WHERE user_id = $1 AND id = $2"
+	GET_LATEST_DATA_EXPORT_QUERY  = "SELECT " + DATA_EXPORT_COLUMNS + " FROM data_exports WHERE user_id = $1 ORDER BY id DESC LIMIT 1"
+	GET_DATA_EXPORT_CONTENT_QUERY = "SELECT content FROM data_exports WHERE user_id = $1 AND id = $2 AND status = 'completed' AND content IS NOT NULL"
+	START_DATA_EXPORT_QUERY       = "UPDATE data_exports SET status = 'running' WHERE id = $1 AND status = 'pending'"
+	COMPLETE_DATA_EXPORT_QUERY    = "UPDATE data_exports SET status = 'completed', content = $2, size_bytes = $3, completed_at = $4, expires_at = $5 WHERE id = $1 AND status = 'running'"
+	FAIL_DATA_EXPORT_QUERY        = "UPDATE data_exports SET status = 'failed', error = $2, completed_at = $3, expires_at = $4 WHERE id = $1 AND status IN ('pending', 'running')"
+	DELETE_EXPIRED_EXPORTS_QUERY  = "DELETE FROM data_exports WHERE expires_at <= $1"
+	// 내보내기에는 사용자가 만든 작업과 태그만 포함됨 (공유 작업 공간의 다른 구성원의 데이터는 제외)
+	GET_EXPORT_TASKS_QUERY             = "SELECT " + TASK_COLUMNS + ", template_id FROM tasks WHERE user_id = $1 ORDER BY id"
+	GET_EXPORT_TAGS_QUERY              = "SELECT " + TAG_COLUMNS + " FROM tags WHERE user_id = $1 ORDER BY id"
+	GET_EXPORT_TASK_TAGS_QUERY         = "SELECT task_tags.task_id, tags.id, tags.user_id, tags.workspace_id, tags.name, tags.color, tags.created_at, tags.change_seq, tags.version FROM task_tags JOIN tasks ON tasks.id = task_tags.task_id JOIN tags ON tags.id = task_tags.tag_id WHERE tasks.user_id = $1 AND tags.user_id = $1 ORDER BY task_tags.task_id, tags.id"
+	GET_TASK_TEMPLATES_QUERY           = "SELECT id, user_id, title, description, repeat_type, repeat_days, start_date, end_date, created_at, updated_at FROM task_templates WHERE user_id = $1 ORDER BY id"
+	SET_USER_DELETION_QUERY            = "UPDATE users SET deletion_scheduled_at = $2 WHERE id = $1"
+	GET_USERS_DUE_FOR_DELETION_QUERY   = "SELECT id FROM users WHERE deletion_scheduled_at <= $1 ORDER BY deletion_scheduled_at LIMIT $2"
+	DELETE_USER_DUE_FOR_DELETION_QUERY = "DELETE FROM users WHERE id = $1 AND deletion_scheduled_at <= $2"
+)
+
+// DATA_EXPORT_FAILED_RETAIN_TIME은 실패한 내보내기 작업 기록을 남겨 두는 시간
+const DATA_EXPORT_FAILED_RETAIN_TIME = 24 * time.Hour
+
+// AccountRepository는 데이터 내보내기와 계정 삭제 관련 데이터베이스 작업을 정의하는 인터페이스
+type AccountRepository interface {
+	CreateExport(ctx context.Context, userID int) (*model.DataExport, error)
+	GetExport(ctx context.Context, userID int, exportID int) (*model.DataExport, error)
+	GetLatestExport(ctx context.Context, userID int) (*model.DataExport, error)
+	GetExportContent(ctx context.Context, userID int, exportID int) ([]byte, error)
+	StartExport(ctx context.Context, exportID int) (bool, error)
+	CompleteExport(ctx context.Context, exportID int, content []byte, expiresAt time.Time) error
+	FailExport(ctx context.Context, exportID int, message string) error
+	DeleteExpiredExports(ctx context.Context, now time.Time) (int64, error)
+	GetExportTasks(ctx context.Context, userID int) ([]model.Task, error)
+	GetExportTags(ctx context.Context, userID int) ([]model.Tag, error)
+	GetTaskTemplates(ctx context.Context, userID int) ([]model.TaskTemplate, error)
+	ScheduleDeletion(ctx context.Context, userID int, deletionAt *time.Time) error
+	GetUsersDueForDeletion(ctx context.Context, now time.Time, limit int) ([]int, error)
+	DeleteUserDueForDeletion(ctx context.Context, userID int, now time.Time) (bool, error)
+}
+
+// accountRepository는 AccountRepository 인터페이스를 구현하는 구조체
+type accountRepository struct {
+	db Executor
+}
+
+// NewAccountRepository는 AccountRepository의 인스턴스를 생성하는 함수
+func NewAccountRepository(db Executor) AccountRepository {
+	return &accountRepository{
+		db: db,
+	}
+}
+
+// CreateExport는 대기 상태의 내보내기 작업을 만드는 메서드
+func (r *accountRepository) CreateExport(ctx context.Context, userID int) (*model.DataExport, error) {
+	return scanDataExport(r.db.QueryRowContext(ctx, INSERT_DATA_EXPORT_QUERY, userID, time.Now().UTC()))
+}
+
+// GetExport는 사용자의 내보내기 작업을 조회하는 메서드, 없으면 sql.ErrNoRows를 반환
+func (r *accountRepository) GetExport(ctx context.Context, userID int, exportID int) (*model.DataExport, error) {
+	return scanDataExport(r.db.QueryRowContext(ctx, GET_DATA_EXPORT_QUERY, userID, exportID))
+}
+
+// GetLatestExport는 사용자의 가장 최근 내보내기 작업을 조회하는 메서드, 없으면 nil을 반환
+func (r *accountRepository) GetLatestExport(ctx context.Context, userID int) (*model.DataExport, error) {
+	export, err := scanDataExport(r.db.QueryRowContext(ctx, GET_LATEST_DATA_EXPORT_QUERY, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return export, err
+}
+
+// GetExportContent는 완료 된 내보내기 작업의 ZIP 파일 내용을 조회하는 메서드, 없으면 sql.ErrNoRows를 반환
+func (r *accountRepository) GetExportContent(ctx context.Context, userID int, exportID int) ([]byte, error) {
+	var content []byte
+	if err := r.db.QueryRowContext(ctx, GET_DATA_EXPORT_CONTENT_QUERY, userID, exportID).Scan(&content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// StartExport는 대기 중인 작업을 진행 중으로 바꾸는 메서드, 이미 다른 곳에서 시작했거나 실패 처리 되었으면 false를 반환
+func (r *accountRepository) StartExport(ctx context.Context, exportID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, START_DATA_EXPORT_QUERY, exportID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// CompleteExport는 진행 중인 작업에 만든 ZIP 파일을 저장하고 완료로 바꾸는 메서드
+func (r *accountRepository) CompleteExport(ctx context.Context, exportID int, content []byte, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, COMPLETE_DATA_EXPORT_QUERY, exportID, content, len(content), time.Now().UTC(), expiresAt.UTC())
+	return err
+}
+
+// FailExport는 끝나지 않은 작업을 실패로 바꾸는 메서드, 실패한 작업은 DATA_EXPORT_FAILED_RETAIN_TIME 뒤에 정리 됨
+func (r *accountRepository) FailExport(ctx context.Context, exportID int, message string) error {
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, FAIL_DATA_EXPORT_QUERY, exportID, message, now, now.Add(DATA_EXPORT_FAILED_RETAIN_TIME))
+	return err
+}
+
+// DeleteExpiredExports는 내려받을 수 있는 시간이 지난 작업을 삭제하고 삭제한 수를 반환하는 메서드
+func (r *accountRepository) DeleteExpiredExports(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, DELETE_EXPIRED_EXPORTS_QUERY, now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetExportTasks는 사용자가 만든 작업을 사용자가 만든 태그와 함께 ID 순으로 조회하는 메서드 (반복 템플릿 ID 포함)
+func (r *accountRepository) GetExportTasks(ctx context.Context, userID int) ([]model.Task, error) {
+	rows, err := r.db.QueryContext(ctx, GET_EXPORT_TASKS_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []model.Task{}
+	index := map[int]int{}
+	for rows.Next() {
+		var (
+			task       model.Task
+			templateID sql.NullInt64
+		)
+		if err := scanTaskFields(rows, &task, &templateID); err != nil {
+			return nil, err
+		}
+		if templateID.Valid {
+			id := int(templateID.Int64)
+			task.TemplateID = &id
+		}
+		task.Tags = []model.Tag{}
+		index[task.ID] = len(tasks)
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := r.db.QueryContext(ctx, GET_EXPORT_TASK_TAGS_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var (
+			taskID int
+			tag    model.Tag
+		)
+		if err := tagRows.Scan(&taskID, &tag.ID, &tag.UserID, &tag.WorkspaceID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.ChangeSeq, &tag.Version); err != nil {
+			return nil, err
+		}
+		if i, ok := index[taskID]; ok {
+			tasks[i].Tags = append(tasks[i].Tags, tag)
+		}
+	}
+	return tasks, tagRows.Err()
+}
+
+// GetExportTags는 사용자가 만든 태그를 ID 순으로 조회하는 메서드
+func (r *accountRepository) GetExportTags(ctx context.Context, userID int) ([]model.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, GET_EXPORT_TAGS_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []model.Tag{}
+	for rows.Next() {
+		var tag model.Tag
+		if err := scanTagFields(rows, &tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// GetTaskTemplates는 사용자의 모든 반복 템플릿을 ID 순으로 조회하는 메서드
+func (r *accountRepository) GetTaskTemplates(ctx context.Context, userID int) ([]model.TaskTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, GET_TASK_TEMPLATES_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []model.TaskTemplate{}
+	for rows.Next() {
+		var (
+			template model.TaskTemplate
+			endDate  sql.NullTime
+		)
+		if err := rows.Scan(&template.ID, &template.UserID, &template.Title, &template.Description, &template.RepeatType, &template.RepeatDays, &template.StartDate, &endDate, &template.CreatedAt, &template.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if endDate.Valid {
+			template.EndDate = &endDate.Time
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// ScheduleDeletion은 계정 삭제 예정 시각을 바꾸는 메서드, deletionAt이 nil이면 삭제 예약을 취소함
+// 사용자가 없으면 sql.ErrNoRows를 반환
+func (r *accountRepository) ScheduleDeletion(ctx context.Context, userID int, deletionAt *time.Time) error {
+	var value sql.NullTime
+	if deletionAt != nil {
+		value = sql.NullTime{Time: deletionAt.UTC(), Valid: true}
+	}
+	result, err := r.db.ExecContext(ctx, SET_USER_DELETION_QUERY, userID, value)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetUsersDueForDeletion은 삭제 예정 시각이 지난 사용자 ID를 limit개까지 조회하는 메서드
+func (r *accountRepository) GetUsersDueForDeletion(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, GET_USERS_DUE_FOR_DELETION_QUERY, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
+
+// DeleteUserDueForDeletion은 삭제 예정 시각이 지난 사용자를 삭제하는 메서드, 모든 데이터는 외래 키로 함께 삭제 됨
+// 그 사이 삭제 예약을 취소했으면 삭제하지 않고 false를 반환
+func (r *accountRepository) DeleteUserDueForDeletion(ctx context.Context, userID int, now time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, DELETE_USER_DUE_FOR_DELETION_QUERY, userID, now.UTC())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// scanDataExport는 DATA_EXPORT_COLUMNS 순서의 행을 내보내기 작업으로 읽는 함수
+func scanDataExport(row rowScanner) (*model.DataExport, error) {
+	var (
+		export      model.DataExport
+		completedAt sql.NullTime
+		expiresAt   sql.NullTime
+	)
+	if err := row.Scan(&export.ID, &export.UserID, &export.Status, &export.Error, &export.SizeBytes, &export.CreatedAt, &completedAt, &expiresAt); err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+	return &export, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"lux-list/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	ADMIN_AUDIT_LOG_COLUMNS      = "id, actor_user_id, target_user_id, action, detail, ip, created_at"
+	INSERT_ADMIN_AUDIT_LOG_QUERY = "INSERT INTO admin_audit_logs (actor_user_id, target_user_id, action, detail, ip, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	SET_USER_DISABLED_QUERY      = "UPDATE users SET disabled_at = $2 WHERE id = $1"
+	SET_USER_ROLE_QUERY          = "UPDATE users SET role = $2 WHERE id = $1"
+	// 사용자 사용량 (활성 세션 수는 세션 저장소에서 따로 셈)
+	GET_USER_USAGE_QUERY = `SELECT
+		(SELECT COUNT(*) FROM tasks WHERE user_id = $1),
+		(SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND is_completed),
+		(SELECT COUNT(*) FROM tags WHERE user_id = $1),
+		(SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = $1),
+		(SELECT COUNT(*) FROM user_identities WHERE user_id = $1),
+		EXISTS (SELECT 1 FROM user_totp WHERE user_id = $1 AND enabled_at IS NOT NULL)`
+)
+
+// AdminRepository는 관리자 기능 관련 데이터베이스 작업을 정의하는 인터페이스
+type AdminRepository interface {
+	ListUsers(ctx context.Context, filter *model.AdminUserFilter) ([]model.User, int, error)
+	GetUserUsage(ctx context.Context, userID int) (*model.UserUsage, error)
+	SetUserDisabled(ctx context.Context, userID int, disabledAt *time.Time) error
+	SetUserRole(ctx context.Context, userID int, role string) error
+	CreateAuditLog(ctx context.Context, log *model.AdminAuditLog) error
+	GetAuditLogs(ctx context.Context, targetUserID int, limit int, page int) ([]model.AdminAuditLog, int, error)
+}
+
+// adminRepository는 AdminRepository 인터페이스를 구현하는 구조체
+type adminRepository struct {
+	db Executor
+}
+
+// NewAdminRepository는 AdminRepository의 인스턴스를 생성하는 함수
+func NewAdminRepository(db Executor) AdminRepository {
+	return &adminRepository{
+		db: db,
+	}
+}
+
+// ListUsers는 검색 조건에 맞는 사용자 목록과 전체 수를 조회하는 메서드 (ID 순)
+func (r *adminRepository) ListUsers(ctx context.Context, filter *model.AdminUserFilter) ([]model.User, int, error) {
+	queryBuilder := sq.Select(USER_COLUMNS, "COUNT(*) OVER() AS total_count").
+		From("users").
+		OrderBy("id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64((filter.Page - 1) * filter.Limit))
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+		queryBuilder = queryBuilder.Where(sq.Or{
+			sq.Expr(`LOWER(name) LIKE ? ESCAPE '\'`, pattern),
+			sq.Expr(`LOWER(COALESCE(email, '')) LIKE ? ESCAPE '\'`, pattern),
+		})
+	}
+	if filter.Role != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"role": filter.Role})
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			queryBuilder = queryBuilder.Where("disabled_at IS NOT NULL")
+		} else {
+			queryBuilder = queryBuilder.Where("disabled_at IS NULL")
+		}
+	}
+
+	query, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	totalCount := 0
+	for rows.Next() {
+		var user model.User
+		if err := scanUserFields(rows, &user, &totalCount); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	return users, totalCount, rows.Err()
+}
+
+// GetUserUsage는 사용자의 할 일, 태그, 토큰, 외부 계정 수와 2단계 인증 사용 여부를 조회하는 메서드
+func (r *adminRepository) GetUserUsage(ctx context.Context, userID int) (*model.UserUsage, error) {
+	var usage model.UserUsage
+	err := r.db.QueryRowContext(ctx, GET_USER_USAGE_QUERY, userID).Scan(
+		&usage.Tasks, &usage.CompletedTasks, &usage.Tags, &usage.PersonalAccessTokens, &usage.Identities, &usage.TwoFactorEnabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// SetUserDisabled는 사용자의 사용 중지 시각을 바꾸는 메서드, disabledAt이 nil이면 사용 중지를 해제함
+// 사용자가 없으면 sql.ErrNoRows를 반환
+func (r *adminRepository) SetUserDisabled(ctx context.Context, userID int, disabledAt *time.Time) error {
+	var value sql.NullTime
+	if disabledAt != nil {
+		value = sql.NullTime{Time: disabledAt.UTC(), Valid: true}
+	}
+	return r.execUserUpdate(ctx, SET_USER_DISABLED_QUERY, userID, value)
+}
+
+// SetUserRole은 사용자의 역할을 바꾸는 메서드, 사용자가 없으면 sql.ErrNoRows를 반환
+func (r *adminRepository) SetUserRole(ctx context.Context, userID int, role string) error {
+	return r.execUserUpdate(ctx, SET_USER_ROLE_QUERY, userID, role)
+}
+
+// CreateAuditLog는 관리자 작업 기록을 저장하는 메서드
+func (r *adminRepository) CreateAuditLog(ctx context.Context, log *model.AdminAuditLog) error {
+	_, err := r.db.ExecContext(ctx, INSERT_ADMIN_AUDIT_LOG_QUERY,
+		log.ActorUserID, log.TargetUserID, log.Action, log.Detail, log.IP, time.Now().UTC())
+	return err
+}
+
+// GetAuditLogs는 관리자 작업 기록을 최신 순으로 조회하는 메서드, targetUserID가 0이면 모든 기록을 조회
+func (r *adminRepository) GetAuditLogs(ctx context.Context, targetUserID int, limit int, page int) ([]model.AdminAuditLog, int, error) {
+	queryBuilder := sq.Select(ADMIN_AUDIT_LOG_COLUMNS, "COUNT(*) OVER() AS total_count").
+		From("admin_audit_logs").
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64((page - 1) * limit))
+	if targetUserID != 0 {
+		queryBuilder = queryBuilder.Where(sq.Eq{"target_user_id": targetUserID})
+	}
+
+	query, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := []model.AdminAuditLog{}
+	totalCount := 0
+	for rows.Next() {
+		var (
+			log          model.AdminAuditLog
+			actorUserID  sql.NullInt64
+			targetUserID sql.NullInt64
+		)
+		if err := rows.Scan(&log.ID, &actorUserID, &targetUserID, &log.Action, &log.Detail, &log.IP, &log.CreatedAt, &totalCount); err != nil {
+			return nil, 0, err
+		}
+		if actorUserID.Valid {
+			id := int(actorUserID.Int64)
+			log.ActorUserID = &id
+		}
+		if targetUserID.Valid {
+			id := int(targetUserID.Int64)
+			log.TargetUserID = &id
+		}
+		logs = append(logs, log)
+	}
+	return logs, totalCount, rows.Err()
+}
+
+// execUserUpdate는 사용자 한 명을 바꾸는 쿼리를 실행하는 메서드, 사용자가 없으면 sql.ErrNoRows를 반환
+func (r *adminRepository) execUserUpdate(ctx context.Context, query string, userID int, value any) error {
+	result, err := r.db.ExecContext(ctx, query, userID, value)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// escapeLike는 LIKE 패턴의 특수 문자(%, _, \)를 이스케이프하는 함수
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -1,31 +1,72 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"lux-list/internal/model"
+	"time"
+)
+
+var (
+	// ErrAccountAlreadyClaimed는 이미 비밀번호가 설정 된 계정을 다시 등록하려 할 때 반환되는 에러
+	ErrAccountAlreadyClaimed = errors.New("account already has a password")
+	// ErrPasswordTokenInvalid는 비밀번호 토큰이 없거나, 만료 되었거나, 이미 사용 되었을 때 반환되는 에러
+	ErrPasswordTokenInvalid = errors.New("password token is invalid or expired")
+)
+
+const (
+	// 사용자 조회 컬럼 (이메일이 없는 기존 사용자는 빈 문자열)
+	USER_COLUMNS = "id, name, COALESCE(email, ''), role, disabled_at, created_at, deletion_scheduled_at, email_verified_at"
+	// 비밀번호 검증용 사용자 조회 컬럼 (외부 ID 공급자 계정 연결 여부 포함)
+	USER_CREDENTIAL_COLUMNS = USER_COLUMNS + ", COALESCE(password_hash, ''), EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id)"
 )
 
 type AuthRepository interface {
-	ExistUser(name string) (bool, error)
-	CreateUser(name string) (*model.User, error)
-	GetUserByName(name string) (*model.User, error)
-	GetUserByID(id int) (*model.User, error)
+	ExistUser(ctx context.Context, name string) (bool, error)
+	ExistEmail(ctx context.Context, email string) (bool, error)
+	CreateUser(ctx context.Context, name string) (*model.User, error)
+	CreateUserWithPassword(ctx context.Context, name string, email string, passwordHash string) (*model.User, error)
+	CreateUserWithEmail(ctx context.Context, name string, email string) (*model.User, error)
+	GetUserByName(ctx context.Context, name string) (*model.User, error)
+	GetUserByID(ctx context.Context, id int) (*model.User, error)
+	GetCredentialsByName(ctx context.Context, name string) (*model.UserCredentials, error)
+	GetCredentialsByEmail(ctx context.Context, email string) (*model.UserCredentials, error)
+	GetCredentialsByID(ctx context.Context, id int) (*model.UserCredentials, error)
+	ClaimAccount(ctx context.Context, userID int, email string, passwordHash string) (*model.User, error)
+	UpdatePassword(ctx context.Context, userID int, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, userID int) error
+	CreatePasswordToken(ctx context.Context, userID int, purpose string, tokenHash string, expiresAt time.Time) error
+	ConsumePasswordToken(ctx context.Context, purpose string, tokenHash string) (int, error)
 }
 
 type authRepository struct {
-	db *sql.DB
+	db Executor
 }
 
-func NewAuthRepository(db *sql.DB) AuthRepository {
+func NewAuthRepository(db Executor) AuthRepository {
 	return &authRepository{
 		db: db,
 	}
 }
 
 // ExistUser는 사용자가 존재하는지 확인하는 메서드
-func (r *authRepository) ExistUser(name string) (bool, error) {
+func (r *authRepository) ExistUser(ctx context.Context, name string) (bool, error) {
 	query := "SELECT COUNT(*) FROM users WHERE name = $1"
-	row := r.db.QueryRow(query, name)
+	row := r.db.QueryRowContext(ctx, query, name)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ExistEmail은 이메일을 사용하는 사용자가 존재하는지 확인하는 메서드
+func (r *authRepository) ExistEmail(ctx context.Context, email string) (bool, error) {
+	query := "SELECT COUNT(*) FROM users WHERE email = $1"
+	row := r.db.QueryRowContext(ctx, query, email)
 
 	var count int
 	if err := row.Scan(&count); err != nil {
@@ -36,41 +77,191 @@ func (r *authRepository) ExistUser(name string) (bool, error) {
 }
 
 // GetUserByName은 사용자 이름으로 사용자를 조회하는 메서드
-func (r *authRepository) GetUserByName(name string) (*model.User, error) {
-	query := "SELECT id, name, created_at FROM users WHERE name = $1"
-	row := r.db.QueryRow(query, name)
+func (r *authRepository) GetUserByName(ctx context.Context, name string) (*model.User, error) {
+	query := "SELECT " + USER_COLUMNS + " FROM users WHERE name = $1"
+	return scanUser(r.db.QueryRowContext(ctx, query, name))
+}
+
+// CreateUser는 새로운 사용자를 생성하는 메서드
+func (r *authRepository) CreateUser(ctx context.Context, name string) (*model.User, error) {
+	query := "INSERT INTO users (name) VALUES ($1) RETURNING " + USER_COLUMNS
+	return r.createUser(ctx, query, name)
+}
 
+// CreateUserWithPassword는 이메일과 비밀번호 해시를 가진 새로운 사용자를 생성하는 메서드
+func (r *authRepository) CreateUserWithPassword(ctx context.Context, name string, email string, passwordHash string) (*model.User, error) {
+	query := "INSERT INTO users (name, email, password_hash, password_updated_at) VALUES ($1, $2, $3, $4) RETURNING " + USER_COLUMNS
+	return r.createUser(ctx, query, name, email, passwordHash, time.Now().UTC())
+}
+
+// CreateUserWithEmail은 비밀번호 없이 새로운 사용자를 생성하는 메서드 (외부 ID 공급자 가입용)
+// email은 ID 공급자가 인증한 이메일이므로 인증 된 것으로 저장하며, 비어있으면 이메일 없이 생성함
+func (r *authRepository) CreateUserWithEmail(ctx context.Context, name string, email string) (*model.User, error) {
+	var verifiedAt *time.Time
+	if email != "" {
+		now := time.Now().UTC()
+		verifiedAt = &now
+	}
+	query := "INSERT INTO users (name, email, email_verified_at) VALUES ($1, NULLIF($2, ''), $3) RETURNING " + USER_COLUMNS
+	return r.createUser(ctx, query, name, email, verifiedAt)
+}
+
+// createUser는 사용자를 생성하는 query를 실행하고 같은 트랜잭션에서 사용자의 개인 작업 공간을 만드는 메서드
+func (r *authRepository) createUser(ctx context.Context, query string, args ...any) (*model.User, error) {
 	var user model.User
-	if err := row.Scan(&user.ID, &user.Name, &user.CreatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil // 사용자 없음
+	err := runInTx(ctx, r.db, func(tx Executor) error {
+		if err := scanUserFields(tx.QueryRowContext(ctx, query, args...), &user); err != nil {
+			return err
 		}
-		return nil, err // 다른 에러
+		_, err := insertWorkspaceTx(ctx, tx, user.ID, model.PERSONAL_WORKSPACE_NAME, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &user, nil
 }
 
-// CreateUser는 새로운 사용자를 생성하는 메서드
-func (r *authRepository) CreateUser(name string) (*model.User, error) {
-	query := "INSERT INTO users (name) VALUES ($1) RETURNING id, name, created_at"
-	row := r.db.QueryRow(query, name)
+// GetUserByID는 사용자 ID로 사용자를 조회하는 메서드
+func (r *authRepository) GetUserByID(ctx context.Context, id int) (*model.User, error) {
+	query := "SELECT " + USER_COLUMNS + " FROM users WHERE id = $1"
+	return scanUser(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetCredentialsByName은 사용자 이름으로 비밀번호 해시를 포함한 사용자를 조회하는 메서드
+func (r *authRepository) GetCredentialsByName(ctx context.Context, name string) (*model.UserCredentials, error) {
+	query := "SELECT " + USER_CREDENTIAL_COLUMNS + " FROM users WHERE name = $1"
+	return scanCredentials(r.db.QueryRowContext(ctx, query, name))
+}
+
+// GetCredentialsByEmail은 이메일로 비밀번호 해시를 포함한 사용자를 조회하는 메서드
+func (r *authRepository) GetCredentialsByEmail(ctx context.Context, email string) (*model.UserCredentials, error) {
+	query := "SELECT " + USER_CREDENTIAL_COLUMNS + " FROM users WHERE email = $1"
+	return scanCredentials(r.db.QueryRowContext(ctx, query, email))
+}
+
+// GetCredentialsByID는 사용자 ID로 비밀번호 해시를 포함한 사용자를 조회하는 메서드
+func (r *authRepository) GetCredentialsByID(ctx context.Context, id int) (*model.UserCredentials, error) {
+	query := "SELECT " + USER_CREDENTIAL_COLUMNS + " FROM users WHERE id = $1"
+	return scanCredentials(r.db.QueryRowContext(ctx, query, id))
+}
+
+// ClaimAccount는 비밀번호가 없는 기존 사용자에게 이메일과 비밀번호를 등록하는 메서드
+// 이미 비밀번호가 있는 계정이면 ErrAccountAlreadyClaimed를 반환
+func (r *authRepository) ClaimAccount(ctx context.Context, userID int, email string, passwordHash string) (*model.User, error) {
+	query := `UPDATE users SET email = $2, password_hash = $3, password_updated_at = $4
+		WHERE id = $1 AND password_hash IS NULL
+		RETURNING ` + USER_COLUMNS
+	row := r.db.QueryRowContext(ctx, query, userID, email, passwordHash, time.Now().UTC())
 
 	var user model.User
-	if err := row.Scan(&user.ID, &user.Name, &user.CreatedAt); err != nil {
+	if err := scanUserFields(row, &user); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAccountAlreadyClaimed
+		}
 		return nil, err
 	}
 
 	return &user, nil
 }
 
-// GetUserByID는 사용자 ID로 사용자를 조회하는 메서드
-func (r *authRepository) GetUserByID(id int) (*model.User, error) {
-	query := "SELECT id, name, created_at FROM users WHERE id = $1"
-	row := r.db.QueryRow(query, id)
+// UpdatePassword는 사용자의 비밀번호 해시를 변경하는 메서드
+func (r *authRepository) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	query := "UPDATE users SET password_hash = $2, password_updated_at = $3 WHERE id = $1"
+	result, err := r.db.ExecContext(ctx, query, userID, passwordHash, time.Now().UTC())
+	if err != nil {
+		return err
+	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MarkEmailVerified는 사용자의 이메일을 인증 된 것으로 표시하는 메서드, 이미 인증했으면 처음 인증한 시각을 유지
+// 이메일이 없는 사용자면 sql.ErrNoRows를 반환
+func (r *authRepository) MarkEmailVerified(ctx context.Context, userID int) error {
+	query := "UPDATE users SET email_verified_at = COALESCE(email_verified_at, $2) WHERE id = $1 AND email IS NOT NULL"
+	result, err := r.db.ExecContext(ctx, query, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CreatePasswordToken은 일회성 비밀번호 토큰의 해시를 저장하는 메서드
+func (r *authRepository) CreatePasswordToken(ctx context.Context, userID int, purpose string, tokenHash string, expiresAt time.Time) error {
+	query := "INSERT INTO password_tokens (user_id, token_hash, purpose, expires_at) VALUES ($1, $2, $3, $4)"
+	_, err := r.db.ExecContext(ctx, query, userID, tokenHash, purpose, expiresAt.UTC())
+	return err
+}
+
+// ConsumePasswordToken은 일회성 비밀번호 토큰을 사용 처리하고 토큰의 사용자 ID를 반환하는 메서드
+// 토큰이 없거나, 만료 되었거나, 이미 사용 되었으면 ErrPasswordTokenInvalid를 반환
+// 같은 사용자의 아직 사용하지 않은 같은 용도의 토큰도 함께 무효화함
+func (r *authRepository) ConsumePasswordToken(ctx context.Context, purpose string, tokenHash string) (int, error) {
+	var userID int
+	err := runInTx(ctx, r.db, func(tx Executor) error {
+		var (
+			tokenID   int
+			expiresAt time.Time
+			usedAt    sql.NullTime
+		)
+		query := "SELECT id, user_id, expires_at, used_at FROM password_tokens WHERE token_hash = $1 AND purpose = $2"
+		if err := tx.QueryRowContext(ctx, query, tokenHash, purpose).Scan(&tokenID, &userID, &expiresAt, &usedAt); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPasswordTokenInvalid
+			}
+			return err
+		}
+
+		now := time.Now().UTC()
+		if usedAt.Valid || !now.Before(expiresAt) {
+			return ErrPasswordTokenInvalid
+		}
+
+		// 동시에 같은 토큰을 사용하는 요청은 하나만 성공
+		query = "UPDATE password_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL"
+		result, err := tx.ExecContext(ctx, query, tokenID, now)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrPasswordTokenInvalid
+		}
+
+		query = "UPDATE password_tokens SET used_at = $3 WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL"
+		_, err = tx.ExecContext(ctx, query, userID, purpose, now)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// scanUser는 USER_COLUMNS 순서의 행을 사용자로 읽는 함수, 행이 없으면 nil을 반환
+func scanUser(row *sql.Row) (*model.User, error) {
 	var user model.User
-	if err := row.Scan(&user.ID, &user.Name, &user.CreatedAt); err != nil {
+	if err := scanUserFields(row, &user); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // 사용자 없음
 		}
@@ -79,3 +270,36 @@ func (r *authRepository) GetUserByID(id int) (*model.User, error) {
 
 	return &user, nil
 }
+
+// scanCredentials는 USER_CREDENTIAL_COLUMNS 순서의 행을 읽는 함수, 행이 없으면 nil을 반환
+func scanCredentials(row *sql.Row) (*model.UserCredentials, error) {
+	var credentials model.UserCredentials
+	if err := scanUserFields(row, &credentials.User, &credentials.PasswordHash, &credentials.HasIdentity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 사용자 없음
+		}
+		return nil, err // 다른 에러
+	}
+
+	return &credentials, nil
+}
+
+// scanUserFields는 USER_COLUMNS 순서의 컬럼과 그 뒤의 extra 컬럼을 읽는 함수
+func scanUserFields(row rowScanner, user *model.User, extra ...any) error {
+	var disabledAt, deletionScheduledAt, emailVerifiedAt sql.NullTime
+	dest := append([]any{&user.ID, &user.Name, &user.Email, &user.Role, &disabledAt, &user.CreatedAt, &deletionScheduledAt, &emailVerifiedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+
+	if disabledAt.Valid {
+		user.DisabledAt = &disabledAt.Time
+	}
+	if deletionScheduledAt.Valid {
+		user.DeletionScheduledAt = &deletionScheduledAt.Time
+	}
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+	return nil
+}
@@ -0,0 +1,1533 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+)
+
+var (
+	// errRollback은 WithTx 롤백 동작을 검사하기 위한 에러
+	errRollback = errors.New("conformance: rollback")
+
+	userSequence int64
+)
+
+// suite는 검사에 필요한 저장소 묶음과 트랜잭션 관리자를 담는 구조체
+type suite struct {
+	repos     *repository.Repositories
+	txManager repository.TxManager
+}
+
+// conformanceCheck는 모든 저장소 드라이버가 통과해야 하는 검사 하나
+type conformanceCheck struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// newSuite는 마이그레이션이 적용 된 db로 검사 묶음을 만드는 함수
+// 검사마다 새 사용자를 만들어 사용하므로 검사끼리 데이터가 섞이지 않음
+func newSuite(db repository.DB) *suite {
+	return &suite{
+		repos:     repository.NewRepositories(db),
+		txManager: repository.NewTxManager(db),
+	}
+}
+
+// checks는 드라이버 공통 검사 목록을 반환하는 메서드
+func (s *suite) checks() []conformanceCheck {
+	return []conformanceCheck{
+		{"auth", s.testAuth},
+		{"credentials", s.testCredentials},
+		{"tasks", s.testTasks},
+		{"due_dates", s.testDueDates},
+		{"tags", s.testTags},
+		{"task_tags", s.testTaskTags},
+		{"bulk", s.testBulk},
+		{"sync", s.testSync},
+		{"sync_rest_edits", s.testSyncRESTEdits},
+		{"workspaces", s.testWorkspaces},
+		{"workspace_non_member", s.testWorkspaceNonMember},
+		{"invitations", s.testInvitations},
+		{"tokens", s.testTokens},
+		{"identities", s.testIdentities},
+		{"two_factor", s.testTwoFactor},
+		{"admin", s.testAdmin},
+		{"account", s.testAccount},
+		{"preferences", s.testPreferences},
+		{"with_tx", s.testWithTx},
+	}
+}
+
+// newUser는 검사 전용 사용자를 생성하는 메서드
+func (s *suite) newUser(ctx context.Context) (*model.User, error) {
+	name := fmt.Sprintf("conformance-%d-%d", time.Now().UnixNano(), atomic.AddInt64(&userSequence, 1))
+	return s.repos.Auth.CreateUser(ctx, name)
+}
+
+// personalWorkspace는 사용자의 개인 작업 공간 ID를 조회하는 메서드
+func (s *suite) personalWorkspace(ctx context.Context, userID int) (int, error) {
+	workspace, err := s.repos.Workspace.GetPersonalWorkspace(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("GetPersonalWorkspace: %w", err)
+	}
+	return workspace.ID, nil
+}
+
+// newTask는 검사 전용 작업을 생성하는 메서드
+func (s *suite) newTask(ctx context.Context, userID int, workspaceID int, title string) (*model.Task, error) {
+	return s.repos.Task.CreateTasks(ctx, userID, workspaceID, &model.Task{
+		Title:    title,
+		DueDate:  time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC),
+		Priority: model.PRIORITY_MEDIUM,
+	})
+}
+
+// expectErr는 err가 target인지 확인하는 함수
+func expectErr(op string, err error, target error) error {
+	if !errors.Is(err, target) {
+		return fmt.Errorf("%s: expected %v, got %v", op, target, err)
+	}
+	return nil
+}
+
+func (s *suite) testAuth(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return fmt.Errorf("CreateUser: %w", err)
+	}
+	if user.ID == 0 || user.CreatedAt.IsZero() {
+		return fmt.Errorf("CreateUser: id and created_at must be set, got %+v", user)
+	}
+
+	exists, err := s.repos.Auth.ExistUser(ctx, user.Name)
+	if err != nil || !exists {
+		return fmt.Errorf("ExistUser: expected true, got %v (%v)", exists, err)
+	}
+	if _, err := s.repos.Auth.CreateUser(ctx, user.Name); err == nil {
+		return errors.New("CreateUser: duplicate name must fail")
+	}
+
+	byName, err := s.repos.Auth.GetUserByName(ctx, user.Name)
+	if err != nil || byName == nil || byName.ID != user.ID {
+		return fmt.Errorf("GetUserByName: expected id %d, got %+v (%v)", user.ID, byName, err)
+	}
+	byID, err := s.repos.Auth.GetUserByID(ctx, user.ID)
+	if err != nil || byID == nil || byID.Name != user.Name {
+		return fmt.Errorf("GetUserByID: expected %q, got %+v (%v)", user.Name, byID, err)
+	}
+
+	// 없는 사용자는 에러 없이 nil을 반환
+	missing, err := s.repos.Auth.GetUserByName(ctx, user.Name+"-missing")
+	if err != nil || missing != nil {
+		return fmt.Errorf("GetUserByName: expected nil for missing user, got %+v (%v)", missing, err)
+	}
+	return nil
+}
+
+func (s *suite) testCredentials(ctx context.Context) error {
+	legacy, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	credentials, err := s.repos.Auth.GetCredentialsByName(ctx, legacy.Name)
+	if err != nil || credentials == nil || credentials.HasPassword() || credentials.Email != "" {
+		return fmt.Errorf("GetCredentialsByName: expected user without password, got %+v (%v)", credentials, err)
+	}
+
+	email := legacy.Name + "@example.com"
+	claimed, err := s.repos.Auth.ClaimAccount(ctx, legacy.ID, email, "hash-1")
+	if err != nil || claimed.Email != email || claimed.IsEmailVerified() {
+		return fmt.Errorf("ClaimAccount: expected unverified email %q, got %+v (%v)", email, claimed, err)
+	}
+	_, err = s.repos.Auth.ClaimAccount(ctx, legacy.ID, email, "hash-2")
+	if err := expectErr("ClaimAccount(claimed)", err, repository.ErrAccountAlreadyClaimed); err != nil {
+		return err
+	}
+
+	exists, err := s.repos.Auth.ExistEmail(ctx, email)
+	if err != nil || !exists {
+		return fmt.Errorf("ExistEmail: expected true, got %v (%v)", exists, err)
+	}
+	if _, err := s.repos.Auth.CreateUserWithPassword(ctx, legacy.Name+"-other", email, "hash"); err == nil {
+		return errors.New("CreateUserWithPassword: duplicate email must fail")
+	}
+
+	if err := s.repos.Auth.UpdatePassword(ctx, legacy.ID, "hash-3"); err != nil {
+		return fmt.Errorf("UpdatePassword: %w", err)
+	}
+	credentials, err = s.repos.Auth.GetCredentialsByEmail(ctx, email)
+	if err != nil || credentials == nil || credentials.PasswordHash != "hash-3" {
+		return fmt.Errorf("GetCredentialsByEmail: expected updated hash, got %+v (%v)", credentials, err)
+	}
+
+	// 토큰은 한 번만 사용할 수 있고, 같은 사용자의 다른 토큰도 함께 무효화됨
+	expiresAt := time.Now().Add(time.Hour)
+	if err := s.repos.Auth.CreatePasswordToken(ctx, legacy.ID, model.PASSWORD_TOKEN_RESET, legacy.Name+"-a", expiresAt); err != nil {
+		return fmt.Errorf("CreatePasswordToken: %w", err)
+	}
+	if err := s.repos.Auth.CreatePasswordToken(ctx, legacy.ID, model.PASSWORD_TOKEN_RESET, legacy.Name+"-b", expiresAt); err != nil {
+		return fmt.Errorf("CreatePasswordToken: %w", err)
+	}
+	if err := s.repos.Auth.CreatePasswordToken(ctx, legacy.ID, model.PASSWORD_TOKEN_RESET, legacy.Name+"-expired", time.Now().Add(-time.Minute)); err != nil {
+		return fmt.Errorf("CreatePasswordToken: %w", err)
+	}
+	_, err = s.repos.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_RESET, legacy.Name+"-expired")
+	if err := expectErr("ConsumePasswordToken(expired)", err, repository.ErrPasswordTokenInvalid); err != nil {
+		return err
+	}
+	userID, err := s.repos.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_RESET, legacy.Name+"-a")
+	if err != nil || userID != legacy.ID {
+		return fmt.Errorf("ConsumePasswordToken: expected user %d, got %d (%v)", legacy.ID, userID, err)
+	}
+	for _, token := range []string{legacy.Name + "-a", legacy.Name + "-b"} {
+		_, err = s.repos.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_RESET, token)
+		if err := expectErr("ConsumePasswordToken(used)", err, repository.ErrPasswordTokenInvalid); err != nil {
+			return err
+		}
+	}
+
+	// 용도가 다른 토큰으로는 사용할 수 없음
+	if err := s.repos.Auth.CreatePasswordToken(ctx, legacy.ID, model.PASSWORD_TOKEN_VERIFY_EMAIL, legacy.Name+"-verify", expiresAt); err != nil {
+		return fmt.Errorf("CreatePasswordToken(verify_email): %w", err)
+	}
+	_, err = s.repos.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_CLAIM, legacy.Name+"-verify")
+	if err := expectErr("ConsumePasswordToken(other purpose)", err, repository.ErrPasswordTokenInvalid); err != nil {
+		return err
+	}
+	userID, err = s.repos.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_VERIFY_EMAIL, legacy.Name+"-verify")
+	if err != nil || userID != legacy.ID {
+		return fmt.Errorf("ConsumePasswordToken(verify_email): expected user %d, got %d (%v)", legacy.ID, userID, err)
+	}
+	if err := s.repos.Auth.MarkEmailVerified(ctx, legacy.ID); err != nil {
+		return fmt.Errorf("MarkEmailVerified: %w", err)
+	}
+	verified, err := s.repos.Auth.GetUserByID(ctx, legacy.ID)
+	if err != nil || verified == nil || !verified.IsEmailVerified() {
+		return fmt.Errorf("MarkEmailVerified: expected verified email, got %+v (%v)", verified, err)
+	}
+
+	withoutEmail, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.repos.Auth.MarkEmailVerified(ctx, withoutEmail.ID)
+	return expectErr("MarkEmailVerified(no email)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testTasks(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	other, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	first, err := s.newTask(ctx, user.ID, workspaceID, "alpha report")
+	if err != nil {
+		return fmt.Errorf("CreateTasks: %w", err)
+	}
+	if first.ID == 0 || first.Version != 1 || first.ChangeSeq == 0 {
+		return fmt.Errorf("CreateTasks: id, version 1 and change_seq must be set, got %+v", first)
+	}
+	if _, err := s.newTask(ctx, user.ID, workspaceID, "beta report"); err != nil {
+		return fmt.Errorf("CreateTasks: %w", err)
+	}
+	if _, err := s.newTask(ctx, user.ID, workspaceID, "gamma"); err != nil {
+		return fmt.Errorf("CreateTasks: %w", err)
+	}
+
+	found, err := s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, first.ID)
+	if err != nil || found.Title != first.Title || !found.DueDate.Equal(first.DueDate) {
+		return fmt.Errorf("GetTasksByTaskID: expected %+v, got %+v (%v)", first, found, err)
+	}
+	if _, err := s.repos.Task.GetTasksByTaskID(ctx, other.ID, workspaceID, first.ID); err != nil {
+		if err := expectErr("GetTasksByTaskID(other user)", err, sql.ErrNoRows); err != nil {
+			return err
+		}
+	} else {
+		return errors.New("GetTasksByTaskID: other user's task must not be visible")
+	}
+
+	list, err := s.repos.Task.GetTasks(ctx, user.ID, workspaceID, map[string]interface{}{"title": "report", "limit": 1, "page": 1})
+	if err != nil {
+		return fmt.Errorf("GetTasks: %w", err)
+	}
+	if len(list.Tasks) != 1 || list.TotalCount != 2 {
+		return fmt.Errorf("GetTasks: expected 1 task of 2 total, got %d of %d", len(list.Tasks), list.TotalCount)
+	}
+
+	// 이전 버전으로 수정하면 충돌
+	stale := *found
+	found.Title = "alpha report v2"
+	updated, err := s.repos.Task.UpdateTasks(ctx, user.ID, workspaceID, first.ID, found)
+	if err != nil || updated.Version != 2 || updated.ChangeSeq <= first.ChangeSeq {
+		return fmt.Errorf("UpdateTasks: expected version 2 and newer change_seq, got %+v (%v)", updated, err)
+	}
+	stale.Title = "lost update"
+	_, err = s.repos.Task.UpdateTasks(ctx, user.ID, workspaceID, first.ID, &stale)
+	if err := expectErr("UpdateTasks(stale version)", err, repository.ErrVersionConflict); err != nil {
+		return err
+	}
+
+	err = s.repos.Task.DeleteTasks(ctx, user.ID, workspaceID, first.ID, 1)
+	if err := expectErr("DeleteTasks(stale version)", err, repository.ErrVersionConflict); err != nil {
+		return err
+	}
+	if err := s.repos.Task.DeleteTasks(ctx, user.ID, workspaceID, first.ID, updated.Version); err != nil {
+		return fmt.Errorf("DeleteTasks: %w", err)
+	}
+	err = s.repos.Task.DeleteTasks(ctx, user.ID, workspaceID, first.ID, 0)
+	return expectErr("DeleteTasks(deleted)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testDueDates(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return err
+	}
+	// 서울 기준 2030-01-01은 UTC 2029-12-31T15:00 ~ 2030-01-01T15:00
+	create := func(title string, dueDate time.Time, allDay bool, completed bool) (*model.Task, error) {
+		return s.repos.Task.CreateTasks(ctx, user.ID, workspaceID, &model.Task{Title: title, DueDate: dueDate, AllDay: allDay, IsCompleted: completed, Priority: model.PRIORITY_MEDIUM})
+	}
+	fixtures := []struct {
+		title     string
+		dueDate   time.Time
+		allDay    bool
+		completed bool
+	}{
+		{"timed today", time.Date(2030, 1, 1, 8, 30, 0, 0, seoul), false, false},
+		{"timed yesterday local", time.Date(2029, 12, 31, 23, 0, 0, 0, seoul), false, false},
+		{"all day today", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), true, false},
+		{"all day yesterday", time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC), true, false},
+		{"done yesterday", time.Date(2029, 12, 31, 12, 0, 0, 0, seoul), false, true},
+	}
+	for _, fixture := range fixtures {
+		if _, err := create(fixture.title, fixture.dueDate, fixture.allDay, fixture.completed); err != nil {
+			return fmt.Errorf("CreateTasks(%s): %w", fixture.title, err)
+		}
+	}
+
+	titles := func(search_query map[string]interface{}) ([]string, error) {
+		result, err := s.repos.Task.GetTasks(ctx, user.ID, workspaceID, search_query)
+		if err != nil {
+			return nil, err
+		}
+		found := []string{}
+		for _, task := range result.Tasks {
+			if task.DueDate.Location() != time.UTC {
+				return nil, fmt.Errorf("due_date must be read as UTC, got %v", task.DueDate)
+			}
+			found = append(found, task.Title)
+		}
+		slices.Sort(found)
+		return found, nil
+	}
+
+	now := time.Date(2030, 1, 1, 10, 0, 0, 0, seoul)
+	cases := []struct {
+		due      string
+		expected []string
+	}{
+		{model.TASK_DUE_TODAY, []string{"all day today", "timed today"}},
+		{model.TASK_DUE_OVERDUE, []string{"all day yesterday", "timed today", "timed yesterday local"}},
+	}
+	for _, c := range cases {
+		query := map[string]interface{}{"due": c.due, "limit": 100}
+		if err := model.ResolveTaskDueFilters(query, seoul, now); err != nil {
+			return err
+		}
+		found, err := titles(query)
+		if err != nil {
+			return fmt.Errorf("GetTasks(due=%s): %w", c.due, err)
+		}
+		if !slices.Equal(found, c.expected) {
+			return fmt.Errorf("GetTasks(due=%s): expected %v, got %v", c.due, c.expected, found)
+		}
+	}
+
+	task, err := create("all day round trip", time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC), true, false)
+	if err != nil {
+		return err
+	}
+	task, err = s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, task.ID)
+	if err != nil || !task.AllDay || !task.DueDate.Equal(time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		return fmt.Errorf("GetTasksByTaskID(all day): unexpected task %+v (%v)", task, err)
+	}
+	return nil
+}
+
+func (s *suite) testTags(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.repos.Tag.CreateTags(ctx, user.ID, workspaceID, &model.Tag{Name: "work", Color: "#112233"})
+	if err != nil {
+		return fmt.Errorf("CreateTags: %w", err)
+	}
+	if tag.ID == 0 || tag.Version != 1 {
+		return fmt.Errorf("CreateTags: id and version 1 must be set, got %+v", tag)
+	}
+
+	found, err := s.repos.Tag.GetTagsByTagID(ctx, user.ID, workspaceID, tag.ID)
+	if err != nil || found.Name != "work" || found.Color != "#112233" {
+		return fmt.Errorf("GetTagsByTagID: expected %+v, got %+v (%v)", tag, found, err)
+	}
+	tags, err := s.repos.Tag.GetTagsByWorkspaceID(ctx, user.ID, workspaceID)
+	if err != nil || len(tags) != 1 {
+		return fmt.Errorf("GetTagsByWorkspaceID: expected 1 tag, got %d (%v)", len(tags), err)
+	}
+
+	stale := *found
+	found.Name = "office"
+	updated, err := s.repos.Tag.UpdateTags(ctx, user.ID, workspaceID, tag.ID, found)
+	if err != nil || updated.Version != 2 {
+		return fmt.Errorf("UpdateTags: expected version 2, got %+v (%v)", updated, err)
+	}
+	_, err = s.repos.Tag.UpdateTags(ctx, user.ID, workspaceID, tag.ID, &stale)
+	if err := expectErr("UpdateTags(stale version)", err, repository.ErrVersionConflict); err != nil {
+		return err
+	}
+
+	if err := s.repos.Tag.DeleteTags(ctx, user.ID, workspaceID, tag.ID, 0); err != nil {
+		return fmt.Errorf("DeleteTags: %w", err)
+	}
+	_, err = s.repos.Tag.GetTagsByTagID(ctx, user.ID, workspaceID, tag.ID)
+	return expectErr("GetTagsByTagID(deleted)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testTaskTags(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	task, err := s.newTask(ctx, user.ID, workspaceID, "linked")
+	if err != nil {
+		return err
+	}
+	tag, err := s.repos.Tag.CreateTags(ctx, user.ID, workspaceID, &model.Tag{Name: "home", Color: "#445566"})
+	if err != nil {
+		return err
+	}
+
+	if err := s.repos.TaskTag.AddTagToTask(ctx, user.ID, workspaceID, task.ID, tag.ID); err != nil {
+		return fmt.Errorf("AddTagToTask: %w", err)
+	}
+	err = s.repos.TaskTag.AddTagToTask(ctx, user.ID, workspaceID, task.ID, tag.ID)
+	if err := expectErr("AddTagToTask(duplicate)", err, repository.ErrTagAlreadyLinked); err != nil {
+		return err
+	}
+
+	tags, err := s.repos.TaskTag.GetTagsByTaskID(ctx, user.ID, workspaceID, task.ID)
+	if err != nil || len(tags) != 1 || tags[0].ID != tag.ID {
+		return fmt.Errorf("GetTagsByTaskID: expected tag %d, got %+v (%v)", tag.ID, tags, err)
+	}
+
+	// 태그 연결은 작업 응답을 바꾸므로 작업 버전이 올라가야 함
+	linked, err := s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, task.ID)
+	if err != nil || linked.Version <= task.Version {
+		return fmt.Errorf("AddTagToTask: task version must increase, got %+v (%v)", linked, err)
+	}
+
+	if err := s.repos.TaskTag.RemoveTagFromTask(ctx, user.ID, workspaceID, task.ID, tag.ID); err != nil {
+		return fmt.Errorf("RemoveTagFromTask: %w", err)
+	}
+	err = s.repos.TaskTag.RemoveTagFromTask(ctx, user.ID, workspaceID, task.ID, tag.ID)
+	return expectErr("RemoveTagFromTask(missing)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testBulk(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	first, err := s.newTask(ctx, user.ID, workspaceID, "bulk one")
+	if err != nil {
+		return err
+	}
+	second, err := s.newTask(ctx, user.ID, workspaceID, "bulk two")
+	if err != nil {
+		return err
+	}
+
+	response, err := s.repos.Task.BulkTasks(ctx, user.ID, workspaceID, &model.BulkTaskRequest{
+		Action:  model.BULK_ACTION_COMPLETE,
+		TaskIDs: []int{first.ID, second.ID},
+	}, nil)
+	if err != nil || !response.Committed || len(response.Results) != 2 {
+		return fmt.Errorf("BulkTasks(complete): expected 2 committed results, got %+v (%v)", response, err)
+	}
+	for _, result := range response.Results {
+		if result.Status != model.BULK_STATUS_UPDATED {
+			return fmt.Errorf("BulkTasks(complete): expected updated, got %+v", result)
+		}
+	}
+
+	// 없는 작업이 포함되면 전체가 롤백되어야 함
+	response, err = s.repos.Task.BulkTasks(ctx, user.ID, workspaceID, &model.BulkTaskRequest{
+		Action:  model.BULK_ACTION_INCOMPLETE,
+		TaskIDs: []int{first.ID, first.ID + second.ID + 1_000_000},
+	}, nil)
+	if err != nil || response.Committed {
+		return fmt.Errorf("BulkTasks(not found): expected uncommitted response, got %+v (%v)", response, err)
+	}
+	task, err := s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, first.ID)
+	if err != nil || !task.IsCompleted {
+		return fmt.Errorf("BulkTasks(not found): changes must be rolled back, got %+v (%v)", task, err)
+	}
+
+	filter := &model.BulkTaskFilter{Title: stringPtr("bulk")}
+	response, err = s.repos.Task.BulkTasks(ctx, user.ID, workspaceID, &model.BulkTaskRequest{
+		Action: model.BULK_ACTION_DELETE,
+		Filter: filter,
+	}, filter.ToSearchQuery())
+	if err != nil || !response.Committed || len(response.Results) != 2 {
+		return fmt.Errorf("BulkTasks(filter delete): expected 2 deleted, got %+v (%v)", response, err)
+	}
+	return nil
+}
+
+func (s *suite) testSync(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	task, err := s.newTask(ctx, user.ID, workspaceID, "synced")
+	if err != nil {
+		return err
+	}
+
+	changes, err := s.repos.Sync.GetChanges(ctx, user.ID, workspaceID, 0)
+	if err != nil || len(changes.Tasks) != 1 || changes.NextToken == "" {
+		return fmt.Errorf("GetChanges: expected 1 task and a token, got %+v (%v)", changes, err)
+	}
+	since, err := s.repos.Sync.GetChangeSeq(ctx, user.ID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("GetChangeSeq: %w", err)
+	}
+
+	// 클라이언트에서 생성한 작업
+	created, err := s.repos.Sync.ApplyTaskMutation(ctx, user.ID, workspaceID, &model.SyncMutation{
+		Entity:    model.SYNC_ENTITY_TASK,
+		Op:        model.SYNC_OP_UPSERT,
+		ClientID:  "client-1",
+		UpdatedAt: time.Now().UTC(),
+		Fields: map[string]json.RawMessage{
+			"title":    json.RawMessage(`"from client"`),
+			"due_date": json.RawMessage(`"2030-02-01T00:00:00Z"`),
+		},
+	})
+	if err != nil || created.Status != model.SYNC_STATUS_APPLIED || created.ID == 0 {
+		return fmt.Errorf("ApplyTaskMutation(create): expected applied with id, got %+v (%v)", created, err)
+	}
+
+	// 서버보다 오래 된 변경은 충돌로 보고되어야 함
+	stale, err := s.repos.Sync.ApplyTaskMutation(ctx, user.ID, workspaceID, &model.SyncMutation{
+		Entity:    model.SYNC_ENTITY_TASK,
+		Op:        model.SYNC_OP_UPSERT,
+		ID:        task.ID,
+		UpdatedAt: time.Now().Add(-time.Hour).UTC(),
+		Fields:    map[string]json.RawMessage{"title": json.RawMessage(`"stale title"`)},
+	})
+	if err != nil || stale.Status != model.SYNC_STATUS_CONFLICT || len(stale.Conflicts) != 1 {
+		return fmt.Errorf("ApplyTaskMutation(stale): expected conflict on title, got %+v (%v)", stale, err)
+	}
+
+	if err := s.repos.Task.DeleteTasks(ctx, user.ID, workspaceID, task.ID, 0); err != nil {
+		return err
+	}
+	changes, err = s.repos.Sync.GetChanges(ctx, user.ID, workspaceID, since)
+	if err != nil {
+		return fmt.Errorf("GetChanges(since): %w", err)
+	}
+	if len(changes.Tasks) != 1 || changes.Tasks[0].ID != created.ID {
+		return fmt.Errorf("GetChanges(since): expected only task %d, got %+v", created.ID, changes.Tasks)
+	}
+	if len(changes.Tombstones) != 1 || changes.Tombstones[0].Entity != model.SYNC_ENTITY_TASK {
+		return fmt.Errorf("GetChanges(since): expected 1 task tombstone, got %+v", changes.Tombstones)
+	}
+	return nil
+}
+
+// testSyncRESTEdits는 REST 수정이 바꾼 필드만 수정 시각을 기록하는지 검사하는 메서드
+func (s *suite) testSyncRESTEdits(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	task, err := s.newTask(ctx, user.ID, workspaceID, "before")
+	if err != nil {
+		return err
+	}
+
+	// 오프라인 기기에서 priority를 바꾼 뒤, 동기화하기 전에 REST로 title만 바꿈
+	offlineAt := time.Now().UTC()
+	found, err := s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, task.ID)
+	if err != nil {
+		return fmt.Errorf("GetTasksByTaskID: %w", err)
+	}
+	found.Title = "after"
+	if _, err := s.repos.Task.UpdateTasks(ctx, user.ID, workspaceID, task.ID, found); err != nil {
+		return fmt.Errorf("UpdateTasks: %w", err)
+	}
+
+	// REST에서 바꾸지 않은 priority는 오프라인 수정이 적용되고, 바꾼 title은 충돌로 보고됨
+	result, err := s.repos.Sync.ApplyTaskMutation(ctx, user.ID, workspaceID, &model.SyncMutation{
+		Entity:    model.SYNC_ENTITY_TASK,
+		Op:        model.SYNC_OP_UPSERT,
+		ID:        task.ID,
+		UpdatedAt: offlineAt,
+		Fields: map[string]json.RawMessage{
+			"title":    json.RawMessage(`"offline"`),
+			"priority": json.RawMessage(`"high"`),
+		},
+	})
+	if err != nil || result.Status != model.SYNC_STATUS_CONFLICT || len(result.Conflicts) != 1 || result.Conflicts[0].Field != "title" {
+		return fmt.Errorf("ApplyTaskMutation(offline): expected conflict on title only, got %+v (%v)", result, err)
+	}
+	synced, err := s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, task.ID)
+	if err != nil || synced.Title != "after" || synced.Priority != model.PRIORITY_HIGH {
+		return fmt.Errorf("GetTasksByTaskID: expected REST title and offline priority, got %+v (%v)", synced, err)
+	}
+	return nil
+}
+
+func (s *suite) testWorkspaces(ctx context.Context) error {
+	users := make([]*model.User, 4)
+	for i := range users {
+		user, err := s.newUser(ctx)
+		if err != nil {
+			return err
+		}
+		users[i] = user
+	}
+	owner, member, viewer, outsider := users[0], users[1], users[2], users[3]
+	personalID, err := s.personalWorkspace(ctx, owner.ID)
+	if err != nil {
+		return err
+	}
+
+	// 개인 작업 공간에는 다른 구성원을 추가할 수 없음
+	err = s.repos.Workspace.AddMember(ctx, personalID, member.ID, model.WORKSPACE_ROLE_MEMBER)
+	if err := expectErr("AddMember(personal)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+
+	workspace, err := s.repos.Workspace.CreateWorkspace(ctx, owner.ID, "team")
+	if err != nil || workspace.Role != model.WORKSPACE_ROLE_OWNER || workspace.Personal || workspace.MemberCount != 1 {
+		return fmt.Errorf("CreateWorkspace: expected shared workspace owned by %d, got %+v (%v)", owner.ID, workspace, err)
+	}
+	if err := s.repos.Workspace.AddMember(ctx, workspace.ID, member.ID, model.WORKSPACE_ROLE_MEMBER); err != nil {
+		return fmt.Errorf("AddMember: %w", err)
+	}
+	if err := s.repos.Workspace.AddMember(ctx, workspace.ID, viewer.ID, model.WORKSPACE_ROLE_VIEWER); err != nil {
+		return fmt.Errorf("AddMember: %w", err)
+	}
+	err = s.repos.Workspace.AddMember(ctx, workspace.ID, member.ID, model.WORKSPACE_ROLE_VIEWER)
+	if err := expectErr("AddMember(duplicate)", err, repository.ErrWorkspaceMemberExists); err != nil {
+		return err
+	}
+	workspaces, err := s.repos.Workspace.GetWorkspaces(ctx, owner.ID)
+	if err != nil || len(workspaces) != 2 || !workspaces[0].Personal || workspaces[1].MemberCount != 3 {
+		return fmt.Errorf("GetWorkspaces: expected personal and team workspaces, got %+v (%v)", workspaces, err)
+	}
+
+	// 구성원이 만든 작업은 다른 구성원도 조회하고 수정할 수 있으며, 만든 사용자는 바뀌지 않음
+	task, err := s.newTask(ctx, member.ID, workspace.ID, "shared")
+	if err != nil {
+		return fmt.Errorf("CreateTasks(member): %w", err)
+	}
+	found, err := s.repos.Task.GetTasksByTaskID(ctx, viewer.ID, workspace.ID, task.ID)
+	if err != nil || found.UserID != member.ID || found.WorkspaceID != workspace.ID {
+		return fmt.Errorf("GetTasksByTaskID(viewer): expected task by %d, got %+v (%v)", member.ID, found, err)
+	}
+	found.Title = "edited by owner"
+	updated, err := s.repos.Task.UpdateTasks(ctx, owner.ID, workspace.ID, task.ID, found)
+	if err != nil || updated.UserID != member.ID {
+		return fmt.Errorf("UpdateTasks(owner): expected creator %d to be kept, got %+v (%v)", member.ID, updated, err)
+	}
+
+	// viewer는 수정할 수 없고, 구성원이 아니면 조회할 수 없음
+	_, err = s.newTask(ctx, viewer.ID, workspace.ID, "denied")
+	if err := expectErr("CreateTasks(viewer)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	_, err = s.repos.Task.GetTasksByTaskID(ctx, outsider.ID, workspace.ID, task.ID)
+	if err := expectErr("GetTasksByTaskID(outsider)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+	_, err = s.repos.Sync.GetChangeSeq(ctx, outsider.ID, workspace.ID)
+	if err := expectErr("GetChangeSeq(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+
+	// 다른 작업 공간의 태그는 연결할 수 없음
+	tag, err := s.repos.Tag.CreateTags(ctx, owner.ID, personalID, &model.Tag{Name: "private", Color: "#000000"})
+	if err != nil {
+		return err
+	}
+	err = s.repos.TaskTag.AddTagToTask(ctx, owner.ID, workspace.ID, task.ID, tag.ID)
+	if err := expectErr("AddTagToTask(other workspace)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	// viewer와 admin은 owner의 역할을 바꿀 수 없고, owner는 작업 공간을 나갈 수 없음
+	if err := s.repos.Workspace.UpdateMemberRole(ctx, owner.ID, workspace.ID, member.ID, model.WORKSPACE_ROLE_ADMIN); err != nil {
+		return fmt.Errorf("UpdateMemberRole: %w", err)
+	}
+	err = s.repos.Workspace.UpdateMemberRole(ctx, viewer.ID, workspace.ID, member.ID, model.WORKSPACE_ROLE_VIEWER)
+	if err := expectErr("UpdateMemberRole(viewer)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.Workspace.UpdateMemberRole(ctx, member.ID, workspace.ID, owner.ID, model.WORKSPACE_ROLE_VIEWER)
+	if err := expectErr("UpdateMemberRole(owner)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.Workspace.LeaveWorkspace(ctx, owner.ID, workspace.ID)
+	if err := expectErr("LeaveWorkspace(owner)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+
+	// owner 계정을 삭제하면 admin이 owner가 되고, owner가 만든 작업은 작업 공간에 남음
+	ownerTask, err := s.newTask(ctx, owner.ID, workspace.ID, "left behind")
+	if err != nil {
+		return err
+	}
+	deletionAt := time.Now().Add(-time.Minute)
+	if err := s.repos.Account.ScheduleDeletion(ctx, owner.ID, &deletionAt); err != nil {
+		return err
+	}
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Workspace.HandOverWorkspaces(ctx, owner.ID); err != nil {
+			return err
+		}
+		_, err := tx.Account.DeleteUserDueForDeletion(ctx, owner.ID, time.Now())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("HandOverWorkspaces: %w", err)
+	}
+	successor, err := s.repos.Workspace.GetMember(ctx, member.ID, workspace.ID, member.ID)
+	if err != nil || successor.Role != model.WORKSPACE_ROLE_OWNER {
+		return fmt.Errorf("HandOverWorkspaces: expected %d to be the new owner, got %+v (%v)", member.ID, successor, err)
+	}
+	kept, err := s.repos.Task.GetTasksByTaskID(ctx, member.ID, workspace.ID, ownerTask.ID)
+	if err != nil || kept.UserID != member.ID {
+		return fmt.Errorf("HandOverWorkspaces: expected task reassigned to %d, got %+v (%v)", member.ID, kept, err)
+	}
+
+	// owner만 삭제할 수 있고, 삭제하면 작업도 함께 삭제 됨
+	err = s.repos.Workspace.DeleteWorkspace(ctx, viewer.ID, workspace.ID)
+	if err := expectErr("DeleteWorkspace(viewer)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	if err := s.repos.Workspace.DeleteWorkspace(ctx, member.ID, workspace.ID); err != nil {
+		return fmt.Errorf("DeleteWorkspace: %w", err)
+	}
+	_, err = s.repos.Task.GetTasksByTaskID(ctx, member.ID, workspace.ID, task.ID)
+	if err := expectErr("GetTasksByTaskID(deleted workspace)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+	memberPersonalID, err := s.personalWorkspace(ctx, member.ID)
+	if err != nil {
+		return err
+	}
+	err = s.repos.Workspace.DeleteWorkspace(ctx, member.ID, memberPersonalID)
+	return expectErr("DeleteWorkspace(personal)", err, repository.ErrWorkspaceAccessDenied)
+}
+
+// testWorkspaceNonMember는 구성원이 아닌 사용자가 작업 공간 ID만 알고 작업 / 태그 / 연결을 바꿀 수 없는지 검사하는 메서드
+func (s *suite) testWorkspaceNonMember(ctx context.Context) error {
+	owner, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	outsider, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspace, err := s.repos.Workspace.CreateWorkspace(ctx, owner.ID, "team")
+	if err != nil {
+		return fmt.Errorf("CreateWorkspace: %w", err)
+	}
+	task, err := s.newTask(ctx, owner.ID, workspace.ID, "owned")
+	if err != nil {
+		return err
+	}
+	linked, err := s.repos.Tag.CreateTags(ctx, owner.ID, workspace.ID, &model.Tag{Name: "linked", Color: "#000000"})
+	if err != nil {
+		return err
+	}
+	unlinked, err := s.repos.Tag.CreateTags(ctx, owner.ID, workspace.ID, &model.Tag{Name: "unlinked", Color: "#ffffff"})
+	if err != nil {
+		return err
+	}
+	if err := s.repos.TaskTag.AddTagToTask(ctx, owner.ID, workspace.ID, task.ID, linked.ID); err != nil {
+		return fmt.Errorf("AddTagToTask: %w", err)
+	}
+
+	edited := *task
+	edited.Title = "taken over"
+	_, err = s.repos.Task.UpdateTasks(ctx, outsider.ID, workspace.ID, task.ID, &edited)
+	if err := expectErr("UpdateTasks(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.Task.DeleteTasks(ctx, outsider.ID, workspace.ID, task.ID, 0)
+	if err := expectErr("DeleteTasks(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	_, err = s.repos.Tag.UpdateTags(ctx, outsider.ID, workspace.ID, linked.ID, &model.Tag{Name: "taken over", Color: "#ff0000", Version: linked.Version})
+	if err := expectErr("UpdateTags(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.Tag.DeleteTags(ctx, outsider.ID, workspace.ID, unlinked.ID, 0)
+	if err := expectErr("DeleteTags(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.TaskTag.AddTagToTask(ctx, outsider.ID, workspace.ID, task.ID, unlinked.ID)
+	if err := expectErr("AddTagToTask(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	err = s.repos.TaskTag.RemoveTagFromTask(ctx, outsider.ID, workspace.ID, task.ID, linked.ID)
+	if err := expectErr("RemoveTagFromTask(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	_, err = s.repos.Sync.ApplyTaskMutation(ctx, outsider.ID, workspace.ID, &model.SyncMutation{
+		Entity:    model.SYNC_ENTITY_TASK,
+		Op:        model.SYNC_OP_DELETE,
+		ID:        task.ID,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err := expectErr("ApplyTaskMutation(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	priority := model.PRIORITY_HIGH
+	_, err = s.repos.Task.BulkTasks(ctx, outsider.ID, workspace.ID, &model.BulkTaskRequest{Action: model.BULK_ACTION_SET_PRIORITY, TaskIDs: []int{task.ID}, Priority: &priority}, nil)
+	if err := expectErr("BulkTasks(outsider)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+
+	// 작업, 태그, 연결은 그대로 남아 있어야 함
+	found, err := s.repos.Task.GetTasksByTaskID(ctx, owner.ID, workspace.ID, task.ID)
+	if err != nil || found.Title != "owned" || found.Priority != model.PRIORITY_MEDIUM || found.Version != task.Version+1 {
+		return fmt.Errorf("GetTasksByTaskID: expected untouched task, got %+v (%v)", found, err)
+	}
+	tags, err := s.repos.TaskTag.GetTagsByTaskID(ctx, owner.ID, workspace.ID, task.ID)
+	if err != nil || len(tags) != 1 || tags[0].ID != linked.ID || tags[0].Name != "linked" {
+		return fmt.Errorf("GetTagsByTaskID: expected only the linked tag, got %+v (%v)", tags, err)
+	}
+	tag, err := s.repos.Tag.GetTagsByTagID(ctx, owner.ID, workspace.ID, unlinked.ID)
+	if err != nil || tag.ID != unlinked.ID {
+		return fmt.Errorf("GetTagsByTagID: expected the unlinked tag to remain, got %+v (%v)", tag, err)
+	}
+	return nil
+}
+
+func (s *suite) testInvitations(ctx context.Context) error {
+	users := make([]*model.User, 4)
+	for i := range users {
+		user, err := s.newUser(ctx)
+		if err != nil {
+			return err
+		}
+		users[i] = user
+	}
+	owner, member, invitee, outsider := users[0], users[1], users[2], users[3]
+	workspace, err := s.repos.Workspace.CreateWorkspace(ctx, owner.ID, "invited")
+	if err != nil {
+		return err
+	}
+	if err := s.repos.Workspace.AddMember(ctx, workspace.ID, member.ID, model.WORKSPACE_ROLE_MEMBER); err != nil {
+		return err
+	}
+	newInvitation := func(workspaceID int, userID *int, email string, role string, expiresAt time.Time) *model.WorkspaceInvitation {
+		return &model.WorkspaceInvitation{WorkspaceID: workspaceID, InviteeUserID: userID, Email: email, Role: role, ExpiresAt: expiresAt}
+	}
+	expiresAt := time.Now().Add(time.Hour)
+
+	// owner / admin만 공유 작업 공간에 초대할 수 있음
+	_, err = s.repos.Invitation.CreateInvitation(ctx, member.ID, newInvitation(workspace.ID, &invitee.ID, "", model.WORKSPACE_ROLE_MEMBER, expiresAt))
+	if err := expectErr("CreateInvitation(member)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+	personalID, err := s.personalWorkspace(ctx, owner.ID)
+	if err != nil {
+		return err
+	}
+	_, err = s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(personalID, &invitee.ID, "", model.WORKSPACE_ROLE_MEMBER, expiresAt))
+	if err := expectErr("CreateInvitation(personal)", err, repository.ErrWorkspaceAccessDenied); err != nil {
+		return err
+	}
+
+	// 같은 사람에게 다시 초대하면 이전 초대는 취소 됨
+	first, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, &invitee.ID, "", model.WORKSPACE_ROLE_VIEWER, expiresAt))
+	if err != nil || first.Status != model.INVITATION_STATUS_PENDING || first.InviteeName != invitee.Name || first.WorkspaceName != "invited" {
+		return fmt.Errorf("CreateInvitation: expected pending invitation for %q, got %+v (%v)", invitee.Name, first, err)
+	}
+	second, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, &invitee.ID, "", model.WORKSPACE_ROLE_MEMBER, expiresAt))
+	if err != nil {
+		return fmt.Errorf("CreateInvitation: %w", err)
+	}
+	pending, err := s.repos.Invitation.GetPendingInvitations(ctx, invitee.ID)
+	if err != nil || len(pending) != 1 || pending[0].ID != second.ID {
+		return fmt.Errorf("GetPendingInvitations: expected only invitation %d, got %+v (%v)", second.ID, pending, err)
+	}
+	_, err = s.repos.Invitation.RespondInvitation(ctx, invitee.ID, first.ID, true, false)
+	if err := expectErr("RespondInvitation(superseded)", err, repository.ErrInvitationNotPending); err != nil {
+		return err
+	}
+
+	// 초대 받은 사용자만 응답할 수 있고, 수락하면 초대의 역할로 구성원이 됨
+	_, err = s.repos.Invitation.RespondInvitation(ctx, outsider.ID, second.ID, true, false)
+	if err := expectErr("RespondInvitation(outsider)", err, repository.ErrInvitationNotForUser); err != nil {
+		return err
+	}
+	accepted, err := s.repos.Invitation.RespondInvitation(ctx, invitee.ID, second.ID, true, false)
+	if err != nil || accepted.Status != model.INVITATION_STATUS_ACCEPTED || accepted.RespondedAt == nil {
+		return fmt.Errorf("RespondInvitation: expected accepted invitation, got %+v (%v)", accepted, err)
+	}
+	joined, err := s.repos.Workspace.GetMember(ctx, invitee.ID, workspace.ID, invitee.ID)
+	if err != nil || joined.Role != model.WORKSPACE_ROLE_MEMBER {
+		return fmt.Errorf("RespondInvitation: expected %d to join as member, got %+v (%v)", invitee.ID, joined, err)
+	}
+	_, err = s.repos.Invitation.RespondInvitation(ctx, invitee.ID, second.ID, false, false)
+	if err := expectErr("RespondInvitation(answered)", err, repository.ErrInvitationNotPending); err != nil {
+		return err
+	}
+
+	// 가입하지 않은 이메일로 초대하면 같은 이메일로 가입한 사용자가 응답할 수 있음
+	// 이메일을 인증하기 전에는 초대 목록에 나오지 않고 서명 된 링크로만 응답할 수 있음
+	email := fmt.Sprintf("invitee-%d@example.com", time.Now().UnixNano())
+	byEmail, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, nil, email, model.WORKSPACE_ROLE_VIEWER, expiresAt))
+	if err != nil || byEmail.Email != email || byEmail.RecipientUserID != nil {
+		return fmt.Errorf("CreateInvitation(email): expected invitation for %q, got %+v (%v)", email, byEmail, err)
+	}
+	registered, err := s.repos.Auth.CreateUserWithPassword(ctx, email, email, "hash")
+	if err != nil {
+		return err
+	}
+	pending, err = s.repos.Invitation.GetPendingInvitations(ctx, registered.ID)
+	if err != nil || len(pending) != 0 {
+		return fmt.Errorf("GetPendingInvitations(unverified email): expected no invitations, got %+v (%v)", pending, err)
+	}
+	_, err = s.repos.Invitation.RespondInvitation(ctx, registered.ID, byEmail.ID, true, false)
+	if err := expectErr("RespondInvitation(unverified email)", err, repository.ErrInvitationNotForUser); err != nil {
+		return err
+	}
+	signedEmail := fmt.Sprintf("signed-%d@example.com", time.Now().UnixNano())
+	bySignedLink, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, nil, signedEmail, model.WORKSPACE_ROLE_VIEWER, expiresAt))
+	if err != nil {
+		return err
+	}
+	signedUser, err := s.repos.Auth.CreateUserWithPassword(ctx, signedEmail, signedEmail, "hash")
+	if err != nil {
+		return err
+	}
+	viaLink, err := s.repos.Invitation.RespondInvitation(ctx, signedUser.ID, bySignedLink.ID, true, true)
+	if err != nil || viaLink.Status != model.INVITATION_STATUS_ACCEPTED {
+		return fmt.Errorf("RespondInvitation(signed link): expected accepted invitation, got %+v (%v)", viaLink, err)
+	}
+	if err := s.repos.Auth.MarkEmailVerified(ctx, registered.ID); err != nil {
+		return err
+	}
+	pending, err = s.repos.Invitation.GetPendingInvitations(ctx, registered.ID)
+	if err != nil || len(pending) != 1 || pending[0].ID != byEmail.ID || pending[0].InviteeUserID != nil {
+		return fmt.Errorf("GetPendingInvitations(email): expected invitation %d without invitee ID, got %+v (%v)", byEmail.ID, pending, err)
+	}
+	declined, err := s.repos.Invitation.RespondInvitation(ctx, registered.ID, byEmail.ID, false, false)
+	if err != nil || declined.Status != model.INVITATION_STATUS_DECLINED {
+		return fmt.Errorf("RespondInvitation(decline): expected declined invitation, got %+v (%v)", declined, err)
+	}
+	if _, err := s.repos.Workspace.GetMember(ctx, owner.ID, workspace.ID, registered.ID); !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("RespondInvitation(decline): expected %d not to join, got %v", registered.ID, err)
+	}
+
+	// 만료 된 초대는 목록에 나오지 않고 응답할 수 없음
+	expired, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, &registered.ID, "", model.WORKSPACE_ROLE_MEMBER, time.Now().Add(-time.Minute)))
+	if err != nil || expired.Status != model.INVITATION_STATUS_EXPIRED {
+		return fmt.Errorf("CreateInvitation(expired): expected expired status, got %+v (%v)", expired, err)
+	}
+	_, err = s.repos.Invitation.RespondInvitation(ctx, registered.ID, expired.ID, true, false)
+	if err := expectErr("RespondInvitation(expired)", err, repository.ErrInvitationExpired); err != nil {
+		return err
+	}
+
+	// owner / admin만 취소할 수 있고, 취소한 초대에는 응답할 수 없음
+	revocable, err := s.repos.Invitation.CreateInvitation(ctx, owner.ID, newInvitation(workspace.ID, &outsider.ID, "", model.WORKSPACE_ROLE_MEMBER, expiresAt))
+	if err != nil {
+		return err
+	}
+	listed, err := s.repos.Invitation.GetWorkspaceInvitations(ctx, owner.ID, workspace.ID)
+	if err != nil || len(listed) != 1 || listed[0].ID != revocable.ID {
+		return fmt.Errorf("GetWorkspaceInvitations: expected only invitation %d, got %+v (%v)", revocable.ID, listed, err)
+	}
+	listed, err = s.repos.Invitation.GetWorkspaceInvitations(ctx, member.ID, workspace.ID)
+	if err != nil || len(listed) != 0 {
+		return fmt.Errorf("GetWorkspaceInvitations(member): expected no invitations, got %+v (%v)", listed, err)
+	}
+	_, err = s.repos.Invitation.RevokeInvitation(ctx, member.ID, workspace.ID, revocable.ID)
+	if err := expectErr("RevokeInvitation(member)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+	revoked, err := s.repos.Invitation.RevokeInvitation(ctx, owner.ID, workspace.ID, revocable.ID)
+	if err != nil || revoked.Status != model.INVITATION_STATUS_REVOKED || revoked.RecipientUserID == nil || *revoked.RecipientUserID != outsider.ID {
+		return fmt.Errorf("RevokeInvitation: expected revoked invitation for %d, got %+v (%v)", outsider.ID, revoked, err)
+	}
+	_, err = s.repos.Invitation.RespondInvitation(ctx, outsider.ID, revocable.ID, true, false)
+	return expectErr("RespondInvitation(revoked)", err, repository.ErrInvitationNotPending)
+}
+
+func (s *suite) testTokens(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	created, err := s.repos.Token.CreateToken(ctx, &model.PersonalAccessToken{
+		UserID:      user.ID,
+		Name:        "ci",
+		TokenPrefix: "lux_pat_abcd",
+		Scopes:      []string{"tasks:read", "tasks:write"},
+		ExpiresAt:   &expiresAt,
+	}, user.Name+"-hash")
+	if err != nil {
+		return fmt.Errorf("CreateToken: %w", err)
+	}
+	if created.ID == 0 || len(created.Scopes) != 2 || created.ExpiresAt == nil || !created.ExpiresAt.Equal(expiresAt) || created.LastUsedAt != nil {
+		return fmt.Errorf("CreateToken: unexpected token %+v", created)
+	}
+
+	found, err := s.repos.Token.GetTokenByHash(ctx, user.Name+"-hash")
+	if err != nil || found.ID != created.ID || !found.HasScope("tasks:write") {
+		return fmt.Errorf("GetTokenByHash: expected token %d, got %+v (%v)", created.ID, found, err)
+	}
+	_, err = s.repos.Token.GetTokenByHash(ctx, user.Name+"-missing")
+	if err := expectErr("GetTokenByHash(missing)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	usedAt := time.Date(2029, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.repos.Token.UpdateLastUsed(ctx, created.ID, usedAt, "10.0.0.1"); err != nil {
+		return fmt.Errorf("UpdateLastUsed: %w", err)
+	}
+	tokens, err := s.repos.Token.GetTokensByUserID(ctx, user.ID)
+	if err != nil || len(tokens) != 1 || tokens[0].LastUsedAt == nil || !tokens[0].LastUsedAt.Equal(usedAt) || tokens[0].LastUsedIP != "10.0.0.1" {
+		return fmt.Errorf("GetTokensByUserID: expected last used token, got %+v (%v)", tokens, err)
+	}
+
+	if err := s.repos.Token.DeleteToken(ctx, user.ID, created.ID); err != nil {
+		return fmt.Errorf("DeleteToken: %w", err)
+	}
+	err = s.repos.Token.DeleteToken(ctx, user.ID, created.ID)
+	return expectErr("DeleteToken(deleted)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testIdentities(ctx context.Context) error {
+	name := fmt.Sprintf("conformance-sso-%d-%d", time.Now().UnixNano(), atomic.AddInt64(&userSequence, 1))
+	user, err := s.repos.Auth.CreateUserWithEmail(ctx, name, "")
+	if err != nil || user.Email != "" || user.EmailVerifiedAt != nil {
+		return fmt.Errorf("CreateUserWithEmail: expected user without email, got %+v (%v)", user, err)
+	}
+	// ID 공급자가 인증한 이메일로 가입하면 인증 된 이메일
+	withEmail, err := s.repos.Auth.CreateUserWithEmail(ctx, name+"-email", name+"-sso@example.com")
+	if err != nil || !withEmail.IsEmailVerified() {
+		return fmt.Errorf("CreateUserWithEmail: expected verified email, got %+v (%v)", withEmail, err)
+	}
+
+	issuer := "https://idp.example.com/" + name
+	missing, err := s.repos.Identity.GetIdentity(ctx, issuer, "subject-1")
+	if err != nil || missing != nil {
+		return fmt.Errorf("GetIdentity(missing): expected nil, got %+v (%v)", missing, err)
+	}
+
+	created, err := s.repos.Identity.CreateIdentity(ctx, &model.UserIdentity{UserID: user.ID, Issuer: issuer, Subject: "subject-1"})
+	if err != nil || created.ID == 0 || created.Email != "" || created.LastLoginAt != nil {
+		return fmt.Errorf("CreateIdentity: unexpected identity %+v (%v)", created, err)
+	}
+	if _, err := s.repos.Identity.CreateIdentity(ctx, &model.UserIdentity{UserID: user.ID, Issuer: issuer, Subject: "subject-1"}); err == nil {
+		return errors.New("CreateIdentity: duplicate issuer and subject must fail")
+	}
+
+	credentials, err := s.repos.Auth.GetCredentialsByID(ctx, user.ID)
+	if err != nil || credentials == nil || !credentials.HasIdentity || credentials.HasPassword() {
+		return fmt.Errorf("GetCredentialsByID: expected linked user without password, got %+v (%v)", credentials, err)
+	}
+
+	loginAt := time.Date(2029, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.repos.Identity.UpdateLastLogin(ctx, created.ID, name+"@example.com", loginAt); err != nil {
+		return fmt.Errorf("UpdateLastLogin: %w", err)
+	}
+	found, err := s.repos.Identity.GetIdentity(ctx, issuer, "subject-1")
+	if err != nil || found == nil || found.UserID != user.ID || found.Email != name+"@example.com" || found.LastLoginAt == nil || !found.LastLoginAt.Equal(loginAt) {
+		return fmt.Errorf("GetIdentity: expected updated identity, got %+v (%v)", found, err)
+	}
+	identities, err := s.repos.Identity.GetIdentitiesByUserID(ctx, user.ID)
+	if err != nil || len(identities) != 1 {
+		return fmt.Errorf("GetIdentitiesByUserID: expected 1 identity, got %+v (%v)", identities, err)
+	}
+
+	if err := s.repos.Identity.DeleteIdentity(ctx, user.ID, created.ID); err != nil {
+		return fmt.Errorf("DeleteIdentity: %w", err)
+	}
+	err = s.repos.Identity.DeleteIdentity(ctx, user.ID, created.ID)
+	return expectErr("DeleteIdentity(deleted)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testTwoFactor(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	totp, err := s.repos.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil || totp != nil {
+		return fmt.Errorf("GetTOTP(missing): expected nil, got %+v (%v)", totp, err)
+	}
+	if err := s.repos.TwoFactor.CreatePendingTOTP(ctx, user.ID, "secret-1"); err != nil {
+		return fmt.Errorf("CreatePendingTOTP: %w", err)
+	}
+	if err := s.repos.TwoFactor.CreatePendingTOTP(ctx, user.ID, "secret-2"); err != nil {
+		return fmt.Errorf("CreatePendingTOTP(replace): %w", err)
+	}
+	totp, err = s.repos.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil || totp == nil || totp.SecretEncrypted != "secret-2" || totp.IsEnabled() {
+		return fmt.Errorf("GetTOTP: expected pending secret-2, got %+v (%v)", totp, err)
+	}
+
+	if err := s.repos.TwoFactor.EnableTOTP(ctx, user.ID, 100); err != nil {
+		return fmt.Errorf("EnableTOTP: %w", err)
+	}
+	err = s.repos.TwoFactor.EnableTOTP(ctx, user.ID, 101)
+	if err := expectErr("EnableTOTP(enabled)", err, repository.ErrTOTPNotPending); err != nil {
+		return err
+	}
+	totp, err = s.repos.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil || !totp.IsEnabled() || totp.LastUsedStep != 100 {
+		return fmt.Errorf("GetTOTP: expected enabled at step 100, got %+v (%v)", totp, err)
+	}
+
+	// 같거나 이전 시간 단계는 다시 사용할 수 없음
+	for step, expected := range map[int64]bool{100: false, 99: false} {
+		used, err := s.repos.TwoFactor.UseTOTPStep(ctx, user.ID, step)
+		if err != nil || used != expected {
+			return fmt.Errorf("UseTOTPStep(%d): expected %v, got %v (%v)", step, expected, used, err)
+		}
+	}
+	used, err := s.repos.TwoFactor.UseTOTPStep(ctx, user.ID, 101)
+	if err != nil || !used {
+		return fmt.Errorf("UseTOTPStep(101): expected true, got %v (%v)", used, err)
+	}
+
+	// 키를 교체할 때 그 사이 secret이 바뀌었으면 덮어쓰지 않음
+	if err := s.repos.TwoFactor.UpdateTOTPSecret(ctx, user.ID, "secret-1", "secret-stale"); err != nil {
+		return fmt.Errorf("UpdateTOTPSecret(stale): %w", err)
+	}
+	if err := s.repos.TwoFactor.UpdateTOTPSecret(ctx, user.ID, "secret-2", "secret-3"); err != nil {
+		return fmt.Errorf("UpdateTOTPSecret: %w", err)
+	}
+	totp, err = s.repos.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil || totp.SecretEncrypted != "secret-3" || !totp.IsEnabled() || totp.LastUsedStep != 101 {
+		return fmt.Errorf("GetTOTP: expected re-encrypted secret-3, got %+v (%v)", totp, err)
+	}
+
+	if err := s.repos.TwoFactor.ReplaceRecoveryCodes(ctx, user.ID, []string{"code-a", "code-b"}); err != nil {
+		return fmt.Errorf("ReplaceRecoveryCodes: %w", err)
+	}
+	used, err = s.repos.TwoFactor.UseRecoveryCode(ctx, user.ID, "code-a")
+	if err != nil || !used {
+		return fmt.Errorf("UseRecoveryCode: expected true, got %v (%v)", used, err)
+	}
+	used, err = s.repos.TwoFactor.UseRecoveryCode(ctx, user.ID, "code-a")
+	if err != nil || used {
+		return fmt.Errorf("UseRecoveryCode(used): expected false, got %v (%v)", used, err)
+	}
+	remaining, err := s.repos.TwoFactor.CountRecoveryCodes(ctx, user.ID)
+	if err != nil || remaining != 1 {
+		return fmt.Errorf("CountRecoveryCodes: expected 1, got %d (%v)", remaining, err)
+	}
+
+	if err := s.repos.TwoFactor.DeleteTOTP(ctx, user.ID); err != nil {
+		return fmt.Errorf("DeleteTOTP: %w", err)
+	}
+	totp, err = s.repos.TwoFactor.GetTOTP(ctx, user.ID)
+	remaining, countErr := s.repos.TwoFactor.CountRecoveryCodes(ctx, user.ID)
+	if err != nil || countErr != nil || totp != nil || remaining != 0 {
+		return fmt.Errorf("DeleteTOTP: expected no totp and recovery codes, got %+v, %d (%v, %v)", totp, remaining, err, countErr)
+	}
+	return nil
+}
+
+func (s *suite) testAdmin(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if user.Role != model.USER_ROLE_USER || user.IsDisabled() {
+		return fmt.Errorf("CreateUser: expected enabled user role, got %+v", user)
+	}
+
+	if err := s.repos.Admin.SetUserRole(ctx, user.ID, model.USER_ROLE_ADMIN); err != nil {
+		return fmt.Errorf("SetUserRole: %w", err)
+	}
+	disabledAt := time.Date(2029, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.repos.Admin.SetUserDisabled(ctx, user.ID, &disabledAt); err != nil {
+		return fmt.Errorf("SetUserDisabled: %w", err)
+	}
+	found, err := s.repos.Auth.GetUserByID(ctx, user.ID)
+	if err != nil || found == nil || !found.IsAdmin() || found.DisabledAt == nil || !found.DisabledAt.Equal(disabledAt) {
+		return fmt.Errorf("GetUserByID: expected disabled admin, got %+v (%v)", found, err)
+	}
+	err = s.repos.Admin.SetUserRole(ctx, -1, model.USER_ROLE_ADMIN)
+	if err := expectErr("SetUserRole(missing)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	disabled := true
+	users, totalCount, err := s.repos.Admin.ListUsers(ctx, &model.AdminUserFilter{
+		Query: strings.ToUpper(user.Name), Role: model.USER_ROLE_ADMIN, Disabled: &disabled, Limit: 10, Page: 1,
+	})
+	if err != nil || totalCount != 1 || len(users) != 1 || users[0].ID != user.ID {
+		return fmt.Errorf("ListUsers: expected user %d, got %+v, %d (%v)", user.ID, users, totalCount, err)
+	}
+	users, totalCount, err = s.repos.Admin.ListUsers(ctx, &model.AdminUserFilter{Query: user.Name + "%_", Limit: 10, Page: 1})
+	if err != nil || totalCount != 0 || len(users) != 0 {
+		return fmt.Errorf("ListUsers: LIKE wildcards must be escaped, got %+v, %d (%v)", users, totalCount, err)
+	}
+
+	if _, err := s.newTask(ctx, user.ID, workspaceID, "usage"); err != nil {
+		return err
+	}
+	usage, err := s.repos.Admin.GetUserUsage(ctx, user.ID)
+	if err != nil || usage.Tasks != 1 || usage.CompletedTasks != 0 || usage.Tags != 0 || usage.TwoFactorEnabled {
+		return fmt.Errorf("GetUserUsage: expected 1 task, got %+v (%v)", usage, err)
+	}
+
+	if err := s.repos.Admin.CreateAuditLog(ctx, &model.AdminAuditLog{TargetUserID: &user.ID, Action: model.ADMIN_ACTION_USER_DISABLE, IP: "10.0.0.1"}); err != nil {
+		return fmt.Errorf("CreateAuditLog: %w", err)
+	}
+	if err := s.repos.Admin.CreateAuditLog(ctx, &model.AdminAuditLog{ActorUserID: &user.ID, TargetUserID: &user.ID, Action: model.ADMIN_ACTION_ROLE_CHANGE}); err != nil {
+		return fmt.Errorf("CreateAuditLog: %w", err)
+	}
+	logs, totalCount, err := s.repos.Admin.GetAuditLogs(ctx, user.ID, 1, 1)
+	if err != nil || totalCount != 2 || len(logs) != 1 || logs[0].Action != model.ADMIN_ACTION_ROLE_CHANGE || logs[0].ActorUserID == nil {
+		return fmt.Errorf("GetAuditLogs: expected newest of 2 logs, got %+v, %d (%v)", logs, totalCount, err)
+	}
+
+	if err := s.repos.Admin.SetUserDisabled(ctx, user.ID, nil); err != nil {
+		return fmt.Errorf("SetUserDisabled(nil): %w", err)
+	}
+	found, err = s.repos.Auth.GetUserByID(ctx, user.ID)
+	if err != nil || found == nil || found.IsDisabled() {
+		return fmt.Errorf("GetUserByID: expected enabled user, got %+v (%v)", found, err)
+	}
+	return nil
+}
+
+func (s *suite) testAccount(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.repos.Account.GetLatestExport(ctx, user.ID)
+	if err != nil || latest != nil {
+		return fmt.Errorf("GetLatestExport(missing): expected nil, got %+v (%v)", latest, err)
+	}
+	export, err := s.repos.Account.CreateExport(ctx, user.ID)
+	if err != nil || export.ID == 0 || export.Status != model.DATA_EXPORT_STATUS_PENDING || !export.IsActive() {
+		return fmt.Errorf("CreateExport: unexpected export %+v (%v)", export, err)
+	}
+	started, err := s.repos.Account.StartExport(ctx, export.ID)
+	if err != nil || !started {
+		return fmt.Errorf("StartExport: expected started, got %v (%v)", started, err)
+	}
+	started, err = s.repos.Account.StartExport(ctx, export.ID)
+	if err != nil || started {
+		return fmt.Errorf("StartExport(running): expected not started, got %v (%v)", started, err)
+	}
+	_, err = s.repos.Account.GetExportContent(ctx, user.ID, export.ID)
+	if err := expectErr("GetExportContent(running)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.repos.Account.CompleteExport(ctx, export.ID, []byte("zip"), expiresAt); err != nil {
+		return fmt.Errorf("CompleteExport: %w", err)
+	}
+	found, err := s.repos.Account.GetExport(ctx, user.ID, export.ID)
+	if err != nil || found.Status != model.DATA_EXPORT_STATUS_COMPLETED || found.SizeBytes != 3 || found.ExpiresAt == nil || !found.ExpiresAt.Equal(expiresAt) {
+		return fmt.Errorf("GetExport: expected completed export, got %+v (%v)", found, err)
+	}
+	content, err := s.repos.Account.GetExportContent(ctx, user.ID, export.ID)
+	if err != nil || string(content) != "zip" {
+		return fmt.Errorf("GetExportContent: expected zip content, got %q (%v)", content, err)
+	}
+	other, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.repos.Account.GetExport(ctx, other.ID, export.ID)
+	if err := expectErr("GetExport(other user)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	failed, err := s.repos.Account.CreateExport(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("CreateExport: %w", err)
+	}
+	if err := s.repos.Account.FailExport(ctx, failed.ID, "boom"); err != nil {
+		return fmt.Errorf("FailExport: %w", err)
+	}
+	latest, err = s.repos.Account.GetLatestExport(ctx, user.ID)
+	if err != nil || latest == nil || latest.ID != failed.ID || latest.Status != model.DATA_EXPORT_STATUS_FAILED || latest.Error != "boom" {
+		return fmt.Errorf("GetLatestExport: expected failed export, got %+v (%v)", latest, err)
+	}
+	if _, err := s.repos.Account.DeleteExpiredExports(ctx, expiresAt); err != nil {
+		return fmt.Errorf("DeleteExpiredExports: %w", err)
+	}
+	_, err = s.repos.Account.GetExport(ctx, user.ID, export.ID)
+	if err := expectErr("GetExport(expired)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	task, err := s.newTask(ctx, user.ID, workspaceID, "exported")
+	if err != nil {
+		return err
+	}
+	tag, err := s.repos.Tag.CreateTags(ctx, user.ID, workspaceID, &model.Tag{Name: "export", Color: "#123456"})
+	if err != nil {
+		return err
+	}
+	if err := s.repos.TaskTag.AddTagToTask(ctx, user.ID, workspaceID, task.ID, tag.ID); err != nil {
+		return err
+	}
+	tasks, err := s.repos.Account.GetExportTasks(ctx, user.ID)
+	if err != nil || len(tasks) != 1 || len(tasks[0].Tags) != 1 || tasks[0].Tags[0].ID != tag.ID {
+		return fmt.Errorf("GetExportTasks: expected 1 task with tag, got %+v (%v)", tasks, err)
+	}
+
+	// 공유 작업 공간에서는 사용자가 만든 작업 / 태그만 내보내고 다른 구성원의 것은 제외함
+	teammate, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	shared, err := s.repos.Workspace.CreateWorkspace(ctx, teammate.ID, "export shared")
+	if err != nil {
+		return err
+	}
+	if err := s.repos.Workspace.AddMember(ctx, shared.ID, user.ID, model.WORKSPACE_ROLE_MEMBER); err != nil {
+		return err
+	}
+	sharedTask, err := s.newTask(ctx, user.ID, shared.ID, "exported shared")
+	if err != nil {
+		return err
+	}
+	teammateTask, err := s.newTask(ctx, teammate.ID, shared.ID, "teammate")
+	if err != nil {
+		return err
+	}
+	teammateTag, err := s.repos.Tag.CreateTags(ctx, teammate.ID, shared.ID, &model.Tag{Name: "teammate", Color: "#654321"})
+	if err != nil {
+		return err
+	}
+	if err := s.repos.TaskTag.AddTagToTask(ctx, teammate.ID, shared.ID, teammateTask.ID, teammateTag.ID); err != nil {
+		return err
+	}
+	if err := s.repos.TaskTag.AddTagToTask(ctx, teammate.ID, shared.ID, sharedTask.ID, teammateTag.ID); err != nil {
+		return err
+	}
+	tasks, err = s.repos.Account.GetExportTasks(ctx, user.ID)
+	if err != nil || len(tasks) != 2 || tasks[0].ID != task.ID || tasks[1].ID != sharedTask.ID || len(tasks[1].Tags) != 0 {
+		return fmt.Errorf("GetExportTasks(shared): expected own tasks without the teammate's tag, got %+v (%v)", tasks, err)
+	}
+	tags, err := s.repos.Account.GetExportTags(ctx, user.ID)
+	if err != nil || len(tags) != 1 || tags[0].ID != tag.ID {
+		return fmt.Errorf("GetExportTags(shared): expected only own tag %d, got %+v (%v)", tag.ID, tags, err)
+	}
+	templates, err := s.repos.Account.GetTaskTemplates(ctx, user.ID)
+	if err != nil || len(templates) != 0 {
+		return fmt.Errorf("GetTaskTemplates: expected no templates, got %+v (%v)", templates, err)
+	}
+
+	// 삭제 예약을 취소하면 삭제 되지 않고, 예정 시각이 지나면 모든 데이터와 함께 삭제 됨
+	now := time.Now().UTC()
+	deletionAt := now.Add(-time.Minute)
+	if err := s.repos.Account.ScheduleDeletion(ctx, user.ID, &deletionAt); err != nil {
+		return fmt.Errorf("ScheduleDeletion: %w", err)
+	}
+	scheduled, err := s.repos.Auth.GetUserByID(ctx, user.ID)
+	if err != nil || scheduled == nil || scheduled.DeletionScheduledAt == nil {
+		return fmt.Errorf("GetUserByID: expected scheduled deletion, got %+v (%v)", scheduled, err)
+	}
+	dueUserIDs, err := s.repos.Account.GetUsersDueForDeletion(ctx, now, 1000)
+	if err != nil || !slices.Contains(dueUserIDs, user.ID) {
+		return fmt.Errorf("GetUsersDueForDeletion: expected user %d, got %v (%v)", user.ID, dueUserIDs, err)
+	}
+	if err := s.repos.Account.ScheduleDeletion(ctx, user.ID, nil); err != nil {
+		return fmt.Errorf("ScheduleDeletion(nil): %w", err)
+	}
+	deleted, err := s.repos.Account.DeleteUserDueForDeletion(ctx, user.ID, now)
+	if err != nil || deleted {
+		return fmt.Errorf("DeleteUserDueForDeletion(cancelled): expected not deleted, got %v (%v)", deleted, err)
+	}
+	if err := s.repos.Account.ScheduleDeletion(ctx, user.ID, &deletionAt); err != nil {
+		return fmt.Errorf("ScheduleDeletion: %w", err)
+	}
+	deleted, err = s.repos.Account.DeleteUserDueForDeletion(ctx, user.ID, now)
+	if err != nil || !deleted {
+		return fmt.Errorf("DeleteUserDueForDeletion: expected deleted, got %v (%v)", deleted, err)
+	}
+	missing, err := s.repos.Auth.GetUserByID(ctx, user.ID)
+	if err != nil || missing != nil {
+		return fmt.Errorf("GetUserByID(deleted): expected nil, got %+v (%v)", missing, err)
+	}
+	_, err = s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, task.ID)
+	return expectErr("GetTasksByTaskID(deleted user)", err, sql.ErrNoRows)
+}
+
+func (s *suite) testPreferences(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.repos.Preferences.GetPreferences(ctx, user.ID)
+	if err != nil || stored != nil {
+		return fmt.Errorf("GetPreferences(missing): expected nil, got %+v (%v)", stored, err)
+	}
+
+	// 저장되지 않은 필드는 기본값, 유효하지 않은 값은 기본값으로 되돌려져야 함
+	document := []byte(`{"theme":"dark","timezone":"Not/AZone","unknown":true}`)
+	saved, err := s.repos.Preferences.SavePreferences(ctx, user.ID, document, 0)
+	if err != nil || saved.Version != 1 {
+		return fmt.Errorf("SavePreferences(insert): unexpected result %+v (%v)", saved, err)
+	}
+	_, err = s.repos.Preferences.SavePreferences(ctx, user.ID, document, 0)
+	if err := expectErr("SavePreferences(duplicate insert)", err, repository.ErrVersionConflict); err != nil {
+		return err
+	}
+
+	stored, err = s.repos.Preferences.GetPreferences(ctx, user.ID)
+	if err != nil || stored == nil || stored.Version != 1 {
+		return fmt.Errorf("GetPreferences: unexpected result %+v (%v)", stored, err)
+	}
+	result, err := model.NewUserPreferencesResult(stored)
+	if err != nil {
+		return fmt.Errorf("NewUserPreferencesResult: %w", err)
+	}
+	defaults := model.DefaultUserPreferences()
+	if result.Preferences.Theme != model.THEME_DARK || result.Preferences.Timezone != defaults.Timezone || result.Preferences.Notifications != defaults.Notifications {
+		return fmt.Errorf("NewUserPreferencesResult: defaults not applied, got %+v", result.Preferences)
+	}
+
+	saved, err = s.repos.Preferences.SavePreferences(ctx, user.ID, []byte(`{"theme":"light"}`), 1)
+	if err != nil || saved.Version != 2 {
+		return fmt.Errorf("SavePreferences(update): unexpected result %+v (%v)", saved, err)
+	}
+	_, err = s.repos.Preferences.SavePreferences(ctx, user.ID, []byte(`{"theme":"dark"}`), 1)
+	return expectErr("SavePreferences(stale version)", err, repository.ErrVersionConflict)
+}
+
+func (s *suite) testWithTx(ctx context.Context) error {
+	user, err := s.newUser(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := s.personalWorkspace(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var taskID int
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		task, err := tx.Task.CreateTasks(ctx, user.ID, workspaceID, &model.Task{
+			Title:    "rolled back",
+			DueDate:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			Priority: model.PRIORITY_LOW,
+		})
+		if err != nil {
+			return err
+		}
+		taskID = task.ID
+		return errRollback
+	})
+	if err := expectErr("WithTx", err, errRollback); err != nil {
+		return err
+	}
+	if taskID == 0 {
+		return errors.New("WithTx: task was not created inside the transaction")
+	}
+	_, err = s.repos.Task.GetTasksByTaskID(ctx, user.ID, workspaceID, taskID)
+	if err := expectErr("WithTx(rolled back task)", err, sql.ErrNoRows); err != nil {
+		return err
+	}
+
+	// 롤백 된 트랜잭션에서 올린 변경 시퀀스도 함께 되돌려져야 함
+	seq, err := s.repos.Sync.GetChangeSeq(ctx, user.ID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if seq != 0 {
+		return fmt.Errorf("WithTx: change_seq must be rolled back, got %d", seq)
+	}
+	return nil
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+)
+
+// 지원하는 SQL 방언
+const (
+	DIALECT_POSTGRES = "postgres"
+	DIALECT_SQLITE   = "sqlite"
+)
+
+var (
+	// SQLite는 행 잠금이 없고 쓰기 트랜잭션이 직렬화되므로 FOR UPDATE를 제거
+	forUpdateRegexp = regexp.MustCompile(`(?i)\s+FOR\s+UPDATE\b`)
+)
+
+// dialectDB는 *sql.DB를 감싸 방언에 맞게 쿼리를 변환하는 구조체
+type dialectDB struct {
+	db     *sql.DB
+	rebind func(query string) string
+	txOpts func(opts *sql.TxOptions) *sql.TxOptions
+}
+
+// dialectTx는 *sql.Tx를 감싸 방언에 맞게 쿼리를 변환하는 구조체
+type dialectTx struct {
+	tx     *sql.Tx
+	rebind func(query string) string
+}
+
+// NewDB는 방언에 맞는 DB를 생성하는 함수, 알 수 없는 방언은 postgres로 취급
+// 저장소의 쿼리는 postgres 문법으로 작성하고, 다른 방언은 여기서 변환함
+func NewDB(db *sql.DB, dialect string) DB {
+	switch dialect {
+	case DIALECT_SQLITE:
+		return &dialectDB{
+			db:     db,
+			rebind: rebindSQLite,
+			txOpts: txOptionsSQLite,
+		}
+	}
+	return &dialectDB{
+		db:     db,
+		rebind: func(query string) string { return query },
+		txOpts: func(opts *sql.TxOptions) *sql.TxOptions { return opts },
+	}
+}
+
+// rebindSQLite는 postgres 쿼리를 SQLite에서 실행할 수 있도록 변환하는 함수
+// $1 형식의 placeholder는 SQLite에서도 순서 기준으로 동작하므로 그대로 둠
+func rebindSQLite(query string) string {
+	return forUpdateRegexp.ReplaceAllString(query, "")
+}
+
+// txOptionsSQLite는 SQLite가 지원하지 않는 격리 수준을 기본값으로 바꾸는 함수
+// SQLite 트랜잭션은 항상 serializable이므로 스냅샷 조회 요구 사항도 만족함
+func txOptionsSQLite(opts *sql.TxOptions) *sql.TxOptions {
+	if opts == nil {
+		return nil
+	}
+	return &sql.TxOptions{ReadOnly: opts.ReadOnly}
+}
+
+func (d *dialectDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return d.db.ExecContext(ctx, d.rebind(query), args...)
+}
+
+func (d *dialectDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return d.db.QueryContext(ctx, d.rebind(query), args...)
+}
+
+func (d *dialectDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return d.db.QueryRowContext(ctx, d.rebind(query), args...)
+}
+
+// BeginTx는 방언에 맞는 옵션으로 트랜잭션을 시작하는 메서드
+func (d *dialectDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := d.db.BeginTx(ctx, d.txOpts(opts))
+	if err != nil {
+		return nil, err
+	}
+	return &dialectTx{tx: tx, rebind: d.rebind}, nil
+}
+
+func (t *dialectTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, t.rebind(query), args...)
+}
+
+func (t *dialectTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, t.rebind(query), args...)
+}
+
+func (t *dialectTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(ctx, t.rebind(query), args...)
+}
+
+func (t *dialectTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *dialectTx) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Executor는 *sql.DB와 *sql.Tx가 공통으로 구현하는 쿼리 실행 인터페이스
+// 저장소는 Executor를 받아 트랜잭션 안팎에서 같은 코드로 동작함
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// DB는 트랜잭션을 시작할 수 있는 Executor
+// 저장소 드라이버(postgres, sqlite 등)마다 쿼리 방언 차이를 감추는 역할을 함
+type DB interface {
+	Executor
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+}
+
+// Tx는 커밋 또는 롤백할 수 있는 Executor
+type Tx interface {
+	Executor
+	Commit() error
+	Rollback() error
+}
+
+// Repositories는 하나의 Executor(트랜잭션)에 묶인 저장소 묶음
+type Repositories struct {
+	Auth        AuthRepository
+	Task        TaskRepository
+	Tag         TagRepository
+	TaskTag     TaskTagRepository
+	Sync        SyncRepository
+	Token       TokenRepository
+	Identity    IdentityRepository
+	TwoFactor   TwoFactorRepository
+	Admin       AdminRepository
+	Account     AccountRepository
+	Preferences PreferencesRepository
+	Workspace   WorkspaceRepository
+	Invitation  WorkspaceInvitationRepository
+}
+
+// TxManager는 여러 저장소 작업을 하나의 트랜잭션으로 묶기 위한 인터페이스
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(tx *Repositories) error) error
+}
+
+// txManager는 TxManager 인터페이스를 구현하는 구조체
+type txManager struct {
+	db DB
+}
+
+// NewTxManager는 TxManager의 인스턴스를 생성하는 함수
+func NewTxManager(db DB) TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// NewRepositories는 Executor에 묶인 저장소 묶음을 생성하는 함수
+func NewRepositories(exec Executor) *Repositories {
+	return &Repositories{
+		Auth:        NewAuthRepository(exec),
+		Task:        NewTaskRepository(exec),
+		Tag:         NewTagRepository(exec),
+		TaskTag:     NewTaskTagRepository(exec),
+		Sync:        NewSyncRepository(exec),
+		Token:       NewTokenRepository(exec),
+		Identity:    NewIdentityRepository(exec),
+		TwoFactor:   NewTwoFactorRepository(exec),
+		Admin:       NewAdminRepository(exec),
+		Account:     NewAccountRepository(exec),
+		Preferences: NewPreferencesRepository(exec),
+		Workspace:   NewWorkspaceRepository(exec),
+		Invitation:  NewWorkspaceInvitationRepository(exec),
+	}
+}
+
+// WithTx는 fn을 하나의 트랜잭션 안에서 실행하는 메서드
+// fn이 에러를 반환하거나 panic이 발생하면 롤백하고, 그렇지 않으면 커밋함
+// ctx가 취소되면 database/sql이 트랜잭션을 롤백하고 진행 중인 쿼리를 중단함
+func (m *txManager) WithTx(ctx context.Context, fn func(tx *Repositories) error) error {
+	return runInTx(ctx, m.db, func(exec Executor) error {
+		return fn(NewRepositories(exec))
+	})
+}
+
+// runInTx는 exec가 이미 트랜잭션이면 그대로 fn을 실행하고, 아니면 새 트랜잭션을 시작하는 함수
+// 저장소 메서드는 이 함수를 통해 바깥 트랜잭션에 자연스럽게 참여함
+func runInTx(ctx context.Context, exec Executor, fn func(exec Executor) error) error {
+	return runInTxWithOptions(ctx, exec, nil, fn)
+}
+
+// runInTxWithOptions는 트랜잭션 옵션을 지정할 수 있는 runInTx
+// 바깥 트랜잭션에 참여하는 경우 옵션은 무시됨
+func runInTxWithOptions(ctx context.Context, exec Executor, opts *sql.TxOptions, fn func(exec Executor) error) (err error) {
+	db, ok := exec.(DB)
+	if !ok {
+		return fn(exec)
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				err = fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	return fn(tx)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	USER_IDENTITY_COLUMNS            = "id, user_id, issuer, subject, COALESCE(email, ''), created_at, last_login_at"
+	GET_IDENTITY_QUERY               = "SELECT " + USER_IDENTITY_COLUMNS + " FROM user_identities WHERE issuer = $1 AND subject = $2"
+	GET_IDENTITIES_BY_USER_ID_QUERY  = "SELECT " + USER_IDENTITY_COLUMNS + " FROM user_identities WHERE user_id = $1 ORDER BY id"
+	INSERT_USER_IDENTITY_QUERY       = "INSERT INTO user_identities (user_id, issuer, subject, email, last_login_at) VALUES ($1, $2, $3, NULLIF($4, ''), $5) RETURNING " + USER_IDENTITY_COLUMNS
+	UPDATE_IDENTITY_LAST_LOGIN_QUERY = "UPDATE user_identities SET email = NULLIF($2, ''), last_login_at = $3 WHERE id = $1"
+	DELETE_USER_IDENTITY_QUERY       = "DELETE FROM user_identities WHERE user_id = $1 AND id = $2"
+)
+
+// IdentityRepository는 외부 ID 공급자 계정 연결 관련 데이터베이스 작업을 정의하는 인터페이스
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, issuer string, subject string) (*model.UserIdentity, error)
+	GetIdentitiesByUserID(ctx context.Context, userID int) ([]model.UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity *model.UserIdentity) (*model.UserIdentity, error)
+	UpdateLastLogin(ctx context.Context, identityID int, email string, loginAt time.Time) error
+	DeleteIdentity(ctx context.Context, userID int, identityID int) error
+}
+
+// identityRepository는 IdentityRepository 인터페이스를 구현하는 구조체
+type identityRepository struct {
+	db Executor
+}
+
+// NewIdentityRepository는 IdentityRepository의 인스턴스를 생성하는 함수
+func NewIdentityRepository(db Executor) IdentityRepository {
+	return &identityRepository{
+		db: db,
+	}
+}
+
+// GetIdentity는 공급자(issuer)와 계정(subject)으로 연결을 조회하는 메서드, 없으면 nil을 반환
+func (r *identityRepository) GetIdentity(ctx context.Context, issuer string, subject string) (*model.UserIdentity, error) {
+	identity, err := scanUserIdentity(r.db.QueryRowContext(ctx, GET_IDENTITY_QUERY, issuer, subject))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return identity, err
+}
+
+// GetIdentitiesByUserID는 사용자에게 연결 된 모든 외부 계정을 조회하는 메서드
+func (r *identityRepository) GetIdentitiesByUserID(ctx context.Context, userID int) ([]model.UserIdentity, error) {
+	rows, err := r.db.QueryContext(ctx, GET_IDENTITIES_BY_USER_ID_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []model.UserIdentity{}
+	for rows.Next() {
+		identity, err := scanUserIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, *identity)
+	}
+	return identities, rows.Err()
+}
+
+// CreateIdentity는 사용자에게 외부 계정을 연결하는 메서드
+func (r *identityRepository) CreateIdentity(ctx context.Context, identity *model.UserIdentity) (*model.UserIdentity, error) {
+	var lastLoginAt interface{}
+	if identity.LastLoginAt != nil {
+		lastLoginAt = identity.LastLoginAt.UTC()
+	}
+
+	row := r.db.QueryRowContext(ctx, INSERT_USER_IDENTITY_QUERY,
+		identity.UserID,
+		identity.Issuer,
+		identity.Subject,
+		identity.Email,
+		lastLoginAt,
+	)
+	return scanUserIdentity(row)
+}
+
+// UpdateLastLogin은 외부 계정의 마지막 로그인 시각과 이메일을 기록하는 메서드
+func (r *identityRepository) UpdateLastLogin(ctx context.Context, identityID int, email string, loginAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, UPDATE_IDENTITY_LAST_LOGIN_QUERY, identityID, email, loginAt.UTC())
+	return err
+}
+
+// DeleteIdentity는 사용자에게 연결 된 외부 계정을 해제하는 메서드, 없으면 sql.ErrNoRows를 반환
+func (r *identityRepository) DeleteIdentity(ctx context.Context, userID int, identityID int) error {
+	result, err := r.db.ExecContext(ctx, DELETE_USER_IDENTITY_QUERY, userID, identityID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// scanUserIdentity는 USER_IDENTITY_COLUMNS 순서의 행을 읽는 함수
+func scanUserIdentity(row rowScanner) (*model.UserIdentity, error) {
+	var (
+		identity    model.UserIdentity
+		lastLoginAt sql.NullTime
+	)
+	if err := row.Scan(&identity.ID, &identity.UserID, &identity.Issuer, &identity.Subject, &identity.Email, &identity.CreatedAt, &lastLoginAt); err != nil {
+		return nil, err
+	}
+
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+	return &identity, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	GET_PREFERENCES_QUERY    = "SELECT preferences, version, updated_at FROM user_preferences WHERE user_id = $1"
+	INSERT_PREFERENCES_QUERY = "INSERT INTO user_preferences (user_id, preferences, version, updated_at) VALUES ($1, $2, 1, $3) ON CONFLICT (user_id) DO NOTHING RETURNING version"
+	UPDATE_PREFERENCES_QUERY = "UPDATE user_preferences SET preferences = $2, version = version + 1, updated_at = $3 WHERE user_id = $1 AND version = $4 RETURNING version"
+)
+
+// PreferencesRepository는 사용자 설정 관련 데이터베이스 작업을 정의하는 인터페이스
+type PreferencesRepository interface {
+	GetPreferences(ctx context.Context, userID int) (*model.StoredPreferences, error)
+	SavePreferences(ctx context.Context, userID int, document []byte, version int) (*model.StoredPreferences, error)
+}
+
+// preferencesRepository는 PreferencesRepository 인터페이스를 구현하는 구조체
+type preferencesRepository struct {
+	db Executor
+}
+
+// NewPreferencesRepository는 PreferencesRepository의 인스턴스를 생성하는 함수
+func NewPreferencesRepository(db Executor) PreferencesRepository {
+	return &preferencesRepository{
+		db: db,
+	}
+}
+
+// GetPreferences는 사용자의 설정 문서를 조회하는 메서드, 저장한 적이 없으면 nil을 반환
+func (r *preferencesRepository) GetPreferences(ctx context.Context, userID int) (*model.StoredPreferences, error) {
+	var stored model.StoredPreferences
+	err := r.db.QueryRowContext(ctx, GET_PREFERENCES_QUERY, userID).Scan(&stored.Document, &stored.Version, &stored.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// SavePreferences는 사용자의 설정 문서를 저장하는 메서드
+// version은 조회한 문서의 버전이며 (저장한 적이 없으면 0), 그 사이 다른 요청이 먼저 저장했으면 ErrVersionConflict를 반환
+func (r *preferencesRepository) SavePreferences(ctx context.Context, userID int, document []byte, version int) (*model.StoredPreferences, error) {
+	stored := model.StoredPreferences{Document: document, UpdatedAt: time.Now().UTC()}
+
+	var err error
+	if version == 0 {
+		err = r.db.QueryRowContext(ctx, INSERT_PREFERENCES_QUERY, userID, string(document), stored.UpdatedAt).Scan(&stored.Version)
+	} else {
+		err = r.db.QueryRowContext(ctx, UPDATE_PREFERENCES_QUERY, userID, string(document), stored.UpdatedAt, version).Scan(&stored.Version)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
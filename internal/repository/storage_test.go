@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lux-list/internal/config"
+	"lux-list/internal/database"
+	"lux-list/internal/repository"
+)
+
+// TEST_POSTGRES_DSN이 설정되면 postgres에서도 검사함
+// 검사용 사용자와 데이터가 남으므로 테스트 전용 데이터베이스를 지정해야 함
+const TEST_POSTGRES_DSN_ENV = "TEST_POSTGRES_DSN"
+
+// TestStorageDrivers는 모든 저장소 드라이버가 같은 동작을 하는지 검사하는 테스트
+func TestStorageDrivers(t *testing.T) {
+	drivers := []struct {
+		name    string
+		dialect string
+		open    func(t *testing.T) (*sql.DB, error)
+	}{
+		{database.DRIVER_MEMORY, database.DIALECT_SQLITE, func(t *testing.T) (*sql.DB, error) {
+			return database.Open(config.PostgresConfig{DRIVER: database.DRIVER_MEMORY})
+		}},
+		{database.DRIVER_SQLITE, database.DIALECT_SQLITE, func(t *testing.T) (*sql.DB, error) {
+			return database.Open(config.PostgresConfig{DRIVER: database.DRIVER_SQLITE, SQLITE_PATH: filepath.Join(t.TempDir(), "lux-list.db")})
+		}},
+		{database.DRIVER_POSTGRES, database.DIALECT_POSTGRES, func(t *testing.T) (*sql.DB, error) {
+			dsn := os.Getenv(TEST_POSTGRES_DSN_ENV)
+			if dsn == "" {
+				t.Skip(TEST_POSTGRES_DSN_ENV + " is not set")
+			}
+			return sql.Open("postgres", dsn)
+		}},
+	}
+
+	for _, driver := range drivers {
+		t.Run(driver.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, err := driver.open(t)
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			migrator, err := database.NewMigrator(db, driver.dialect)
+			if err != nil {
+				t.Fatalf("NewMigrator: %v", err)
+			}
+			if _, err := migrator.Up(ctx); err != nil {
+				t.Fatalf("migrate up: %v", err)
+			}
+
+			s := newSuite(repository.NewDB(db, driver.dialect))
+			for _, check := range s.checks() {
+				t.Run(check.name, func(t *testing.T) {
+					if err := check.fn(ctx); err != nil {
+						t.Fatal(err)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,557 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"lux-list/internal/model"
+	"lux-list/pkg/utils"
+)
+
+const (
+	// 변경 시퀀스는 작업 공간 단위로 발급하며, 발급과 잠금은 viewer가 아닌 구성원만, 조회는 구성원만 가능
+	NEXT_CHANGE_SEQ_QUERY   = "UPDATE workspaces SET change_seq = change_seq + 1 WHERE id = $1 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = $2 AND wm.role <> 'viewer') RETURNING change_seq"
+	GET_CHANGE_SEQ_QUERY    = "SELECT change_seq FROM workspaces WHERE id = $1 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = $2)"
+	LOCK_CHANGE_SEQ_QUERY   = "SELECT change_seq FROM workspaces WHERE id = $1 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = $2 AND wm.role <> 'viewer') FOR UPDATE"
+	INSERT_TOMBSTONE_QUERY  = "INSERT INTO sync_tombstones (user_id, workspace_id, entity, entity_id, change_seq) VALUES ($1, $2, $3, $4, $5)"
+	GET_TOMBSTONES_QUERY    = "SELECT entity, entity_id, change_seq, deleted_at FROM sync_tombstones WHERE workspace_id = $1 AND change_seq > $2 ORDER BY change_seq"
+	GET_CHANGED_TASKS_QUERY = "SELECT " + TASK_COLUMNS + " FROM tasks WHERE workspace_id = $1 AND change_seq > $2 ORDER BY change_seq"
+	GET_CHANGED_TAGS_QUERY  = "SELECT " + TAG_COLUMNS + " FROM tags WHERE workspace_id = $1 AND change_seq > $2 ORDER BY change_seq"
+	GET_CHANGED_LINKS_QUERY = "SELECT tt.task_id, tt.tag_id, tt.change_seq FROM task_tags tt JOIN tasks t ON t.id = tt.task_id WHERE t.workspace_id = $1 AND tt.change_seq > $2 ORDER BY tt.change_seq"
+	LOCK_TASK_QUERY         = "SELECT " + TASK_COLUMNS + ", field_updated_at FROM tasks WHERE id = $1 AND workspace_id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = $3 AND wm.role <> 'viewer') FOR UPDATE"
+	LOCK_TAG_QUERY          = "SELECT " + TAG_COLUMNS + ", field_updated_at FROM tags WHERE id = $1 AND workspace_id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $3 AND wm.role <> 'viewer') FOR UPDATE"
+
+	INSERT_TASK_TAG_TOMBSTONES_BY_TASK_ID_QUERY = "INSERT INTO sync_tombstones (user_id, workspace_id, entity, entity_id, change_seq) SELECT $1, $2, 'task_tag', task_id || ':' || tag_id, $3 FROM task_tags WHERE task_id = $4"
+	INSERT_TASK_TAG_TOMBSTONES_BY_TAG_ID_QUERY  = "INSERT INTO sync_tombstones (user_id, workspace_id, entity, entity_id, change_seq) SELECT $1, $2, 'task_tag', task_id || ':' || tag_id, $3 FROM task_tags WHERE tag_id = $4"
+)
+
+var (
+	ErrSyncEntityDeleted = errors.New("entity was deleted on the server")
+)
+
+// SyncRepository는 오프라인 클라이언트 동기화 관련 데이터베이스 작업을 정의하는 인터페이스
+// 동기화는 작업 공간 단위이며, 요청 사용자가 구성원이 아니거나 수정할 수 없는 역할이면 ErrWorkspaceAccessDenied를 반환
+type SyncRepository interface {
+	GetChangeSeq(ctx context.Context, userID int, workspaceID int) (int64, error)
+	GetChanges(ctx context.Context, userID int, workspaceID int, since int64) (*model.SyncChanges, error)
+	ApplyTaskMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error)
+	ApplyTagMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error)
+	ApplyTaskTagMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error)
+}
+
+// syncRepository는 SyncRepository 인터페이스를 구현하는 구조체
+type syncRepository struct {
+	db Executor
+}
+
+// NewSyncRepository는 SyncRepository의 인스턴스를 생성하는 함수
+func NewSyncRepository(db Executor) SyncRepository {
+	return &syncRepository{
+		db: db,
+	}
+}
+
+// GetChangeSeq는 작업 공간의 현재 변경 시퀀스를 조회하는 메서드
+func (r *syncRepository) GetChangeSeq(ctx context.Context, userID int, workspaceID int) (int64, error) {
+	return getChangeSeq(ctx, r.db, userID, workspaceID)
+}
+
+// GetChanges는 since 시퀀스 이후의 모든 변경 내역과 tombstone을 조회하는 메서드
+func (r *syncRepository) GetChanges(ctx context.Context, userID int, workspaceID int, since int64) (changes *model.SyncChanges, err error) {
+	// 하나의 스냅샷에서 조회해야 next_token과 변경 내역이 일치함
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err = runInTxWithOptions(ctx, r.db, opts, func(tx Executor) error {
+		changes, err = getChangesTx(ctx, tx, userID, workspaceID, since)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// getChangesTx는 트랜잭션 안에서 since 시퀀스 이후의 변경 내역을 조회하는 함수
+func getChangesTx(ctx context.Context, tx Executor, userID int, workspaceID int, since int64) (*model.SyncChanges, error) {
+	current, err := getChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &model.SyncChanges{
+		Tasks:      []model.Task{},
+		Tags:       []model.Tag{},
+		TaskTags:   []model.TaskTagLink{},
+		Tombstones: []model.Tombstone{},
+		NextToken:  model.FormatSyncToken(workspaceID, current),
+	}
+
+	taskRows, err := tx.QueryContext(ctx, GET_CHANGED_TASKS_QUERY, workspaceID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer taskRows.Close()
+	for taskRows.Next() {
+		var task model.Task
+		if err := scanTaskFields(taskRows, &task); err != nil {
+			return nil, err
+		}
+		changes.Tasks = append(changes.Tasks, task)
+	}
+	if err := taskRows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := tx.QueryContext(ctx, GET_CHANGED_TAGS_QUERY, workspaceID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var tag model.Tag
+		if err := scanTagFields(tagRows, &tag); err != nil {
+			return nil, err
+		}
+		changes.Tags = append(changes.Tags, tag)
+	}
+	if err := tagRows.Err(); err != nil {
+		return nil, err
+	}
+
+	linkRows, err := tx.QueryContext(ctx, GET_CHANGED_LINKS_QUERY, workspaceID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer linkRows.Close()
+	for linkRows.Next() {
+		var link model.TaskTagLink
+		if err := linkRows.Scan(&link.TaskID, &link.TagID, &link.ChangeSeq); err != nil {
+			return nil, err
+		}
+		changes.TaskTags = append(changes.TaskTags, link)
+	}
+	if err := linkRows.Err(); err != nil {
+		return nil, err
+	}
+
+	tombstoneRows, err := tx.QueryContext(ctx, GET_TOMBSTONES_QUERY, workspaceID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer tombstoneRows.Close()
+	for tombstoneRows.Next() {
+		var tombstone model.Tombstone
+		if err := tombstoneRows.Scan(&tombstone.Entity, &tombstone.EntityID, &tombstone.ChangeSeq, &tombstone.DeletedAt); err != nil {
+			return nil, err
+		}
+		changes.Tombstones = append(changes.Tombstones, tombstone)
+	}
+	if err := tombstoneRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ApplyTaskMutation은 클라이언트의 작업 변경 사항을 필드 단위 last-writer-wins로 적용하는 메서드
+func (r *syncRepository) ApplyTaskMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (result *model.SyncResult, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		result, err = applyTaskMutationTx(ctx, tx, userID, workspaceID, mutation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyTaskMutationTx는 트랜잭션 안에서 ApplyTaskMutation을 처리하는 함수
+func applyTaskMutationTx(ctx context.Context, tx Executor, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error) {
+	result := &model.SyncResult{Entity: model.SYNC_ENTITY_TASK, ID: mutation.ID, ClientID: mutation.ClientID, Status: model.SYNC_STATUS_APPLIED}
+
+	if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	// 새 작업 생성
+	if mutation.Op == model.SYNC_OP_UPSERT && mutation.ID == 0 {
+		task := &model.Task{Priority: model.PRIORITY_MEDIUM}
+		if err := model.ApplyTaskFields(task, mutation.Fields); err != nil {
+			return nil, err
+		}
+		if task.Title == "" || task.DueDate.IsZero() {
+			return nil, errors.New("title and due_date are required to create a task")
+		}
+
+		stamps, err := stampFields(model.SyncTaskFields, mutation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertTaskTx(ctx, tx, userID, workspaceID, task, stamps); err != nil {
+			return nil, err
+		}
+		result.ID = task.ID
+		result.ChangeSeq = task.ChangeSeq
+		return result, nil
+	}
+
+	task, serverStamps, err := lockTaskTx(ctx, tx, userID, workspaceID, mutation.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// 서버에서 이미 삭제 된 경우 삭제 요청은 성공, 수정 요청은 충돌로 처리
+			if mutation.Op == model.SYNC_OP_DELETE {
+				return result, nil
+			}
+			result.Status = model.SYNC_STATUS_CONFLICT
+			result.Error = ErrSyncEntityDeleted.Error()
+			return result, nil
+		}
+		return nil, err
+	}
+
+	if mutation.Op == model.SYNC_OP_DELETE {
+		// 삭제 요청 이후에 서버에서 수정 된 필드가 있으면 삭제하지 않음
+		if conflicted := newerFields(serverStamps, mutation.UpdatedAt); len(conflicted) > 0 {
+			result.Status = model.SYNC_STATUS_CONFLICT
+			for _, field := range conflicted {
+				result.Conflicts = append(result.Conflicts, model.SyncConflict{Field: field, ServerValue: model.TaskFieldValue(task, field)})
+			}
+			return result, nil
+		}
+		if err := deleteTaskTx(ctx, tx, userID, workspaceID, mutation.ID, 0); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	applied, conflicted := resolveFields(mutation.Fields, serverStamps, mutation.UpdatedAt)
+	for _, field := range conflicted {
+		result.Conflicts = append(result.Conflicts, model.SyncConflict{Field: field, ServerValue: model.TaskFieldValue(task, field)})
+	}
+	if len(conflicted) > 0 {
+		result.Status = model.SYNC_STATUS_CONFLICT
+	}
+	if len(applied) == 0 {
+		result.ChangeSeq = task.ChangeSeq
+		return result, nil
+	}
+
+	if err := model.ApplyTaskFields(task, applied); err != nil {
+		return nil, err
+	}
+	stamps, err := json.Marshal(serverStamps)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateTaskTx(ctx, tx, userID, workspaceID, mutation.ID, task, stamps); err != nil {
+		return nil, err
+	}
+	result.ChangeSeq = task.ChangeSeq
+	return result, nil
+}
+
+// ApplyTagMutation은 클라이언트의 태그 변경 사항을 필드 단위 last-writer-wins로 적용하는 메서드
+func (r *syncRepository) ApplyTagMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (result *model.SyncResult, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		result, err = applyTagMutationTx(ctx, tx, userID, workspaceID, mutation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyTagMutationTx는 트랜잭션 안에서 ApplyTagMutation을 처리하는 함수
+func applyTagMutationTx(ctx context.Context, tx Executor, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error) {
+	result := &model.SyncResult{Entity: model.SYNC_ENTITY_TAG, ID: mutation.ID, ClientID: mutation.ClientID, Status: model.SYNC_STATUS_APPLIED}
+
+	if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	// 새 태그 생성
+	if mutation.Op == model.SYNC_OP_UPSERT && mutation.ID == 0 {
+		tag := &model.Tag{}
+		if err := model.ApplyTagFields(tag, mutation.Fields); err != nil {
+			return nil, err
+		}
+		if tag.Name == "" || tag.Color == "" {
+			return nil, errors.New("name and color are required to create a tag")
+		}
+
+		stamps, err := stampFields(model.SyncTagFields, mutation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertTagTx(ctx, tx, userID, workspaceID, tag, stamps); err != nil {
+			return nil, err
+		}
+		result.ID = tag.ID
+		result.ChangeSeq = tag.ChangeSeq
+		return result, nil
+	}
+
+	tag, serverStamps, err := lockTagTx(ctx, tx, userID, workspaceID, mutation.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if mutation.Op == model.SYNC_OP_DELETE {
+				return result, nil
+			}
+			result.Status = model.SYNC_STATUS_CONFLICT
+			result.Error = ErrSyncEntityDeleted.Error()
+			return result, nil
+		}
+		return nil, err
+	}
+
+	if mutation.Op == model.SYNC_OP_DELETE {
+		if conflicted := newerFields(serverStamps, mutation.UpdatedAt); len(conflicted) > 0 {
+			result.Status = model.SYNC_STATUS_CONFLICT
+			for _, field := range conflicted {
+				result.Conflicts = append(result.Conflicts, model.SyncConflict{Field: field, ServerValue: model.TagFieldValue(tag, field)})
+			}
+			return result, nil
+		}
+		if err := deleteTagTx(ctx, tx, userID, workspaceID, mutation.ID, 0); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	applied, conflicted := resolveFields(mutation.Fields, serverStamps, mutation.UpdatedAt)
+	for _, field := range conflicted {
+		result.Conflicts = append(result.Conflicts, model.SyncConflict{Field: field, ServerValue: model.TagFieldValue(tag, field)})
+	}
+	if len(conflicted) > 0 {
+		result.Status = model.SYNC_STATUS_CONFLICT
+	}
+	if len(applied) == 0 {
+		result.ChangeSeq = tag.ChangeSeq
+		return result, nil
+	}
+
+	if err := model.ApplyTagFields(tag, applied); err != nil {
+		return nil, err
+	}
+	stamps, err := json.Marshal(serverStamps)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateTagTx(ctx, tx, userID, workspaceID, mutation.ID, tag, stamps); err != nil {
+		return nil, err
+	}
+	result.ChangeSeq = tag.ChangeSeq
+	return result, nil
+}
+
+// ApplyTaskTagMutation은 클라이언트의 작업-태그 연결 변경 사항을 적용하는 메서드 (멱등)
+func (r *syncRepository) ApplyTaskTagMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) (result *model.SyncResult, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		result, err = applyTaskTagMutationTx(ctx, tx, userID, workspaceID, mutation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyTaskTagMutationTx는 트랜잭션 안에서 ApplyTaskTagMutation을 처리하는 함수
+func applyTaskTagMutationTx(ctx context.Context, tx Executor, userID int, workspaceID int, mutation *model.SyncMutation) (*model.SyncResult, error) {
+	result := &model.SyncResult{Entity: model.SYNC_ENTITY_TASK_TAG, ClientID: mutation.ClientID, Status: model.SYNC_STATUS_APPLIED}
+
+	if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	// 작업과 태그가 모두 같은 작업 공간에 있는지 확인
+	err := checkTaskTagInWorkspaceTx(ctx, tx, workspaceID, mutation.TaskID, mutation.TagID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if err == sql.ErrNoRows {
+		if mutation.Op == model.SYNC_OP_DELETE {
+			return result, nil
+		}
+		result.Status = model.SYNC_STATUS_CONFLICT
+		result.Error = ErrSyncEntityDeleted.Error()
+		return result, nil
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, EXIST_TAG_IN_TASK_QUERY, mutation.TaskID, mutation.TagID).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case mutation.Op == model.SYNC_OP_UPSERT && !exists:
+		seq, err := addTagToTaskTx(ctx, tx, userID, workspaceID, mutation.TaskID, mutation.TagID)
+		if err != nil {
+			return nil, err
+		}
+		result.ChangeSeq = seq
+	case mutation.Op == model.SYNC_OP_DELETE && exists:
+		seq, err := removeTagFromTaskTx(ctx, tx, userID, workspaceID, mutation.TaskID, mutation.TagID)
+		if err != nil {
+			return nil, err
+		}
+		result.ChangeSeq = seq
+	default:
+		// 이미 원하는 상태이므로 변경 없음
+		return result, nil
+	}
+
+	return result, nil
+}
+
+// getChangeSeq는 작업 공간의 현재 변경 시퀀스를 조회하는 함수, 구성원이 아니면 ErrWorkspaceAccessDenied를 반환
+func getChangeSeq(ctx context.Context, tx Executor, userID int, workspaceID int) (int64, error) {
+	var seq int64
+	if err := tx.QueryRowContext(ctx, GET_CHANGE_SEQ_QUERY, workspaceID, userID).Scan(&seq); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrWorkspaceAccessDenied
+		}
+		return 0, err
+	}
+	return seq, nil
+}
+
+// nextChangeSeq는 작업 공간의 변경 시퀀스를 1 증가시키고 반환하는 함수
+// workspaces 행 잠금이 커밋까지 유지되므로 같은 작업 공간의 변경은 시퀀스 순서대로 커밋됨
+// 요청 사용자가 수정할 수 있는 구성원이 아니면 ErrWorkspaceAccessDenied를 반환
+func nextChangeSeq(ctx context.Context, tx Executor, userID int, workspaceID int) (int64, error) {
+	var seq int64
+	if err := tx.QueryRowContext(ctx, NEXT_CHANGE_SEQ_QUERY, workspaceID, userID).Scan(&seq); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrWorkspaceAccessDenied
+		}
+		return 0, err
+	}
+	return seq, nil
+}
+
+// lockChangeSeq는 엔티티 행보다 먼저 작업 공간 행을 잠그는 함수
+// REST 요청과 같은 순서(workspaces -> 엔티티)로 잠가야 교착 상태가 발생하지 않음
+// 요청 사용자가 수정할 수 있는 구성원이 아니면 ErrWorkspaceAccessDenied를 반환
+func lockChangeSeq(ctx context.Context, tx Executor, userID int, workspaceID int) error {
+	var seq int64
+	if err := tx.QueryRowContext(ctx, LOCK_CHANGE_SEQ_QUERY, workspaceID, userID).Scan(&seq); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkspaceAccessDenied
+		}
+		return err
+	}
+	return nil
+}
+
+// insertTombstone은 삭제 된 엔티티를 tombstone으로 기록하는 함수, userID는 삭제한 사용자
+func insertTombstone(ctx context.Context, tx Executor, userID int, workspaceID int, entity string, entityID string, seq int64) error {
+	_, err := tx.ExecContext(ctx, INSERT_TOMBSTONE_QUERY, userID, workspaceID, entity, entityID, seq)
+	return err
+}
+
+// taskTagEntityID는 작업-태그 연결의 tombstone ID를 반환하는 함수
+func taskTagEntityID(taskID int, tagID int) string {
+	return utils.InterfaceToString(taskID) + ":" + utils.InterfaceToString(tagID)
+}
+
+// stampFields는 모든 필드의 마지막 수정 시각을 at으로 기록한 JSON을 반환하는 함수
+func stampFields(fields []string, at time.Time) ([]byte, error) {
+	stamps := make(map[string]time.Time, len(fields))
+	for _, field := range fields {
+		stamps[field] = at.UTC()
+	}
+	return json.Marshal(stamps)
+}
+
+// changedFields는 저장 된 값과 새 값이 다른 필드 목록을 반환하는 함수, values는 필드의 (저장 된 값, 새 값)을 반환
+func changedFields(fields []string, values func(field string) (interface{}, interface{})) []string {
+	changed := []string{}
+	for _, field := range fields {
+		stored, updated := values(field)
+		if storedTime, ok := stored.(time.Time); ok {
+			if updatedTime, ok := updated.(time.Time); ok && storedTime.Equal(updatedTime) {
+				continue
+			}
+		} else if reflect.DeepEqual(stored, updated) {
+			continue
+		}
+		changed = append(changed, field)
+	}
+	return changed
+}
+
+// restampFields는 fields의 마지막 수정 시각만 at으로 바꾼 JSON을 반환하는 함수
+func restampFields(stamps map[string]time.Time, fields []string, at time.Time) ([]byte, error) {
+	for _, field := range fields {
+		stamps[field] = at.UTC()
+	}
+	return json.Marshal(stamps)
+}
+
+// resolveFields는 필드 별 수정 시각을 비교하여 적용할 필드와 충돌 필드를 나누는 함수
+// 적용 된 필드의 수정 시각은 stamps에 반영됨
+func resolveFields(fields map[string]json.RawMessage, stamps map[string]time.Time, clientAt time.Time) (map[string]json.RawMessage, []string) {
+	applied := make(map[string]json.RawMessage)
+	conflicted := []string{}
+	for field, value := range fields {
+		if serverAt, ok := stamps[field]; ok && serverAt.After(clientAt) {
+			conflicted = append(conflicted, field)
+			continue
+		}
+		applied[field] = value
+		stamps[field] = clientAt.UTC()
+	}
+	return applied, conflicted
+}
+
+// newerFields는 clientAt 이후에 서버에서 수정 된 필드 목록을 반환하는 함수
+func newerFields(stamps map[string]time.Time, clientAt time.Time) []string {
+	fields := []string{}
+	for field, serverAt := range stamps {
+		if serverAt.After(clientAt) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// lockTaskTx는 작업 공간의 작업과 필드 별 수정 시각을 행 잠금과 함께 조회하는 함수, userID가 수정할 수 있는 구성원이 아니면 sql.ErrNoRows를 반환
+func lockTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int) (*model.Task, map[string]time.Time, error) {
+	var (
+		task   model.Task
+		stamps []byte
+	)
+	row := tx.QueryRowContext(ctx, LOCK_TASK_QUERY, taskID, workspaceID, userID)
+	if err := scanTaskFields(row, &task, &stamps); err != nil {
+		return nil, nil, err
+	}
+
+	serverStamps := make(map[string]time.Time)
+	if err := json.Unmarshal(stamps, &serverStamps); err != nil {
+		return nil, nil, err
+	}
+	return &task, serverStamps, nil
+}
+
+// lockTagTx는 작업 공간의 태그와 필드 별 수정 시각을 행 잠금과 함께 조회하는 함수, userID가 수정할 수 있는 구성원이 아니면 sql.ErrNoRows를 반환
+func lockTagTx(ctx context.Context, tx Executor, userID int, workspaceID int, tagID int) (*model.Tag, map[string]time.Time, error) {
+	var (
+		tag    model.Tag
+		stamps []byte
+	)
+	row := tx.QueryRowContext(ctx, LOCK_TAG_QUERY, tagID, workspaceID, userID)
+	if err := scanTagFields(row, &tag, &stamps); err != nil {
+		return nil, nil, err
+	}
+
+	serverStamps := make(map[string]time.Time)
+	if err := json.Unmarshal(stamps, &serverStamps); err != nil {
+		return nil, nil, err
+	}
+	return &tag, serverStamps, nil
+}
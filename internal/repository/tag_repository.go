@@ -1,57 +1,66 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"time"
+
 	"lux-list/internal/model"
+	"lux-list/pkg/utils"
 )
 
 const (
-	GET_TAGS_BY_TAG_ID_QUERY  = "SELECT id, user_id, name, color, created_at FROM tags WHERE user_id = $1 AND id = $2"
-	GET_TAGS_BY_USER_ID_QUERY = "SELECT id, user_id, name, color, created_at FROM tags WHERE user_id = $1"
-	GET_TAGS_BY_TASK_ID_QUERY = "SELECT id, user_id, name, color, created_at FROM tags WHERE id IN (SELECT tag_id FROM task_tags WHERE task_id = $1)"
-	INSERT_TAGS_QUERY         = "INSERT INTO tags (user_id, name, color) VALUES ($1, $2, $3) RETURNING id, created_at"
-	DELETE_TAGS_QUERY         = "DELETE FROM tags WHERE user_id = $1 AND id = $2"
-	UPDATE_TAGS_QUERY         = "UPDATE tags SET name = $1, color = $2 WHERE user_id = $3 AND id = $4 RETURNING id, created_at"
+	// user_id는 태그를 만든 사용자, workspace_id는 태그가 속한 작업 공간
+	// 수정 / 삭제 쿼리는 요청 사용자가 작업 공간에서 수정할 수 있는 구성원인지 함께 확인함
+	TAG_COLUMNS                    = "id, user_id, workspace_id, name, color, created_at, change_seq, version"
+	GET_TAGS_BY_TAG_ID_QUERY       = "SELECT " + TAG_COLUMNS + " FROM tags WHERE workspace_id = $1 AND id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $3)"
+	GET_TAGS_BY_WORKSPACE_ID_QUERY = "SELECT " + TAG_COLUMNS + " FROM tags WHERE workspace_id = $1 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $2)"
+	GET_TAGS_BY_TASK_ID_QUERY      = "SELECT " + TAG_COLUMNS + " FROM tags WHERE workspace_id = $1 AND id IN (SELECT tag_id FROM task_tags WHERE task_id = $2) AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $3)"
+	INSERT_TAGS_QUERY              = "INSERT INTO tags (user_id, workspace_id, name, color, change_seq, field_updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, version"
+	DELETE_TAGS_QUERY              = "DELETE FROM tags WHERE workspace_id = $1 AND id = $2 AND ($3 = 0 OR version = $3) AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $4 AND wm.role <> 'viewer')"
+	UPDATE_TAGS_QUERY              = "UPDATE tags SET name = $1, color = $2, change_seq = $3, field_updated_at = $4, version = version + 1 WHERE workspace_id = $5 AND id = $6 AND version = $7 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.user_id = $8 AND wm.role <> 'viewer') RETURNING id, user_id, created_at, version"
+	EXIST_WORKSPACE_TAG_QUERY      = "SELECT EXISTS(SELECT 1 FROM tags WHERE id = $1 AND workspace_id = $2)"
 )
 
 // TagRepository는 태그 관련 데이터베이스 작업을 정의하는 인터페이스
+// 태그는 작업 공간(workspaceID)에 속하며, 작업과 같은 방식으로 요청 사용자(userID)의 역할을 확인함
 type TagRepository interface {
-	GetTagsByTagID(userID int, tagID int) (*model.Tag, error)
-	GetTagsByUserID(userID int) ([]model.Tag, error)
-	GetTagsByTaskID(userID int, taskID int) ([]model.Tag, error)
-	CreateTags(userID int, tag *model.Tag) (*model.Tag, error)
-	DeleteTags(userID int, tagID int) error
-	UpdateTags(userID int, tagID int, tag *model.Tag) (*model.Tag, error)
+	GetTagsByTagID(ctx context.Context, userID int, workspaceID int, tagID int) (*model.Tag, error)
+	GetTagsByWorkspaceID(ctx context.Context, userID int, workspaceID int) ([]model.Tag, error)
+	GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, error)
+	CreateTags(ctx context.Context, userID int, workspaceID int, tag *model.Tag) (*model.Tag, error)
+	DeleteTags(ctx context.Context, userID int, workspaceID int, tagID int, version int) error
+	UpdateTags(ctx context.Context, userID int, workspaceID int, tagID int, tag *model.Tag) (*model.Tag, error)
 }
 
 // tagRepository는 TagRepository 인터페이스를 구현하는 구조체
 type tagRepository struct {
-	db *sql.DB
+	db Executor
 }
 
 // NewTagRepository는 TagRepository의 인스턴스를 생성하는 함수
-func NewTagRepository(db *sql.DB) TagRepository {
+func NewTagRepository(db Executor) TagRepository {
 	return &tagRepository{
 		db: db,
 	}
 }
 
 // GetTagsByTagID는 태그 ID로 태그를 조회하는 메서드
-func (r *tagRepository) GetTagsByTagID(userID int, tagID int) (*model.Tag, error) {
-	row := r.db.QueryRow(GET_TAGS_BY_TAG_ID_QUERY, userID, tagID)
+func (r *tagRepository) GetTagsByTagID(ctx context.Context, userID int, workspaceID int, tagID int) (*model.Tag, error) {
+	row := r.db.QueryRowContext(ctx, GET_TAGS_BY_TAG_ID_QUERY, workspaceID, tagID, userID)
 	var tag model.Tag
-	if err := row.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+	if err := scanTagFields(row, &tag); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, sql.ErrNoRows
 		}
 		return nil, err
 	}
 	return &tag, nil
 }
 
-// GetTagsByUserID는 사용자의 모든 태그를 조회하는 메서드
-func (r *tagRepository) GetTagsByUserID(userID int) ([]model.Tag, error) {
-	rows, err := r.db.Query(GET_TAGS_BY_USER_ID_QUERY, userID)
+// GetTagsByWorkspaceID는 작업 공간의 모든 태그를 조회하는 메서드
+func (r *tagRepository) GetTagsByWorkspaceID(ctx context.Context, userID int, workspaceID int) ([]model.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, GET_TAGS_BY_WORKSPACE_ID_QUERY, workspaceID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +69,7 @@ func (r *tagRepository) GetTagsByUserID(userID int) ([]model.Tag, error) {
 	var tags []model.Tag
 	for rows.Next() {
 		var tag model.Tag
-		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+		if err := scanTagFields(rows, &tag); err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
@@ -69,8 +78,8 @@ func (r *tagRepository) GetTagsByUserID(userID int) ([]model.Tag, error) {
 }
 
 // GetTagsByTaskID는 작업 ID로 태그를 조회하는 메서드
-func (r *tagRepository) GetTagsByTaskID(userID int, taskID int) ([]model.Tag, error) {
-	rows, err := r.db.Query(GET_TAGS_BY_TASK_ID_QUERY, taskID)
+func (r *tagRepository) GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, GET_TAGS_BY_TASK_ID_QUERY, workspaceID, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +88,7 @@ func (r *tagRepository) GetTagsByTaskID(userID int, taskID int) ([]model.Tag, er
 	var tags []model.Tag
 	for rows.Next() {
 		var tag model.Tag
-		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+		if err := scanTagFields(rows, &tag); err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
@@ -88,42 +97,131 @@ func (r *tagRepository) GetTagsByTaskID(userID int, taskID int) ([]model.Tag, er
 }
 
 // CreateTags는 새로운 태그를 생성하는 메서드
-func (r *tagRepository) CreateTags(userID int, tag *model.Tag) (*model.Tag, error) {
-	row := r.db.QueryRow(INSERT_TAGS_QUERY, userID, tag.Name, tag.Color)
-	if err := row.Scan(&tag.ID, &tag.CreatedAt); err != nil {
+func (r *tagRepository) CreateTags(ctx context.Context, userID int, workspaceID int, tag *model.Tag) (*model.Tag, error) {
+	stamps, err := stampFields(model.SyncTagFields, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		return insertTagTx(ctx, tx, userID, workspaceID, tag, stamps)
+	})
+	if err != nil {
 		return nil, err
 	}
-	tag.UserID = userID
 	return tag, nil
 }
 
-// DeleteTags는 태그를 삭제하는 메서드
-func (r *tagRepository) DeleteTags(userID int, tagID int) error {
-	result, err := r.db.Exec(DELETE_TAGS_QUERY, userID, tagID)
+// DeleteTags는 태그를 삭제하는 메서드, version이 0이 아니면 해당 버전일 때만 삭제
+func (r *tagRepository) DeleteTags(ctx context.Context, userID int, workspaceID int, tagID int, version int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		return deleteTagTx(ctx, tx, userID, workspaceID, tagID, version)
+	})
+}
+
+// UpdateTags는 태그를 업데이트하는 메서드, tag.Version과 현재 버전이 다르면 ErrVersionConflict를 반환
+// 저장 된 값과 달라진 필드만 현재 시각으로 기록함
+func (r *tagRepository) UpdateTags(ctx context.Context, userID int, workspaceID int, tagID int, tag *model.Tag) (*model.Tag, error) {
+	err := runInTx(ctx, r.db, func(tx Executor) error {
+		if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+			return err
+		}
+		stored, stamps, err := lockTagTx(ctx, tx, userID, workspaceID, tagID)
+		if err != nil {
+			return err
+		}
+
+		changed := changedFields(model.SyncTagFields, func(field string) (interface{}, interface{}) {
+			return model.TagFieldValue(stored, field), model.TagFieldValue(tag, field)
+		})
+		encoded, err := restampFields(stamps, changed, time.Now())
+		if err != nil {
+			return err
+		}
+		return updateTagTx(ctx, tx, userID, workspaceID, tagID, tag, encoded)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return tag, nil
+}
 
-	rowsAffected, err := result.RowsAffected()
+// insertTagTx는 트랜잭션 안에서 변경 시퀀스를 발급받아 작업 공간에 태그를 생성하는 함수
+func insertTagTx(ctx context.Context, tx Executor, userID int, workspaceID int, tag *model.Tag, stamps []byte) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+
+	row := tx.QueryRowContext(ctx, INSERT_TAGS_QUERY, userID, workspaceID, tag.Name, tag.Color, seq, stamps)
+	if err := row.Scan(&tag.ID, &tag.CreatedAt, &tag.Version); err != nil {
+		return err
 	}
+	tag.UserID = userID
+	tag.WorkspaceID = workspaceID
+	tag.ChangeSeq = seq
 
 	return nil
 }
 
-// UpdateTags는 태그를 업데이트하는 메서드
-func (r *tagRepository) UpdateTags(userID int, tagID int, tag *model.Tag) (*model.Tag, error) {
-	row := r.db.QueryRow(UPDATE_TAGS_QUERY, tag.Name, tag.Color, userID, tagID)
-	if err := row.Scan(&tag.ID, &tag.CreatedAt); err != nil {
+// updateTagTx는 트랜잭션 안에서 변경 시퀀스를 발급받아 태그를 업데이트하는 함수 (태그를 만든 사용자는 바뀌지 않음)
+func updateTagTx(ctx context.Context, tx Executor, userID int, workspaceID int, tagID int, tag *model.Tag, stamps []byte) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRowContext(ctx, UPDATE_TAGS_QUERY, tag.Name, tag.Color, seq, stamps, workspaceID, tagID, tag.Version, userID)
+	if err := row.Scan(&tag.ID, &tag.UserID, &tag.CreatedAt, &tag.Version); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, sql.ErrNoRows
+			return versionConflictOrNotFound(ctx, tx, EXIST_WORKSPACE_TAG_QUERY, tagID, workspaceID)
 		}
-		return nil, err
+		return err
 	}
-	tag.UserID = userID
-	return tag, nil
+
+	// 작업 응답에 태그가 포함되므로 연결 된 작업의 버전도 올림
+	if _, err := tx.ExecContext(ctx, BUMP_TASK_VERSIONS_BY_TAG_QUERY, tagID); err != nil {
+		return err
+	}
+	tag.WorkspaceID = workspaceID
+	tag.ChangeSeq = seq
+
+	return nil
+}
+
+// deleteTagTx는 트랜잭션 안에서 태그를 삭제하고 태그와 연결 된 작업의 tombstone을 기록하는 함수
+func deleteTagTx(ctx context.Context, tx Executor, userID int, workspaceID int, tagID int, version int) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// ON DELETE CASCADE로 사라지는 task_tags도 tombstone으로 기록하고, 연결 된 작업의 버전을 올림
+	if _, err := tx.ExecContext(ctx, INSERT_TASK_TAG_TOMBSTONES_BY_TAG_ID_QUERY, userID, workspaceID, seq, tagID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, BUMP_TASK_VERSIONS_BY_TAG_QUERY, tagID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, DELETE_TAGS_QUERY, workspaceID, tagID, version, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return versionConflictOrNotFound(ctx, tx, EXIST_WORKSPACE_TAG_QUERY, tagID, workspaceID)
+	}
+
+	return insertTombstone(ctx, tx, userID, workspaceID, model.SYNC_ENTITY_TAG, utils.InterfaceToString(tagID), seq)
+}
+
+// scanTagFields는 TAG_COLUMNS 순서의 행을 태그로 읽는 함수, extra는 그 뒤에 이어지는 컬럼
+func scanTagFields(row rowScanner, tag *model.Tag, extra ...any) error {
+	dest := append([]any{&tag.ID, &tag.UserID, &tag.WorkspaceID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.ChangeSeq, &tag.Version}, extra...)
+	return row.Scan(dest...)
 }
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"lux-list/internal/model"
 	"lux-list/pkg/utils"
@@ -11,69 +15,82 @@ import (
 
 const (
 	// Query
-	FIND_ALL_TASKS_QUERY            = "SELECT id, user_id, title, description, due_date, is_completed, priority, created_at, updated_at FROM tasks WHERE user_id = $1 ORDER BY due_date DESC"
-	FIND_ALL_TASKS_QUERY_BY_TASK_ID = "SELECT id, user_id, title, description, due_date, is_completed, priority, created_at, updated_at FROM tasks WHERE id = $1 AND user_id = $2"
-	INSERT_TASKS_QUERY              = "INSERT INTO tasks (user_id, title, description, due_date, is_completed, priority) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at"
-	DELETE_TASKS_QUERY              = "DELETE FROM tasks WHERE id = $1 AND user_id = $2"
-	UPDATE_TASKS_QUERY              = "UPDATE tasks SET title = $1, description = $2, due_date = $3, is_completed = $4, priority = $5, updated_at = NOW() WHERE id = $6 AND user_id = $7 RETURNING updated_at"
+	// user_id는 작업을 만든 사용자, workspace_id는 작업이 속한 작업 공간
+	// 수정 / 삭제 쿼리는 요청 사용자가 작업 공간에서 수정할 수 있는 구성원인지 함께 확인함 (호출 전 권한 확인이 빠져도 다른 작업 공간을 바꿀 수 없음)
+	TASK_COLUMNS                    = "id, user_id, workspace_id, title, description, due_date, all_day, is_completed, priority, created_at, updated_at, change_seq, version"
+	FIND_ALL_TASKS_QUERY_BY_TASK_ID = "SELECT " + TASK_COLUMNS + " FROM tasks WHERE id = $1 AND workspace_id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = $3)"
+	INSERT_TASKS_QUERY              = "INSERT INTO tasks (user_id, workspace_id, title, description, due_date, all_day, is_completed, priority, change_seq, field_updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at, version"
+	DELETE_TASKS_QUERY              = "DELETE FROM tasks WHERE id = $1 AND workspace_id = $2 AND ($3 = 0 OR version = $3) AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = $4 AND wm.role <> 'viewer')"
+	UPDATE_TASKS_QUERY              = "UPDATE tasks SET title = $1, description = $2, due_date = $3, all_day = $4, is_completed = $5, priority = $6, change_seq = $7, field_updated_at = $8, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $9 AND workspace_id = $10 AND version = $11 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = $12 AND wm.role <> 'viewer') RETURNING user_id, updated_at, version"
+	EXIST_WORKSPACE_TASK_QUERY      = "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND workspace_id = $2)"
+	GET_TASK_WORKSPACE_QUERY        = "SELECT workspace_id FROM tasks WHERE id = $1"
+	BUMP_TASK_VERSION_QUERY         = "UPDATE tasks SET version = version + 1 WHERE id = $1"
+	BUMP_TASK_VERSIONS_BY_TAG_QUERY = "UPDATE tasks SET version = version + 1 WHERE id IN (SELECT task_id FROM task_tags WHERE tag_id = $1)"
+
+	// 작업 목록 조회(squirrel)에서 요청 사용자가 작업 공간의 구성원인지 확인하는 조건
+	TASK_WORKSPACE_MEMBER_CONDITION = "EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = ?)"
+)
+
+var (
+	// ErrTooManyBulkTasks는 필터에 해당하는 작업이 일괄 처리 최대 개수를 넘을 때 반환되는 에러
+	ErrTooManyBulkTasks = errors.New("filter matches too many tasks")
+	// ErrVersionConflict는 조회 이후 다른 요청이 먼저 수정하여 버전이 달라졌을 때 반환되는 에러
+	ErrVersionConflict = errors.New("resource was modified by another request")
+
+	// errBulkRollback은 일괄 처리 결과는 반환하면서 트랜잭션만 롤백하기 위한 내부 에러
+	errBulkRollback = errors.New("bulk operation rolled back")
 )
 
 // TaskRepository는 작업 관련 데이터베이스 작업을 정의하는 인터페이스
+// 작업은 작업 공간(workspaceID)에 속하며, 요청 사용자(userID)가 구성원이 아니면 조회 되지 않고
+// 수정할 수 있는 역할이 아니면 ErrWorkspaceAccessDenied를 반환
 type TaskRepository interface {
-	GetTasks(userID int, search_query map[string]interface{}) (*model.TaskListResult, error)
-	GetTasksByTaskID(userID int, taskID int) (*model.Task, error)
-	CreateTasks(userID int, task *model.Task) (*model.Task, error)
-	DeleteTasks(userID int, taskID int) error
-	UpdateTasks(userID int, taskID int, task *model.Task) (*model.Task, error)
+	GetTasks(ctx context.Context, userID int, workspaceID int, search_query map[string]interface{}) (*model.TaskListResult, error)
+	GetTasksByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) (*model.Task, error)
+	GetTaskWorkspaceID(ctx context.Context, taskID int) (int, error)
+	CreateTasks(ctx context.Context, userID int, workspaceID int, task *model.Task) (*model.Task, error)
+	DeleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) error
+	UpdateTasks(ctx context.Context, userID int, workspaceID int, taskID int, task *model.Task) (*model.Task, error)
+	BulkTasks(ctx context.Context, userID int, workspaceID int, req *model.BulkTaskRequest, search_query map[string]interface{}) (*model.BulkTaskResponse, error)
 }
 
 // taskRepository는 TaskRepository 인터페이스를 구현하는 구조체
 type taskRepository struct {
-	db *sql.DB
+	db Executor
 }
 
 // NewTaskRepository는 TaskRepository의 인스턴스를 생성하는 함수
-func NewTaskRepository(db *sql.DB) TaskRepository {
+func NewTaskRepository(db Executor) TaskRepository {
 	return &taskRepository{
 		db: db,
 	}
 }
 
-// GetTasks는 사용자의 모든 작업을 조회하는 메서드
-func (r *taskRepository) GetTasks(userID int, search_query map[string]interface{}) (*model.TaskListResult, error) {
+// GetTasks는 작업 공간의 모든 작업을 조회하는 메서드
+func (r *taskRepository) GetTasks(ctx context.Context, userID int, workspaceID int, search_query map[string]interface{}) (*model.TaskListResult, error) {
 	limit, page := utils.CreatePaginationQuery(search_query)
 	orderBy := utils.CreateOrderByQuery(search_query)
 
 	queryBuilder := sq.Select(
-		"id", "user_id", "title", "description", "due_date", "is_completed", "priority", "created_at", "updated_at",
+		TASK_COLUMNS,
 		"COUNT(*) OVER() AS total_count", // 전체 작업 수를 가져오기 위한 서브쿼리
 	).
 		From("tasks").
-		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"workspace_id": workspaceID}).
+		Where(TASK_WORKSPACE_MEMBER_CONDITION, userID).
 		Limit(uint64(limit)).
 		Offset(uint64((page - 1) * limit)).
 		OrderBy(orderBy)
 
 	// 검색 쿼리 처리
-	for key, value := range search_query {
-		switch key {
-		case "title":
-			queryBuilder = queryBuilder.Where(sq.Like{"title": "%" + value.(string) + "%"})
-		case "is_completed":
-			queryBuilder = queryBuilder.Where(sq.Eq{"is_completed": value})
-		case "priority":
-			queryBuilder = queryBuilder.Where(sq.Eq{"priority": value.(string)})
-		case "due_date":
-			queryBuilder = queryBuilder.Where(sq.Eq{"due_date": value.(string)})
-		}
-	}
+	queryBuilder = applyTaskFilters(queryBuilder, search_query)
 
 	query, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +100,7 @@ func (r *taskRepository) GetTasks(userID int, search_query map[string]interface{
 	totalCount := 0
 	for rows.Next() {
 		var task model.Task
-		if err := rows.Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.DueDate, &task.IsCompleted, &task.Priority, &task.CreatedAt, &task.UpdatedAt, &totalCount); err != nil {
+		if err := scanTaskFields(rows, &task, &totalCount); err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, task)
@@ -96,11 +113,11 @@ func (r *taskRepository) GetTasks(userID int, search_query map[string]interface{
 }
 
 // GetTasksByTaskID는 작업 ID로 작업을 조회하는 메서드
-func (r *taskRepository) GetTasksByTaskID(userID int, taskID int) (*model.Task, error) {
+func (r *taskRepository) GetTasksByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) (*model.Task, error) {
 	var task model.Task
 	query := FIND_ALL_TASKS_QUERY_BY_TASK_ID
-	row := r.db.QueryRow(query, taskID, userID)
-	if err := row.Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.DueDate, &task.IsCompleted, &task.Priority, &task.CreatedAt, &task.UpdatedAt); err != nil {
+	row := r.db.QueryRowContext(ctx, query, taskID, workspaceID, userID)
+	if err := scanTaskFields(row, &task); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
 		}
@@ -109,21 +126,280 @@ func (r *taskRepository) GetTasksByTaskID(userID int, taskID int) (*model.Task,
 	return &task, nil
 }
 
+// GetTaskWorkspaceID는 작업이 속한 작업 공간 ID를 조회하는 메서드 (실시간 구독 권한 확인용)
+func (r *taskRepository) GetTaskWorkspaceID(ctx context.Context, taskID int) (int, error) {
+	var workspaceID int
+	if err := r.db.QueryRowContext(ctx, GET_TASK_WORKSPACE_QUERY, taskID).Scan(&workspaceID); err != nil {
+		return 0, err
+	}
+	return workspaceID, nil
+}
+
 // CreateTasks는 새로운 작업을 생성하는 메서드
-func (r *taskRepository) CreateTasks(userID int, task *model.Task) (*model.Task, error) {
-	query := INSERT_TASKS_QUERY
-	row := r.db.QueryRow(query, userID, task.Title, task.Description, task.DueDate, task.IsCompleted, task.Priority)
-	if err := row.Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt); err != nil {
+func (r *taskRepository) CreateTasks(ctx context.Context, userID int, workspaceID int, task *model.Task) (*model.Task, error) {
+	stamps, err := stampFields(model.SyncTaskFields, time.Now())
+	if err != nil {
 		return nil, err
 	}
-	task.UserID = userID
 
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		return insertTaskTx(ctx, tx, userID, workspaceID, task, stamps)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-// DeleteTask는 작업을 삭제하는 메서드
-func (r *taskRepository) DeleteTasks(userID int, taskID int) error {
-	result, err := r.db.Exec(DELETE_TASKS_QUERY, taskID, userID)
+// DeleteTask는 작업을 삭제하는 메서드, version이 0이 아니면 해당 버전일 때만 삭제
+func (r *taskRepository) DeleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		return deleteTaskTx(ctx, tx, userID, workspaceID, taskID, version)
+	})
+}
+
+// UpdateTasks는 작업을 업데이트하는 메서드, task.Version과 현재 버전이 다르면 ErrVersionConflict를 반환
+// 저장 된 값과 달라진 필드만 현재 시각으로 기록하여, 바꾸지 않은 필드의 오프라인 수정이 동기화에서 밀려나지 않도록 함
+func (r *taskRepository) UpdateTasks(ctx context.Context, userID int, workspaceID int, taskID int, task *model.Task) (*model.Task, error) {
+	err := runInTx(ctx, r.db, func(tx Executor) error {
+		if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+			return err
+		}
+		stored, stamps, err := lockTaskTx(ctx, tx, userID, workspaceID, taskID)
+		if err != nil {
+			return err
+		}
+
+		changed := changedFields(model.SyncTaskFields, func(field string) (interface{}, interface{}) {
+			return model.TaskFieldValue(stored, field), model.TaskFieldValue(task, field)
+		})
+		encoded, err := restampFields(stamps, changed, time.Now())
+		if err != nil {
+			return err
+		}
+		return updateTaskTx(ctx, tx, userID, workspaceID, taskID, task, encoded)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// BulkTasks는 하나의 트랜잭션 안에서 여러 작업에 같은 변경을 적용하는 메서드
+// search_query는 req.Filter를 GetTasks와 같은 형식으로 바꾼 검색 쿼리이며, nil이면 req.TaskIDs를 대상으로 함
+// 찾을 수 없는 작업이 하나라도 있으면 전체를 롤백하고 Committed가 false인 결과를 반환
+func (r *taskRepository) BulkTasks(ctx context.Context, userID int, workspaceID int, req *model.BulkTaskRequest, search_query map[string]interface{}) (*model.BulkTaskResponse, error) {
+	response := &model.BulkTaskResponse{Committed: true, Results: []model.BulkTaskResult{}}
+	err := runInTx(ctx, r.db, func(tx Executor) error {
+		if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+			return err
+		}
+
+		taskIDs := req.TaskIDs
+		if search_query != nil {
+			var err error
+			taskIDs, err = findTaskIDsTx(ctx, tx, workspaceID, search_query)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 태그 관련 작업은 태그가 같은 작업 공간의 것인지 먼저 확인
+		if req.TagID != nil {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, EXIST_WORKSPACE_TAG_QUERY, *req.TagID, workspaceID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return sql.ErrNoRows
+			}
+		}
+
+		seen := make(map[int]struct{}, len(taskIDs))
+		for _, taskID := range taskIDs {
+			if _, ok := seen[taskID]; ok {
+				continue
+			}
+			seen[taskID] = struct{}{}
+
+			result, err := bulkTaskTx(ctx, tx, userID, workspaceID, taskID, req)
+			if err != nil {
+				return err
+			}
+			if result.Status == model.BULK_STATUS_NOT_FOUND {
+				response.Committed = false
+			}
+			response.Results = append(response.Results, *result)
+		}
+
+		if !response.Committed {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if err == errBulkRollback {
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// findTaskIDsTx는 GetTasks와 같은 필터로 작업 공간의 작업 ID를 조회하고 행을 잠그는 함수
+func findTaskIDsTx(ctx context.Context, tx Executor, workspaceID int, search_query map[string]interface{}) ([]int, error) {
+	queryBuilder := sq.Select("id").
+		From("tasks").
+		Where(sq.Eq{"workspace_id": workspaceID}).
+		OrderBy("id").
+		Limit(uint64(model.MAX_BULK_TASKS + 1)).
+		Suffix("FOR UPDATE")
+	queryBuilder = applyTaskFilters(queryBuilder, search_query)
+
+	query, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var taskIDs []int
+	for rows.Next() {
+		var taskID int
+		if err := rows.Scan(&taskID); err != nil {
+			return nil, err
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(taskIDs) > model.MAX_BULK_TASKS {
+		return nil, ErrTooManyBulkTasks
+	}
+	return taskIDs, nil
+}
+
+// bulkTaskTx는 트랜잭션 안에서 작업 하나에 일괄 작업을 적용하는 함수
+func bulkTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int, req *model.BulkTaskRequest) (*model.BulkTaskResult, error) {
+	result := &model.BulkTaskResult{TaskID: taskID, Status: model.BULK_STATUS_UNCHANGED}
+
+	task, stamps, err := lockTaskTx(ctx, tx, userID, workspaceID, taskID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			result.Status = model.BULK_STATUS_NOT_FOUND
+			return result, nil
+		}
+		return nil, err
+	}
+
+	switch req.Action {
+	case model.BULK_ACTION_COMPLETE, model.BULK_ACTION_INCOMPLETE:
+		completed := req.Action == model.BULK_ACTION_COMPLETE
+		if task.IsCompleted == completed {
+			return result, nil
+		}
+		task.IsCompleted = completed
+		stamps["is_completed"] = time.Now().UTC()
+	case model.BULK_ACTION_SET_PRIORITY:
+		if task.Priority == *req.Priority {
+			return result, nil
+		}
+		task.Priority = *req.Priority
+		stamps["priority"] = time.Now().UTC()
+	case model.BULK_ACTION_DELETE:
+		if err := deleteTaskTx(ctx, tx, userID, workspaceID, taskID, 0); err != nil {
+			return nil, err
+		}
+		result.Status = model.BULK_STATUS_DELETED
+		return result, nil
+	case model.BULK_ACTION_ADD_TAG, model.BULK_ACTION_REMOVE_TAG:
+		var exists bool
+		if err := tx.QueryRowContext(ctx, EXIST_TAG_IN_TASK_QUERY, taskID, *req.TagID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if req.Action == model.BULK_ACTION_ADD_TAG && !exists {
+			if _, err := addTagToTaskTx(ctx, tx, userID, workspaceID, taskID, *req.TagID); err != nil {
+				return nil, err
+			}
+			result.Status = model.BULK_STATUS_UPDATED
+		}
+		if req.Action == model.BULK_ACTION_REMOVE_TAG && exists {
+			if _, err := removeTagFromTaskTx(ctx, tx, userID, workspaceID, taskID, *req.TagID); err != nil {
+				return nil, err
+			}
+			result.Status = model.BULK_STATUS_UPDATED
+		}
+		return result, nil
+	}
+
+	encoded, err := json.Marshal(stamps)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateTaskTx(ctx, tx, userID, workspaceID, taskID, task, encoded); err != nil {
+		return nil, err
+	}
+	result.Status = model.BULK_STATUS_UPDATED
+	result.Task = task
+	return result, nil
+}
+
+// insertTaskTx는 트랜잭션 안에서 변경 시퀀스를 발급받아 작업 공간에 작업을 생성하는 함수
+func insertTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, task *model.Task, stamps []byte) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRowContext(ctx, INSERT_TASKS_QUERY, userID, workspaceID, task.Title, task.Description, task.DueDate.UTC(), task.AllDay, task.IsCompleted, task.Priority, seq, stamps)
+	if err := row.Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt, &task.Version); err != nil {
+		return err
+	}
+	task.UserID = userID
+	task.WorkspaceID = workspaceID
+	task.ChangeSeq = seq
+
+	return nil
+}
+
+// updateTaskTx는 트랜잭션 안에서 변경 시퀀스를 발급받아 작업을 업데이트하는 함수 (작업을 만든 사용자는 바뀌지 않음)
+func updateTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int, task *model.Task, stamps []byte) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRowContext(ctx, UPDATE_TASKS_QUERY, task.Title, task.Description, task.DueDate.UTC(), task.AllDay, task.IsCompleted, task.Priority, seq, stamps, taskID, workspaceID, task.Version, userID)
+	if err := row.Scan(&task.UserID, &task.UpdatedAt, &task.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return versionConflictOrNotFound(ctx, tx, EXIST_WORKSPACE_TASK_QUERY, taskID, workspaceID)
+		}
+		return err
+	}
+	task.WorkspaceID = workspaceID
+	task.ChangeSeq = seq
+
+	return nil
+}
+
+// deleteTaskTx는 트랜잭션 안에서 작업을 삭제하고 작업과 연결 된 태그의 tombstone을 기록하는 함수
+func deleteTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int, version int) error {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// ON DELETE CASCADE로 사라지는 task_tags도 tombstone으로 기록
+	if _, err := tx.ExecContext(ctx, INSERT_TASK_TAG_TOMBSTONES_BY_TASK_ID_QUERY, userID, workspaceID, seq, taskID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, DELETE_TASKS_QUERY, taskID, workspaceID, version, userID)
 	if err != nil {
 		return err
 	}
@@ -133,20 +409,60 @@ func (r *taskRepository) DeleteTasks(userID int, taskID int) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return versionConflictOrNotFound(ctx, tx, EXIST_WORKSPACE_TASK_QUERY, taskID, workspaceID)
 	}
 
-	return nil
+	return insertTombstone(ctx, tx, userID, workspaceID, model.SYNC_ENTITY_TASK, utils.InterfaceToString(taskID), seq)
 }
 
-// UpdateTasks는 작업을 업데이트하는 메서드
-func (r *taskRepository) UpdateTasks(userID int, taskID int, task *model.Task) (*model.Task, error) {
-	query := UPDATE_TASKS_QUERY
-	row := r.db.QueryRow(query, task.Title, task.Description, task.DueDate, task.IsCompleted, task.Priority, taskID, userID)
-	if err := row.Scan(&task.UpdatedAt); err != nil {
-		return nil, err
+// versionConflictOrNotFound는 조건부 수정/삭제가 실패했을 때 원인을 구분하는 함수
+// 행이 존재하면 ErrVersionConflict, 존재하지 않으면 sql.ErrNoRows를 반환
+func versionConflictOrNotFound(ctx context.Context, tx Executor, existQuery string, id int, workspaceID int) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, existQuery, id, workspaceID).Scan(&exists); err != nil {
+		return err
 	}
-	task.UserID = userID
+	if exists {
+		return ErrVersionConflict
+	}
+	return sql.ErrNoRows
+}
 
-	return task, nil
+// applyTaskFilters는 검색 쿼리의 필터 조건을 쿼리 빌더에 추가하는 함수
+func applyTaskFilters(queryBuilder sq.SelectBuilder, search_query map[string]interface{}) sq.SelectBuilder {
+	for key, value := range search_query {
+		switch key {
+		case "title":
+			queryBuilder = queryBuilder.Where(sq.Like{"title": "%" + value.(string) + "%"})
+		case "is_completed":
+			queryBuilder = queryBuilder.Where(sq.Eq{"is_completed": value})
+		case "priority":
+			queryBuilder = queryBuilder.Where(sq.Eq{"priority": value.(string)})
+		case "due_date":
+			// 시각이 있는 작업은 사용자 시간대의 하루, 하루 종일 작업은 같은 날짜인지 비교
+			dueRange := value.(model.TaskDueRange)
+			queryBuilder = queryBuilder.Where(sq.Or{
+				sq.And{sq.Eq{"all_day": false}, sq.GtOrEq{"due_date": dueRange.Start}, sq.Lt{"due_date": dueRange.End}},
+				sq.And{sq.Eq{"all_day": true}, sq.GtOrEq{"due_date": dueRange.DateStart}, sq.Lt{"due_date": dueRange.DateEnd}},
+			})
+		case "overdue":
+			overdue := value.(model.TaskOverdue)
+			queryBuilder = queryBuilder.Where(sq.Eq{"is_completed": false}).Where(sq.Or{
+				sq.And{sq.Eq{"all_day": false}, sq.Lt{"due_date": overdue.Now}},
+				sq.And{sq.Eq{"all_day": true}, sq.Lt{"due_date": overdue.Today}},
+			})
+		}
+	}
+	return queryBuilder
+}
+
+// scanTaskFields는 TASK_COLUMNS 순서의 행을 작업으로 읽는 함수, extra는 그 뒤에 이어지는 컬럼
+// 드라이버(세션 시간대)에 따라 시간대가 다르게 읽히므로 마감일은 UTC로 맞춤
+func scanTaskFields(row rowScanner, task *model.Task, extra ...any) error {
+	dest := append([]any{&task.ID, &task.UserID, &task.WorkspaceID, &task.Title, &task.Description, &task.DueDate, &task.AllDay, &task.IsCompleted, &task.Priority, &task.CreatedAt, &task.UpdatedAt, &task.ChangeSeq, &task.Version}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	task.DueDate = task.DueDate.UTC()
+	return nil
 }
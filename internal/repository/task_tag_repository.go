@@ -1,15 +1,17 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"lux-list/internal/model"
 )
 
 const (
-	EXIST_TAG_IN_TASK_QUERY    = "SELECT EXISTS(SELECT 1 FROM task_tags WHERE task_id = $1 AND tag_id = $2)"
-	ADD_TAG_TO_TASK_QUERY      = "INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2)"
-	REMOVE_TAG_FROM_TASK_QUERY = "DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2"
+	EXIST_TAG_IN_TASK_QUERY = "SELECT EXISTS(SELECT 1 FROM task_tags WHERE task_id = $1 AND tag_id = $2)"
+	// 연결 추가 / 제거 쿼리는 작업과 태그가 같은 작업 공간에 있고 요청 사용자가 그 작업 공간에서 수정할 수 있는 구성원인지 함께 확인함
+	ADD_TAG_TO_TASK_QUERY      = "INSERT INTO task_tags (task_id, tag_id, change_seq) SELECT t.id, tg.id, $3 FROM tasks t JOIN tags tg ON tg.workspace_id = t.workspace_id WHERE t.id = $1 AND tg.id = $2 AND t.workspace_id = $4 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = t.workspace_id AND wm.user_id = $5 AND wm.role <> 'viewer')"
+	REMOVE_TAG_FROM_TASK_QUERY = "DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2 AND EXISTS (SELECT 1 FROM tasks t JOIN workspace_members wm ON wm.workspace_id = t.workspace_id WHERE t.id = task_tags.task_id AND t.workspace_id = $3 AND wm.user_id = $4 AND wm.role <> 'viewer')"
 )
 
 var (
@@ -17,68 +19,141 @@ var (
 )
 
 // TaskTagRepository는 작업과 태그 간의 관계를 관리하는 인터페이스
+// 작업과 태그가 모두 workspaceID 작업 공간에 있어야 하며, 그렇지 않으면 sql.ErrNoRows를 반환
 type TaskTagRepository interface {
-	AddTagToTask(taskID int, tagID int) error
-	RemoveTagFromTask(taskID int, tagID int) error
-	GetTagsByTaskID(taskID int) ([]model.Tag, error)
+	AddTagToTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) error
+	RemoveTagFromTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) error
+	GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, error)
 }
 
 // taskTagRepository는 TaskTagRepository 인터페이스를 구현하는 구조체
 type taskTagRepository struct {
-	db *sql.DB
+	db Executor
 }
 
 // NewTaskTagRepository는 TaskTagRepository의 인스턴스를 생성하는 함수
-func NewTaskTagRepository(db *sql.DB) TaskTagRepository {
+func NewTaskTagRepository(db Executor) TaskTagRepository {
 	return &taskTagRepository{
 		db: db,
 	}
 }
 
 // AddTagToTask는 작업에 태그를 추가하는 메서드
-func (r *taskTagRepository) AddTagToTask(taskID int, tagID int) error {
-	// 이미 연결되어 있는지 확인하는 쿼리 실행
-	var exists bool
-	err := r.db.QueryRow(EXIST_TAG_IN_TASK_QUERY, taskID, tagID).Scan(&exists)
-	if err != nil {
+func (r *taskTagRepository) AddTagToTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+			return err
+		}
+		if err := checkTaskTagInWorkspaceTx(ctx, tx, workspaceID, taskID, tagID); err != nil {
+			return err
+		}
+
+		// 이미 연결되어 있는지 확인하는 쿼리 실행
+		var exists bool
+		if err := tx.QueryRowContext(ctx, EXIST_TAG_IN_TASK_QUERY, taskID, tagID).Scan(&exists); err != nil {
+			return err
+		}
+
+		// 이미 연결되어 있다면 sql.ErrNoRows 대신 의미 있는 에러를 반환
+		if exists {
+			return ErrTagAlreadyLinked
+		}
+
+		// 연결되어 있지 않으면 태그 추가
+		_, err := addTagToTaskTx(ctx, tx, userID, workspaceID, taskID, tagID)
+		return err
+	})
+}
+
+// RemoveTagFromTask는 작업에서 태그를 제거하는 메서드
+func (r *taskTagRepository) RemoveTagFromTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		if err := lockChangeSeq(ctx, tx, userID, workspaceID); err != nil {
+			return err
+		}
+		if err := checkTaskTagInWorkspaceTx(ctx, tx, workspaceID, taskID, tagID); err != nil {
+			return err
+		}
+
+		_, err := removeTagFromTaskTx(ctx, tx, userID, workspaceID, taskID, tagID)
 		return err
+	})
+}
+
+// checkTaskTagInWorkspaceTx는 작업과 태그가 모두 작업 공간에 있는지 확인하는 함수, 하나라도 없으면 sql.ErrNoRows를 반환
+func checkTaskTagInWorkspaceTx(ctx context.Context, tx Executor, workspaceID int, taskID int, tagID int) error {
+	var taskExists, tagExists bool
+	if err := tx.QueryRowContext(ctx, EXIST_WORKSPACE_TASK_QUERY, taskID, workspaceID).Scan(&taskExists); err != nil {
+		return err
+	}
+	if err := tx.QueryRowContext(ctx, EXIST_WORKSPACE_TAG_QUERY, tagID, workspaceID).Scan(&tagExists); err != nil {
+		return err
+	}
+	if !taskExists || !tagExists {
+		return sql.ErrNoRows
 	}
+	return nil
+}
 
-	// 이미 연결되어 있다면 sql.ErrNoRows 대신 의미 있는 에러를 반환
-	if exists {
-		return ErrTagAlreadyLinked
+// addTagToTaskTx는 트랜잭션 안에서 변경 시퀀스를 발급받아 작업에 태그를 연결하는 함수
+func addTagToTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int, tagID int) (int64, error) {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
+	if err != nil {
+		return 0, err
 	}
 
-	// 연결되어 있지 않으면 태그 추가
-	_, err = r.db.Exec(ADD_TAG_TO_TASK_QUERY, taskID, tagID)
+	result, err := tx.ExecContext(ctx, ADD_TAG_TO_TASK_QUERY, taskID, tagID, seq, workspaceID, userID)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, sql.ErrNoRows
 	}
 
-	return nil
+	// 작업 응답에 태그가 포함되므로 작업의 버전도 올림
+	if _, err := tx.ExecContext(ctx, BUMP_TASK_VERSION_QUERY, taskID); err != nil {
+		return 0, err
+	}
+	return seq, nil
 }
 
-// RemoveTagFromTask는 작업에서 태그를 제거하는 메서드
-func (r *taskTagRepository) RemoveTagFromTask(taskID int, tagID int) error {
-	result, err := r.db.Exec(REMOVE_TAG_FROM_TASK_QUERY, taskID, tagID)
+// removeTagFromTaskTx는 트랜잭션 안에서 작업과 태그의 연결을 제거하고 tombstone을 기록하는 함수
+func removeTagFromTaskTx(ctx context.Context, tx Executor, userID int, workspaceID int, taskID int, tagID int) (int64, error) {
+	seq, err := nextChangeSeq(ctx, tx, userID, workspaceID)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, REMOVE_TAG_FROM_TASK_QUERY, taskID, tagID, workspaceID, userID)
+	if err != nil {
+		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return 0, sql.ErrNoRows
 	}
 
-	return nil
+	if err := insertTombstone(ctx, tx, userID, workspaceID, model.SYNC_ENTITY_TASK_TAG, taskTagEntityID(taskID, tagID), seq); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, BUMP_TASK_VERSION_QUERY, taskID); err != nil {
+		return 0, err
+	}
+	return seq, nil
 }
 
 // GetTagsByTaskID는 특정 작업에 연결된 태그를 조회하는 메서드
-func (r *taskTagRepository) GetTagsByTaskID(taskID int) ([]model.Tag, error) {
-	rows, err := r.db.Query(GET_TAGS_BY_TASK_ID_QUERY, taskID)
+func (r *taskTagRepository) GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, GET_TAGS_BY_TASK_ID_QUERY, workspaceID, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +162,7 @@ func (r *taskTagRepository) GetTagsByTaskID(taskID int) ([]model.Tag, error) {
 	var tags []model.Tag
 	for rows.Next() {
 		var tag model.Tag
-		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+		if err := scanTagFields(rows, &tag); err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
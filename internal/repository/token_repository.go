@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	PERSONAL_ACCESS_TOKEN_COLUMNS      = "id, user_id, name, token_prefix, scopes, expires_at, last_used_at, COALESCE(last_used_ip, ''), created_at"
+	GET_TOKENS_BY_USER_ID_QUERY        = "SELECT " + PERSONAL_ACCESS_TOKEN_COLUMNS + " FROM personal_access_tokens WHERE user_id = $1 ORDER BY id"
+	GET_TOKEN_BY_HASH_QUERY            = "SELECT " + PERSONAL_ACCESS_TOKEN_COLUMNS + " FROM personal_access_tokens WHERE token_hash = $1 AND user_id IN (SELECT id FROM users WHERE disabled_at IS NULL AND deletion_scheduled_at IS NULL)"
+	COUNT_TOKENS_BY_USER_ID_QUERY      = "SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = $1"
+	INSERT_PERSONAL_ACCESS_TOKEN_QUERY = "INSERT INTO personal_access_tokens (user_id, name, token_prefix, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING " + PERSONAL_ACCESS_TOKEN_COLUMNS
+	UPDATE_TOKEN_LAST_USED_QUERY       = "UPDATE personal_access_tokens SET last_used_at = $2, last_used_ip = $3 WHERE id = $1"
+	DELETE_PERSONAL_ACCESS_TOKEN_QUERY = "DELETE FROM personal_access_tokens WHERE user_id = $1 AND id = $2"
+)
+
+// TokenRepository는 개인 액세스 토큰 관련 데이터베이스 작업을 정의하는 인터페이스
+type TokenRepository interface {
+	GetTokensByUserID(ctx context.Context, userID int) ([]model.PersonalAccessToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+	CountTokens(ctx context.Context, userID int) (int, error)
+	CreateToken(ctx context.Context, token *model.PersonalAccessToken, tokenHash string) (*model.PersonalAccessToken, error)
+	UpdateLastUsed(ctx context.Context, tokenID int, usedAt time.Time, ip string) error
+	DeleteToken(ctx context.Context, userID int, tokenID int) error
+}
+
+// tokenRepository는 TokenRepository 인터페이스를 구현하는 구조체
+type tokenRepository struct {
+	db Executor
+}
+
+// NewTokenRepository는 TokenRepository의 인스턴스를 생성하는 함수
+func NewTokenRepository(db Executor) TokenRepository {
+	return &tokenRepository{
+		db: db,
+	}
+}
+
+// GetTokensByUserID는 사용자의 모든 개인 액세스 토큰을 조회하는 메서드
+func (r *tokenRepository) GetTokensByUserID(ctx context.Context, userID int) ([]model.PersonalAccessToken, error) {
+	rows, err := r.db.QueryContext(ctx, GET_TOKENS_BY_USER_ID_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []model.PersonalAccessToken{}
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+// GetTokenByHash는 토큰 해시로 개인 액세스 토큰을 조회하는 메서드
+// 없거나 사용 중지 된 사용자의 토큰이면 sql.ErrNoRows를 반환
+func (r *tokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	return scanPersonalAccessToken(r.db.QueryRowContext(ctx, GET_TOKEN_BY_HASH_QUERY, tokenHash))
+}
+
+// CountTokens는 사용자의 개인 액세스 토큰 수를 조회하는 메서드
+func (r *tokenRepository) CountTokens(ctx context.Context, userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, COUNT_TOKENS_BY_USER_ID_QUERY, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateToken은 개인 액세스 토큰을 저장하는 메서드, 토큰 원문이 아닌 해시만 저장
+func (r *tokenRepository) CreateToken(ctx context.Context, token *model.PersonalAccessToken, tokenHash string) (*model.PersonalAccessToken, error) {
+	var expiresAt interface{}
+	if token.ExpiresAt != nil {
+		expiresAt = token.ExpiresAt.UTC()
+	}
+
+	row := r.db.QueryRowContext(ctx, INSERT_PERSONAL_ACCESS_TOKEN_QUERY,
+		token.UserID,
+		token.Name,
+		token.TokenPrefix,
+		tokenHash,
+		strings.Join(token.Scopes, ","),
+		expiresAt,
+	)
+	return scanPersonalAccessToken(row)
+}
+
+// UpdateLastUsed는 토큰의 마지막 사용 시각과 IP를 기록하는 메서드
+func (r *tokenRepository) UpdateLastUsed(ctx context.Context, tokenID int, usedAt time.Time, ip string) error {
+	_, err := r.db.ExecContext(ctx, UPDATE_TOKEN_LAST_USED_QUERY, tokenID, usedAt.UTC(), ip)
+	return err
+}
+
+// DeleteToken은 사용자의 개인 액세스 토큰을 삭제(폐기)하는 메서드, 없으면 sql.ErrNoRows를 반환
+func (r *tokenRepository) DeleteToken(ctx context.Context, userID int, tokenID int) error {
+	result, err := r.db.ExecContext(ctx, DELETE_PERSONAL_ACCESS_TOKEN_QUERY, userID, tokenID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// rowScanner는 *sql.Row와 *sql.Rows가 공통으로 구현하는 Scan 인터페이스
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPersonalAccessToken은 PERSONAL_ACCESS_TOKEN_COLUMNS 순서의 행을 읽는 함수
+func scanPersonalAccessToken(row rowScanner) (*model.PersonalAccessToken, error) {
+	var (
+		token      model.PersonalAccessToken
+		scopes     string
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+	)
+	if err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenPrefix, &scopes, &expiresAt, &lastUsedAt, &token.LastUsedIP, &token.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	token.Scopes = strings.Split(scopes, ",")
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	return &token, nil
+}
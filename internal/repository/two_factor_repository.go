@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	USER_TOTP_COLUMNS                 = "user_id, secret_encrypted, last_used_step, enabled_at, created_at"
+	GET_TOTP_QUERY                    = "SELECT " + USER_TOTP_COLUMNS + " FROM user_totp WHERE user_id = $1"
+	DELETE_TOTP_QUERY                 = "DELETE FROM user_totp WHERE user_id = $1"
+	INSERT_TOTP_QUERY                 = "INSERT INTO user_totp (user_id, secret_encrypted, created_at) VALUES ($1, $2, $3)"
+	ENABLE_TOTP_QUERY                 = "UPDATE user_totp SET enabled_at = $2, last_used_step = $3 WHERE user_id = $1 AND enabled_at IS NULL"
+	USE_TOTP_STEP_QUERY               = "UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2"
+	UPDATE_TOTP_SECRET_QUERY          = "UPDATE user_totp SET secret_encrypted = $3 WHERE user_id = $1 AND secret_encrypted = $2"
+	DELETE_RECOVERY_CODES_QUERY       = "DELETE FROM user_recovery_codes WHERE user_id = $1"
+	INSERT_RECOVERY_CODE_QUERY        = "INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)"
+	USE_RECOVERY_CODE_QUERY           = "UPDATE user_recovery_codes SET used_at = $3 WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL"
+	COUNT_UNUSED_RECOVERY_CODES_QUERY = "SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL"
+)
+
+// ErrTOTPNotPending은 확인할 등록 대기 중인 TOTP가 없을 때 반환되는 에러
+var ErrTOTPNotPending = errors.New("no pending two-factor enrollment")
+
+// TwoFactorRepository는 2단계 인증 관련 데이터베이스 작업을 정의하는 인터페이스
+type TwoFactorRepository interface {
+	GetTOTP(ctx context.Context, userID int) (*model.UserTOTP, error)
+	CreatePendingTOTP(ctx context.Context, userID int, secretEncrypted string) error
+	EnableTOTP(ctx context.Context, userID int, step int64) error
+	UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error)
+	UpdateTOTPSecret(ctx context.Context, userID int, oldSecretEncrypted string, secretEncrypted string) error
+	DeleteTOTP(ctx context.Context, userID int) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID int) (int, error)
+}
+
+// twoFactorRepository는 TwoFactorRepository 인터페이스를 구현하는 구조체
+type twoFactorRepository struct {
+	db Executor
+}
+
+// NewTwoFactorRepository는 TwoFactorRepository의 인스턴스를 생성하는 함수
+func NewTwoFactorRepository(db Executor) TwoFactorRepository {
+	return &twoFactorRepository{
+		db: db,
+	}
+}
+
+// GetTOTP는 사용자의 TOTP 설정을 조회하는 메서드, 없으면 nil을 반환
+func (r *twoFactorRepository) GetTOTP(ctx context.Context, userID int) (*model.UserTOTP, error) {
+	var (
+		totp      model.UserTOTP
+		enabledAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, GET_TOTP_QUERY, userID).Scan(&totp.UserID, &totp.SecretEncrypted, &totp.LastUsedStep, &enabledAt, &totp.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if enabledAt.Valid {
+		totp.EnabledAt = &enabledAt.Time
+	}
+	return &totp, nil
+}
+
+// CreatePendingTOTP는 확인 전 상태의 TOTP secret을 저장하는 메서드, 기존 설정은 교체함
+func (r *twoFactorRepository) CreatePendingTOTP(ctx context.Context, userID int, secretEncrypted string) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		if _, err := tx.ExecContext(ctx, DELETE_TOTP_QUERY, userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, INSERT_TOTP_QUERY, userID, secretEncrypted, time.Now().UTC())
+		return err
+	})
+}
+
+// EnableTOTP는 확인 전 상태의 TOTP를 켜고 확인에 사용한 시간 단계를 기록하는 메서드
+// 등록 대기 중인 TOTP가 없으면 ErrTOTPNotPending을 반환
+func (r *twoFactorRepository) EnableTOTP(ctx context.Context, userID int, step int64) error {
+	result, err := r.db.ExecContext(ctx, ENABLE_TOTP_QUERY, userID, time.Now().UTC(), step)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTOTPNotPending
+	}
+	return nil
+}
+
+// UseTOTPStep은 TOTP 코드의 시간 단계를 사용 처리하는 메서드
+// 이미 같거나 더 늦은 단계를 사용했으면(코드 재사용) false를 반환
+func (r *twoFactorRepository) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, USE_TOTP_STEP_QUERY, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// UpdateTOTPSecret은 암호화 키를 교체한 뒤 TOTP secret을 새 키로 다시 암호화해 저장하는 메서드
+// 그 사이 다시 등록해 secret이 바뀌었으면 아무것도 바꾸지 않음
+func (r *twoFactorRepository) UpdateTOTPSecret(ctx context.Context, userID int, oldSecretEncrypted string, secretEncrypted string) error {
+	_, err := r.db.ExecContext(ctx, UPDATE_TOTP_SECRET_QUERY, userID, oldSecretEncrypted, secretEncrypted)
+	return err
+}
+
+// DeleteTOTP는 사용자의 TOTP 설정과 복구 코드를 삭제하는 메서드
+func (r *twoFactorRepository) DeleteTOTP(ctx context.Context, userID int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		if _, err := tx.ExecContext(ctx, DELETE_RECOVERY_CODES_QUERY, userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, DELETE_TOTP_QUERY, userID)
+		return err
+	})
+}
+
+// ReplaceRecoveryCodes는 사용자의 복구 코드를 새 코드 해시로 모두 교체하는 메서드
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		if _, err := tx.ExecContext(ctx, DELETE_RECOVERY_CODES_QUERY, userID); err != nil {
+			return err
+		}
+		for _, codeHash := range codeHashes {
+			if _, err := tx.ExecContext(ctx, INSERT_RECOVERY_CODE_QUERY, userID, codeHash); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UseRecoveryCode는 복구 코드를 사용 처리하는 메서드, 없거나 이미 사용한 코드면 false를 반환
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, USE_RECOVERY_CODE_QUERY, userID, codeHash, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// CountRecoveryCodes는 사용하지 않은 복구 코드 수를 조회하는 메서드
+func (r *twoFactorRepository) CountRecoveryCodes(ctx context.Context, userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, COUNT_UNUSED_RECOVERY_CODES_QUERY, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	// 초대 조회 컬럼, 이메일로 초대했으면 가입 여부를 드러내지 않도록 초대 받은 사용자의 ID와 이름을 비움
+	// (i: workspace_invitations, w: workspaces, inviter / invitee: users)
+	INVITATION_COLUMNS = "i.id, i.workspace_id, w.name, i.inviter_id, COALESCE(inviter.name, ''), " +
+		"CASE WHEN i.email IS NULL THEN i.invitee_user_id END, CASE WHEN i.email IS NULL THEN COALESCE(invitee.name, '') ELSE '' END, " +
+		"COALESCE(i.email, ''), i.role, i.status, i.expires_at, i.created_at, i.responded_at, i.invitee_user_id"
+	INVITATION_FROM = " FROM workspace_invitations i JOIN workspaces w ON w.id = i.workspace_id " +
+		"LEFT JOIN users inviter ON inviter.id = i.inviter_id LEFT JOIN users invitee ON invitee.id = i.invitee_user_id"
+	// 요청 사용자가 초대 받은 사람인지 확인하는 조건 ($1: 사용자 ID), 이메일 초대는 같은 이메일을 인증한 사용자도 포함
+	INVITATION_INVITEE_CONDITION = "(i.invitee_user_id = $1 OR (i.invitee_user_id IS NULL AND i.email = (SELECT email FROM users WHERE id = $1 AND email_verified_at IS NOT NULL)))"
+
+	GET_INVITATION_QUERY            = "SELECT " + INVITATION_COLUMNS + INVITATION_FROM + " WHERE i.id = $1"
+	GET_WORKSPACE_INVITATIONS_QUERY = "SELECT " + INVITATION_COLUMNS + INVITATION_FROM + " WHERE i.workspace_id = $2 AND i.status = 'pending' AND i.expires_at > $3 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = i.workspace_id AND wm.user_id = $1 AND wm.role IN ('owner', 'admin')) ORDER BY i.created_at DESC, i.id DESC"
+	GET_PENDING_INVITATIONS_QUERY   = "SELECT " + INVITATION_COLUMNS + INVITATION_FROM + " WHERE " + INVITATION_INVITEE_CONDITION + " AND i.status = 'pending' AND i.expires_at > $2 ORDER BY i.created_at DESC, i.id DESC"
+	COUNT_PENDING_INVITATIONS_QUERY = "SELECT COUNT(*) FROM workspace_invitations WHERE workspace_id = $1 AND status = 'pending' AND expires_at > $2"
+	// 초대하는 사용자가 공유 작업 공간의 owner / admin일 때만 추가
+	INSERT_INVITATION_QUERY = "INSERT INTO workspace_invitations (workspace_id, inviter_id, invitee_user_id, email, role, status, expires_at, created_at) " +
+		"SELECT w.id, $2, $3, $4, $5, 'pending', $6, $7 FROM workspaces w WHERE w.id = $1 AND w.personal_user_id IS NULL " +
+		"AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = w.id AND wm.user_id = $2 AND wm.role IN ('owner', 'admin')) RETURNING id"
+	// 같은 사람에게 다시 초대하면 새 초대($5)를 제외한 이전 초대는 취소 됨 (이전 링크는 더 이상 사용할 수 없음)
+	SUPERSEDE_INVITATIONS_QUERY = "UPDATE workspace_invitations SET status = 'revoked', responded_at = $4 WHERE workspace_id = $1 AND status = 'pending' AND (invitee_user_id = $2 OR email = $3) AND id <> $5"
+	REVOKE_INVITATION_QUERY     = "UPDATE workspace_invitations SET status = 'revoked', responded_at = $4 WHERE id = $3 AND workspace_id = $2 AND status = 'pending' AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspace_invitations.workspace_id AND wm.user_id = $1 AND wm.role IN ('owner', 'admin'))"
+	LOCK_INVITATION_QUERY       = "SELECT workspace_id, invitee_user_id, COALESCE(email, ''), role, status, expires_at FROM workspace_invitations WHERE id = $1 FOR UPDATE"
+	GET_USER_EMAIL_QUERY        = "SELECT COALESCE(email, ''), email_verified_at FROM users WHERE id = $1"
+	RESPOND_INVITATION_QUERY    = "UPDATE workspace_invitations SET status = $2, invitee_user_id = $3, responded_at = $4 WHERE id = $1 AND status = 'pending'"
+)
+
+var (
+	// ErrInvitationNotForUser는 다른 사용자에게 보낸 초대에 응답하려 할 때 반환되는 에러
+	ErrInvitationNotForUser = errors.New("invitation was sent to a different account")
+	// ErrInvitationNotPending은 이미 수락 / 거절 / 취소 된 초대에 응답하려 할 때 반환되는 에러
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	// ErrInvitationExpired는 만료 된 초대에 응답하려 할 때 반환되는 에러
+	ErrInvitationExpired = errors.New("invitation has expired")
+)
+
+// WorkspaceInvitationRepository는 작업 공간 초대 관련 데이터베이스 작업을 정의하는 인터페이스
+// 초대 목록과 생성 / 취소는 owner / admin인지, 응답은 초대 받은 사용자인지를 쿼리 안에서 확인함
+type WorkspaceInvitationRepository interface {
+	GetWorkspaceInvitations(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceInvitation, error)
+	GetPendingInvitations(ctx context.Context, userID int) ([]model.WorkspaceInvitation, error)
+	CountPendingInvitations(ctx context.Context, workspaceID int) (int, error)
+	CreateInvitation(ctx context.Context, userID int, invitation *model.WorkspaceInvitation) (*model.WorkspaceInvitation, error)
+	RevokeInvitation(ctx context.Context, userID int, workspaceID int, invitationID int) (*model.WorkspaceInvitation, error)
+	RespondInvitation(ctx context.Context, userID int, invitationID int, accept bool, signedLink bool) (*model.WorkspaceInvitation, error)
+}
+
+// workspaceInvitationRepository는 WorkspaceInvitationRepository 인터페이스를 구현하는 구조체
+type workspaceInvitationRepository struct {
+	db Executor
+}
+
+// NewWorkspaceInvitationRepository는 WorkspaceInvitationRepository의 인스턴스를 생성하는 함수
+func NewWorkspaceInvitationRepository(db Executor) WorkspaceInvitationRepository {
+	return &workspaceInvitationRepository{
+		db: db,
+	}
+}
+
+// GetWorkspaceInvitations는 작업 공간의 대기 중인 초대 목록을 조회하는 메서드, owner / admin이 아니면 빈 목록을 반환
+func (r *workspaceInvitationRepository) GetWorkspaceInvitations(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceInvitation, error) {
+	return queryInvitations(ctx, r.db, GET_WORKSPACE_INVITATIONS_QUERY, userID, workspaceID, time.Now().UTC())
+}
+
+// GetPendingInvitations는 사용자가 받은 대기 중인 초대 목록을 조회하는 메서드
+func (r *workspaceInvitationRepository) GetPendingInvitations(ctx context.Context, userID int) ([]model.WorkspaceInvitation, error) {
+	return queryInvitations(ctx, r.db, GET_PENDING_INVITATIONS_QUERY, userID, time.Now().UTC())
+}
+
+// CountPendingInvitations는 작업 공간의 대기 중인 초대 수를 조회하는 메서드
+func (r *workspaceInvitationRepository) CountPendingInvitations(ctx context.Context, workspaceID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, COUNT_PENDING_INVITATIONS_QUERY, workspaceID, time.Now().UTC()).Scan(&count)
+	return count, err
+}
+
+// CreateInvitation은 작업 공간 초대를 만드는 메서드, 같은 사람에게 보낸 대기 중인 초대는 취소함
+// 초대하는 사용자가 owner / admin이 아니거나 개인 작업 공간이면 ErrWorkspaceAccessDenied를 반환
+func (r *workspaceInvitationRepository) CreateInvitation(ctx context.Context, userID int, invitation *model.WorkspaceInvitation) (created *model.WorkspaceInvitation, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		now := time.Now().UTC()
+		var (
+			inviteeUserID sql.NullInt64
+			email         sql.NullString
+		)
+		if invitation.InviteeUserID != nil {
+			inviteeUserID = sql.NullInt64{Int64: int64(*invitation.InviteeUserID), Valid: true}
+		}
+		if invitation.Email != "" {
+			email = sql.NullString{String: invitation.Email, Valid: true}
+		}
+
+		var invitationID int
+		err := tx.QueryRowContext(ctx, INSERT_INVITATION_QUERY, invitation.WorkspaceID, userID, inviteeUserID, email, invitation.Role, invitation.ExpiresAt.UTC(), now).Scan(&invitationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWorkspaceAccessDenied
+			}
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, SUPERSEDE_INVITATIONS_QUERY, invitation.WorkspaceID, inviteeUserID, email, now, invitationID); err != nil {
+			return err
+		}
+
+		created, err = scanInvitation(tx.QueryRowContext(ctx, GET_INVITATION_QUERY, invitationID))
+		return err
+	})
+	return created, err
+}
+
+// RevokeInvitation은 대기 중인 초대를 취소하는 메서드
+// 초대가 없거나 이미 응답했거나 요청 사용자가 owner / admin이 아니면 sql.ErrNoRows를 반환
+func (r *workspaceInvitationRepository) RevokeInvitation(ctx context.Context, userID int, workspaceID int, invitationID int) (revoked *model.WorkspaceInvitation, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		result, err := tx.ExecContext(ctx, REVOKE_INVITATION_QUERY, userID, workspaceID, invitationID, time.Now().UTC())
+		if err := expectAffected(result, err, sql.ErrNoRows); err != nil {
+			return err
+		}
+		revoked, err = scanInvitation(tx.QueryRowContext(ctx, GET_INVITATION_QUERY, invitationID))
+		return err
+	})
+	return revoked, err
+}
+
+// RespondInvitation은 초대를 수락하거나 거절하는 메서드, 수락하면 초대의 역할로 작업 공간에 추가함
+// 이미 구성원이면 역할을 바꾸지 않고 수락 처리함
+// 이메일 초대는 같은 이메일을 인증한 사용자만 응답할 수 있으며, 서명 된 초대 링크로 응답하면(signedLink) 인증하지 않은 이메일도 허용
+// 초대가 없으면 sql.ErrNoRows, 초대 받은 사용자가 아니면 ErrInvitationNotForUser,
+// 이미 응답했으면 ErrInvitationNotPending, 만료 되었으면 ErrInvitationExpired를 반환
+func (r *workspaceInvitationRepository) RespondInvitation(ctx context.Context, userID int, invitationID int, accept bool, signedLink bool) (responded *model.WorkspaceInvitation, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		var (
+			workspaceID   int
+			inviteeUserID sql.NullInt64
+			email         string
+			role          string
+			status        string
+			expiresAt     time.Time
+		)
+		if err := tx.QueryRowContext(ctx, LOCK_INVITATION_QUERY, invitationID).Scan(&workspaceID, &inviteeUserID, &email, &role, &status, &expiresAt); err != nil {
+			return err
+		}
+
+		var (
+			userEmail       string
+			emailVerifiedAt sql.NullTime
+		)
+		if err := tx.QueryRowContext(ctx, GET_USER_EMAIL_QUERY, userID).Scan(&userEmail, &emailVerifiedAt); err != nil {
+			return err
+		}
+		if inviteeUserID.Valid {
+			if int(inviteeUserID.Int64) != userID {
+				return ErrInvitationNotForUser
+			}
+		} else if email == "" || email != userEmail || (!emailVerifiedAt.Valid && !signedLink) {
+			return ErrInvitationNotForUser
+		}
+
+		now := time.Now().UTC()
+		if status != model.INVITATION_STATUS_PENDING {
+			return ErrInvitationNotPending
+		}
+		if !now.Before(expiresAt) {
+			return ErrInvitationExpired
+		}
+
+		status = model.INVITATION_STATUS_DECLINED
+		if accept {
+			status = model.INVITATION_STATUS_ACCEPTED
+			err := NewWorkspaceRepository(tx).AddMember(ctx, workspaceID, userID, role)
+			if err != nil && !errors.Is(err, ErrWorkspaceMemberExists) {
+				return err
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, RESPOND_INVITATION_QUERY, invitationID, status, userID, now)
+		if err := expectAffected(result, err, ErrInvitationNotPending); err != nil {
+			return err
+		}
+		responded, err = scanInvitation(tx.QueryRowContext(ctx, GET_INVITATION_QUERY, invitationID))
+		return err
+	})
+	return responded, err
+}
+
+// queryInvitations는 INVITATION_COLUMNS를 조회하는 쿼리의 결과를 모두 읽는 함수
+func queryInvitations(ctx context.Context, db Executor, query string, args ...any) ([]model.WorkspaceInvitation, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []model.WorkspaceInvitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// scanInvitation은 INVITATION_COLUMNS 순서의 행을 초대로 읽는 함수, 대기 중이지만 만료 된 초대는 expired로 표시
+func scanInvitation(row rowScanner) (*model.WorkspaceInvitation, error) {
+	var (
+		invitation    model.WorkspaceInvitation
+		inviterID     sql.NullInt64
+		inviteeUserID sql.NullInt64
+		respondedAt   sql.NullTime
+		recipientID   sql.NullInt64
+	)
+	if err := row.Scan(&invitation.ID, &invitation.WorkspaceID, &invitation.WorkspaceName, &inviterID, &invitation.InviterName,
+		&inviteeUserID, &invitation.InviteeName, &invitation.Email, &invitation.Role, &invitation.Status,
+		&invitation.ExpiresAt, &invitation.CreatedAt, &respondedAt, &recipientID); err != nil {
+		return nil, err
+	}
+	if inviterID.Valid {
+		id := int(inviterID.Int64)
+		invitation.InviterID = &id
+	}
+	if inviteeUserID.Valid {
+		id := int(inviteeUserID.Int64)
+		invitation.InviteeUserID = &id
+	}
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+	if recipientID.Valid {
+		id := int(recipientID.Int64)
+		invitation.RecipientUserID = &id
+	}
+	invitation.ResolveStatus(time.Now())
+	return &invitation, nil
+}
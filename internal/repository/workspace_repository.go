@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+const (
+	// 요청 사용자의 역할과 구성원 수를 포함한 작업 공간 조회 컬럼 (w: workspaces, wm: 요청 사용자의 workspace_members)
+	WORKSPACE_COLUMNS                   = "w.id, w.name, w.personal_user_id IS NOT NULL, wm.role, (SELECT COUNT(*) FROM workspace_members c WHERE c.workspace_id = w.id), w.created_at, w.updated_at"
+	GET_WORKSPACES_QUERY                = "SELECT " + WORKSPACE_COLUMNS + " FROM workspaces w JOIN workspace_members wm ON wm.workspace_id = w.id WHERE wm.user_id = $1 ORDER BY w.personal_user_id IS NULL, w.id"
+	GET_WORKSPACE_QUERY                 = "SELECT " + WORKSPACE_COLUMNS + " FROM workspaces w JOIN workspace_members wm ON wm.workspace_id = w.id WHERE wm.user_id = $1 AND w.id = $2"
+	GET_PERSONAL_WORKSPACE_QUERY        = "SELECT " + WORKSPACE_COLUMNS + " FROM workspaces w JOIN workspace_members wm ON wm.workspace_id = w.id WHERE wm.user_id = $1 AND w.personal_user_id = $1"
+	INSERT_WORKSPACE_QUERY              = "INSERT INTO workspaces (name, personal_user_id, created_at, updated_at) VALUES ($1, $2, $3, $3) RETURNING id"
+	INSERT_WORKSPACE_MEMBER_QUERY       = "INSERT INTO workspace_members (workspace_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)"
+	ADD_WORKSPACE_MEMBER_QUERY          = "INSERT INTO workspace_members (workspace_id, user_id, role, created_at) SELECT id, $2, $3, $4 FROM workspaces WHERE id = $1 AND personal_user_id IS NULL"
+	EXIST_WORKSPACE_MEMBER_QUERY        = "SELECT EXISTS(SELECT 1 FROM workspace_members WHERE workspace_id = $1 AND user_id = $2)"
+	COUNT_OWNED_WORKSPACES_QUERY        = "SELECT COUNT(*) FROM workspace_members wm JOIN workspaces w ON w.id = wm.workspace_id WHERE wm.user_id = $1 AND wm.role = 'owner' AND w.personal_user_id IS NULL"
+	UPDATE_WORKSPACE_QUERY              = "UPDATE workspaces SET name = $3, updated_at = $4 WHERE id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = $1 AND wm.role IN ('owner', 'admin'))"
+	DELETE_WORKSPACE_QUERY              = "DELETE FROM workspaces WHERE id = $2 AND personal_user_id IS NULL AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspaces.id AND wm.user_id = $1 AND wm.role = 'owner')"
+	WORKSPACE_MEMBER_COLUMNS            = "m.workspace_id, m.user_id, u.name, m.role, m.created_at"
+	GET_WORKSPACE_MEMBERS_QUERY         = "SELECT " + WORKSPACE_MEMBER_COLUMNS + " FROM workspace_members m JOIN users u ON u.id = m.user_id WHERE m.workspace_id = $2 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = m.workspace_id AND wm.user_id = $1) ORDER BY m.created_at, m.user_id"
+	GET_WORKSPACE_MEMBER_QUERY          = "SELECT " + WORKSPACE_MEMBER_COLUMNS + " FROM workspace_members m JOIN users u ON u.id = m.user_id WHERE m.workspace_id = $2 AND m.user_id = $3 AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = m.workspace_id AND wm.user_id = $1)"
+	UPDATE_WORKSPACE_MEMBER_ROLE_QUERY  = "UPDATE workspace_members SET role = $4 WHERE workspace_id = $2 AND user_id = $3 AND role <> 'owner' AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspace_members.workspace_id AND wm.user_id = $1 AND wm.role IN ('owner', 'admin'))"
+	DEMOTE_WORKSPACE_OWNER_QUERY        = "UPDATE workspace_members SET role = 'admin' WHERE workspace_id = $1 AND user_id = $2 AND role = 'owner'"
+	PROMOTE_WORKSPACE_OWNER_QUERY       = "UPDATE workspace_members SET role = 'owner' WHERE workspace_id = $1 AND user_id = $2 AND role <> 'owner'"
+	REMOVE_WORKSPACE_MEMBER_QUERY       = "DELETE FROM workspace_members WHERE workspace_id = $2 AND user_id = $3 AND role <> 'owner' AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = workspace_members.workspace_id AND wm.user_id = $1 AND wm.role IN ('owner', 'admin'))"
+	LEAVE_WORKSPACE_QUERY               = "DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2 AND role <> 'owner'"
+	GET_OWNED_SHARED_WORKSPACES_QUERY   = "SELECT w.id FROM workspaces w JOIN workspace_members wm ON wm.workspace_id = w.id WHERE wm.user_id = $1 AND wm.role = 'owner' AND w.personal_user_id IS NULL ORDER BY w.id"
+	GET_WORKSPACE_SUCCESSOR_QUERY       = "SELECT user_id FROM workspace_members WHERE workspace_id = $1 AND user_id <> $2 ORDER BY CASE role WHEN 'admin' THEN 0 WHEN 'member' THEN 1 ELSE 2 END, created_at, user_id LIMIT 1"
+	DELETE_ORPHAN_WORKSPACE_QUERY       = "DELETE FROM workspaces WHERE id = $1"
+	REASSIGN_WORKSPACE_TASKS_QUERY      = "UPDATE tasks SET user_id = (SELECT wm.user_id FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.role = 'owner') WHERE user_id = $1 AND workspace_id IN (SELECT id FROM workspaces WHERE personal_user_id IS NULL)"
+	REASSIGN_WORKSPACE_TAGS_QUERY       = "UPDATE tags SET user_id = (SELECT wm.user_id FROM workspace_members wm WHERE wm.workspace_id = tags.workspace_id AND wm.role = 'owner') WHERE user_id = $1 AND workspace_id IN (SELECT id FROM workspaces WHERE personal_user_id IS NULL)"
+	REASSIGN_WORKSPACE_TOMBSTONES_QUERY = "UPDATE sync_tombstones SET user_id = (SELECT wm.user_id FROM workspace_members wm WHERE wm.workspace_id = sync_tombstones.workspace_id AND wm.role = 'owner') WHERE user_id = $1 AND workspace_id IN (SELECT id FROM workspaces WHERE personal_user_id IS NULL)"
+)
+
+var (
+	// ErrWorkspaceAccessDenied는 작업 공간의 구성원이 아니거나 역할에 필요한 권한이 없을 때 반환되는 에러
+	ErrWorkspaceAccessDenied = errors.New("workspace access denied")
+	// ErrWorkspaceMemberExists는 이미 구성원인 사용자를 추가하려 할 때 반환되는 에러
+	ErrWorkspaceMemberExists = errors.New("user is already a member of the workspace")
+)
+
+// WorkspaceRepository는 작업 공간과 구성원 관련 데이터베이스 작업을 정의하는 인터페이스
+// 모든 조회와 수정은 요청 사용자(userID)가 구성원인지, 필요한 역할인지를 쿼리 안에서 확인함
+type WorkspaceRepository interface {
+	GetWorkspaces(ctx context.Context, userID int) ([]model.Workspace, error)
+	GetWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, error)
+	GetPersonalWorkspace(ctx context.Context, userID int) (*model.Workspace, error)
+	CountOwnedWorkspaces(ctx context.Context, userID int) (int, error)
+	CreateWorkspace(ctx context.Context, userID int, name string) (*model.Workspace, error)
+	UpdateWorkspace(ctx context.Context, userID int, workspaceID int, name string) error
+	DeleteWorkspace(ctx context.Context, userID int, workspaceID int) error
+	GetMembers(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceMember, error)
+	GetMember(ctx context.Context, userID int, workspaceID int, memberUserID int) (*model.WorkspaceMember, error)
+	AddMember(ctx context.Context, workspaceID int, memberUserID int, role string) error
+	UpdateMemberRole(ctx context.Context, userID int, workspaceID int, memberUserID int, role string) error
+	TransferOwnership(ctx context.Context, userID int, workspaceID int, memberUserID int) error
+	RemoveMember(ctx context.Context, userID int, workspaceID int, memberUserID int) error
+	LeaveWorkspace(ctx context.Context, userID int, workspaceID int) error
+	HandOverWorkspaces(ctx context.Context, userID int) error
+}
+
+// workspaceRepository는 WorkspaceRepository 인터페이스를 구현하는 구조체
+type workspaceRepository struct {
+	db Executor
+}
+
+// NewWorkspaceRepository는 WorkspaceRepository의 인스턴스를 생성하는 함수
+func NewWorkspaceRepository(db Executor) WorkspaceRepository {
+	return &workspaceRepository{
+		db: db,
+	}
+}
+
+// GetWorkspaces는 사용자가 구성원인 모든 작업 공간을 조회하는 메서드 (개인 작업 공간이 먼저)
+func (r *workspaceRepository) GetWorkspaces(ctx context.Context, userID int) ([]model.Workspace, error) {
+	rows, err := r.db.QueryContext(ctx, GET_WORKSPACES_QUERY, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []model.Workspace{}
+	for rows.Next() {
+		workspace, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, *workspace)
+	}
+	return workspaces, rows.Err()
+}
+
+// GetWorkspace는 사용자가 구성원인 작업 공간을 역할과 함께 조회하는 메서드, 구성원이 아니면 sql.ErrNoRows를 반환
+func (r *workspaceRepository) GetWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, error) {
+	return scanWorkspace(r.db.QueryRowContext(ctx, GET_WORKSPACE_QUERY, userID, workspaceID))
+}
+
+// GetPersonalWorkspace는 사용자의 개인 작업 공간을 조회하는 메서드
+func (r *workspaceRepository) GetPersonalWorkspace(ctx context.Context, userID int) (*model.Workspace, error) {
+	return scanWorkspace(r.db.QueryRowContext(ctx, GET_PERSONAL_WORKSPACE_QUERY, userID))
+}
+
+// CountOwnedWorkspaces는 사용자가 owner인 작업 공간 수를 조회하는 메서드 (개인 작업 공간 제외)
+func (r *workspaceRepository) CountOwnedWorkspaces(ctx context.Context, userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, COUNT_OWNED_WORKSPACES_QUERY, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateWorkspace는 작업 공간을 만들고 요청 사용자를 owner로 추가하는 메서드
+func (r *workspaceRepository) CreateWorkspace(ctx context.Context, userID int, name string) (workspace *model.Workspace, err error) {
+	err = runInTx(ctx, r.db, func(tx Executor) error {
+		workspaceID, err := insertWorkspaceTx(ctx, tx, userID, name, false)
+		if err != nil {
+			return err
+		}
+		workspace, err = scanWorkspace(tx.QueryRowContext(ctx, GET_WORKSPACE_QUERY, userID, workspaceID))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// UpdateWorkspace는 작업 공간 이름을 바꾸는 메서드, owner / admin이 아니면 ErrWorkspaceAccessDenied를 반환
+func (r *workspaceRepository) UpdateWorkspace(ctx context.Context, userID int, workspaceID int, name string) error {
+	result, err := r.db.ExecContext(ctx, UPDATE_WORKSPACE_QUERY, userID, workspaceID, name, time.Now().UTC())
+	return expectAffected(result, err, ErrWorkspaceAccessDenied)
+}
+
+// DeleteWorkspace는 작업 공간과 작업 공간의 모든 작업, 태그를 삭제하는 메서드
+// owner가 아니거나 개인 작업 공간이면 ErrWorkspaceAccessDenied를 반환
+func (r *workspaceRepository) DeleteWorkspace(ctx context.Context, userID int, workspaceID int) error {
+	result, err := r.db.ExecContext(ctx, DELETE_WORKSPACE_QUERY, userID, workspaceID)
+	return expectAffected(result, err, ErrWorkspaceAccessDenied)
+}
+
+// GetMembers는 작업 공간의 구성원을 가입한 순서로 조회하는 메서드, 요청 사용자가 구성원이 아니면 빈 목록을 반환
+func (r *workspaceRepository) GetMembers(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceMember, error) {
+	rows, err := r.db.QueryContext(ctx, GET_WORKSPACE_MEMBERS_QUERY, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []model.WorkspaceMember{}
+	for rows.Next() {
+		member, err := scanWorkspaceMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *member)
+	}
+	return members, rows.Err()
+}
+
+// GetMember는 작업 공간의 구성원 한 명을 조회하는 메서드, 없으면 sql.ErrNoRows를 반환
+func (r *workspaceRepository) GetMember(ctx context.Context, userID int, workspaceID int, memberUserID int) (*model.WorkspaceMember, error) {
+	return scanWorkspaceMember(r.db.QueryRowContext(ctx, GET_WORKSPACE_MEMBER_QUERY, userID, workspaceID, memberUserID))
+}
+
+// AddMember는 공유 작업 공간에 구성원을 추가하는 메서드, 추가할 권한은 호출하는 쪽에서 확인해야 함
+// 개인 작업 공간이거나 작업 공간이 없으면 ErrWorkspaceAccessDenied, 이미 구성원이면 ErrWorkspaceMemberExists를 반환
+func (r *workspaceRepository) AddMember(ctx context.Context, workspaceID int, memberUserID int, role string) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, EXIST_WORKSPACE_MEMBER_QUERY, workspaceID, memberUserID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return ErrWorkspaceMemberExists
+		}
+		result, err := tx.ExecContext(ctx, ADD_WORKSPACE_MEMBER_QUERY, workspaceID, memberUserID, role, time.Now().UTC())
+		return expectAffected(result, err, ErrWorkspaceAccessDenied)
+	})
+}
+
+// UpdateMemberRole은 구성원의 역할을 바꾸는 메서드, owner의 역할은 바꿀 수 없음
+// 요청 사용자가 owner / admin이 아니거나 대상이 owner이면 ErrWorkspaceAccessDenied를 반환
+func (r *workspaceRepository) UpdateMemberRole(ctx context.Context, userID int, workspaceID int, memberUserID int, role string) error {
+	result, err := r.db.ExecContext(ctx, UPDATE_WORKSPACE_MEMBER_ROLE_QUERY, userID, workspaceID, memberUserID, role)
+	return expectAffected(result, err, ErrWorkspaceAccessDenied)
+}
+
+// TransferOwnership은 작업 공간의 소유권을 다른 구성원에게 넘기는 메서드, 기존 owner는 admin이 됨
+// 요청 사용자가 owner가 아니면 ErrWorkspaceAccessDenied, 대상이 구성원이 아니면 sql.ErrNoRows를 반환
+func (r *workspaceRepository) TransferOwnership(ctx context.Context, userID int, workspaceID int, memberUserID int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		result, err := tx.ExecContext(ctx, DEMOTE_WORKSPACE_OWNER_QUERY, workspaceID, userID)
+		if err := expectAffected(result, err, ErrWorkspaceAccessDenied); err != nil {
+			return err
+		}
+		result, err = tx.ExecContext(ctx, PROMOTE_WORKSPACE_OWNER_QUERY, workspaceID, memberUserID)
+		return expectAffected(result, err, sql.ErrNoRows)
+	})
+}
+
+// RemoveMember는 구성원을 작업 공간에서 내보내는 메서드, owner는 내보낼 수 없음
+// 요청 사용자가 owner / admin이 아니거나 대상이 owner이면 ErrWorkspaceAccessDenied를 반환
+func (r *workspaceRepository) RemoveMember(ctx context.Context, userID int, workspaceID int, memberUserID int) error {
+	result, err := r.db.ExecContext(ctx, REMOVE_WORKSPACE_MEMBER_QUERY, userID, workspaceID, memberUserID)
+	return expectAffected(result, err, ErrWorkspaceAccessDenied)
+}
+
+// LeaveWorkspace는 요청 사용자가 작업 공간에서 나가는 메서드, owner는 소유권을 넘기기 전에는 나갈 수 없음
+func (r *workspaceRepository) LeaveWorkspace(ctx context.Context, userID int, workspaceID int) error {
+	result, err := r.db.ExecContext(ctx, LEAVE_WORKSPACE_QUERY, workspaceID, userID)
+	return expectAffected(result, err, ErrWorkspaceAccessDenied)
+}
+
+// HandOverWorkspaces는 계정을 삭제하기 전에 사용자가 남긴 공유 작업 공간을 정리하는 메서드
+// owner인 작업 공간은 admin, member, viewer 순으로 가장 먼저 가입한 구성원에게 넘기고, 다른 구성원이 없으면 삭제함
+// 공유 작업 공간에 만든 작업, 태그, tombstone은 사용자와 함께 삭제 되지 않도록 작업 공간 owner의 것으로 바꿈
+// (개인 작업 공간은 사용자를 삭제할 때 외래 키로 함께 삭제 됨)
+func (r *workspaceRepository) HandOverWorkspaces(ctx context.Context, userID int) error {
+	return runInTx(ctx, r.db, func(tx Executor) error {
+		workspaceIDs, err := queryIDs(ctx, tx, GET_OWNED_SHARED_WORKSPACES_QUERY, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, workspaceID := range workspaceIDs {
+			var successorID int
+			err := tx.QueryRowContext(ctx, GET_WORKSPACE_SUCCESSOR_QUERY, workspaceID, userID).Scan(&successorID)
+			if errors.Is(err, sql.ErrNoRows) {
+				if _, err := tx.ExecContext(ctx, DELETE_ORPHAN_WORKSPACE_QUERY, workspaceID); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, DEMOTE_WORKSPACE_OWNER_QUERY, workspaceID, userID); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, PROMOTE_WORKSPACE_OWNER_QUERY, workspaceID, successorID); err != nil {
+				return err
+			}
+		}
+
+		for _, query := range []string{REASSIGN_WORKSPACE_TASKS_QUERY, REASSIGN_WORKSPACE_TAGS_QUERY, REASSIGN_WORKSPACE_TOMBSTONES_QUERY} {
+			if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertWorkspaceTx는 트랜잭션 안에서 작업 공간을 만들고 userID를 owner로 추가하는 함수
+// personal이면 사용자의 개인 작업 공간으로 만듦
+func insertWorkspaceTx(ctx context.Context, tx Executor, userID int, name string, personal bool) (int, error) {
+	now := time.Now().UTC()
+	var personalUserID sql.NullInt64
+	if personal {
+		personalUserID = sql.NullInt64{Int64: int64(userID), Valid: true}
+	}
+
+	var workspaceID int
+	if err := tx.QueryRowContext(ctx, INSERT_WORKSPACE_QUERY, name, personalUserID, now).Scan(&workspaceID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, INSERT_WORKSPACE_MEMBER_QUERY, workspaceID, userID, model.WORKSPACE_ROLE_OWNER, now); err != nil {
+		return 0, err
+	}
+	return workspaceID, nil
+}
+
+// expectAffected는 수정 / 삭제 쿼리가 행을 바꾸지 못했으면 errNone을 반환하는 함수
+func expectAffected(result sql.Result, err error, errNone error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errNone
+	}
+	return nil
+}
+
+// queryIDs는 ID 한 컬럼을 조회하는 쿼리의 결과를 모두 읽는 함수
+func queryIDs(ctx context.Context, tx Executor, query string, args ...any) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// scanWorkspace는 WORKSPACE_COLUMNS 순서의 행을 작업 공간으로 읽는 함수
+func scanWorkspace(row rowScanner) (*model.Workspace, error) {
+	var workspace model.Workspace
+	if err := row.Scan(&workspace.ID, &workspace.Name, &workspace.Personal, &workspace.Role, &workspace.MemberCount, &workspace.CreatedAt, &workspace.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// scanWorkspaceMember는 WORKSPACE_MEMBER_COLUMNS 순서의 행을 구성원으로 읽는 함수
+func scanWorkspaceMember(row rowScanner) (*model.WorkspaceMember, error) {
+	var member model.WorkspaceMember
+	if err := row.Scan(&member.WorkspaceID, &member.UserID, &member.Name, &member.Role, &member.JoinedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
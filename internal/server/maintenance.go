@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"lux-list/internal/service"
+	"lux-list/pkg/redis"
+)
+
+// runAccountMaintenance는 interval마다 삭제 유예 기간이 지난 계정과 만료 된 내보내기 파일을 정리하는 함수
+// ctx가 취소될 때까지 실행되며, 여러 인스턴스에서 실행해도 같은 계정을 두 번 삭제하지 않음
+func runAccountMaintenance(ctx context.Context, accountService service.AccountService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		cleanupAccounts(ctx, accountService)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanupAccounts는 삭제 예정 시각이 지난 계정을 삭제하고 세션을 해제한 뒤, 만료 된 내보내기 파일을 삭제하는 함수
+func cleanupAccounts(ctx context.Context, accountService service.AccountService) {
+	purged, err := accountService.PurgeDueAccounts(ctx)
+	for _, userID := range purged {
+		// 유예 기간 중에 다시 로그인한 세션도 함께 해제
+		if _, err := redis.DeleteAuthSessions(ctx, userID, ""); err != nil {
+			log.Printf("Failed to delete sessions of deleted user %d: %v", userID, err)
+		}
+	}
+	if len(purged) > 0 {
+		log.Printf("Deleted %d account(s) scheduled for deletion", len(purged))
+	}
+	if err != nil {
+		log.Printf("Account deletion failed: %v", err)
+	}
+
+	if _, err := accountService.DeleteExpiredExports(ctx); err != nil {
+		log.Printf("Failed to delete expired data exports: %v", err)
+	}
+}
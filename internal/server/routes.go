@@ -1,9 +1,12 @@
 package server
 
 import (
+	"lux-list/internal/config"
 	"lux-list/internal/controller"
 	"lux-list/internal/database"
 	"lux-list/internal/middleware"
+	"lux-list/internal/model"
+	"lux-list/internal/realtime"
 	"lux-list/internal/repository"
 	"lux-list/internal/service"
 
@@ -11,39 +14,107 @@ import (
 )
 
 var (
-	db = database.GetDB()
-
-	authRepository    = repository.NewAuthRepository(db)
-	authService       = service.NewAuthService(authRepository)
-	taskRepository    = repository.NewTaskRepository(db)
-	taskService       = service.NewTaskService(taskRepository)
-	tagRepository     = repository.NewTagRepository(db)
-	tagService        = service.NewTagService(tagRepository)
-	taskTagRepository = repository.NewTaskTagRepository(db)
-	taskTagService    = service.NewTaskTagService(taskTagRepository)
-
-	authController = controller.NewAuthController(authService)
-	taskController = controller.NewTaskController(taskService, taskTagService)
-	tagController  = controller.NewTagController(tagService)
+	db        = repository.NewDB(database.GetDB(), database.Dialect())
+	txManager = repository.NewTxManager(db)
+
+	authRepository        = repository.NewAuthRepository(db)
+	twoFactorRepository   = repository.NewTwoFactorRepository(db)
+	authService           = service.NewAuthService(authRepository, twoFactorRepository, txManager, config.GetConfig().Auth, service.NewLogPasswordResetSender(), service.NewLogEmailVerificationSender())
+	twoFactorService      = service.NewTwoFactorService(twoFactorRepository, authRepository, txManager, config.GetConfig().Auth)
+	preferencesRepository = repository.NewPreferencesRepository(db)
+	taskRepository        = repository.NewTaskRepository(db)
+	taskService           = service.NewTaskService(taskRepository, preferencesRepository, txManager)
+	tagRepository         = repository.NewTagRepository(db)
+	tagService            = service.NewTagService(tagRepository)
+	taskTagRepository     = repository.NewTaskTagRepository(db)
+	taskTagService        = service.NewTaskTagService(taskTagRepository)
+	syncRepository        = repository.NewSyncRepository(db)
+	syncService           = service.NewSyncService(syncRepository)
+	tokenRepository       = repository.NewTokenRepository(db)
+	tokenService          = service.NewTokenService(tokenRepository)
+	identityRepository    = repository.NewIdentityRepository(db)
+	oidcService           = service.NewOIDCService(txManager, identityRepository, authRepository, twoFactorRepository, config.GetConfig().OIDC, config.GetConfig().Auth)
+	adminRepository       = repository.NewAdminRepository(db)
+	adminService          = service.NewAdminService(adminRepository, authRepository, txManager, config.GetConfig().Auth)
+	accountRepository     = repository.NewAccountRepository(db)
+	accountService        = service.NewAccountService(accountRepository, authRepository, twoFactorRepository, txManager, config.GetConfig().Account, config.GetConfig().Auth)
+	preferencesService    = service.NewPreferencesService(preferencesRepository)
+	workspaceRepository   = repository.NewWorkspaceRepository(db)
+	workspaceService      = service.NewWorkspaceService(workspaceRepository)
+	invitationRepository  = repository.NewWorkspaceInvitationRepository(db)
+	invitationService     = service.NewWorkspaceInvitationService(invitationRepository, workspaceRepository, authRepository, config.GetConfig().Workspace, service.NewLogInvitationSender())
+
+	realtimeHub = realtime.NewHub()
+
+	authController        = controller.NewAuthController(authService)
+	taskController        = controller.NewTaskController(taskService, taskTagService, realtimeHub)
+	tagController         = controller.NewTagController(tagService, realtimeHub)
+	wsController          = controller.NewWSController(realtimeHub, taskService, workspaceService)
+	syncController        = controller.NewSyncController(syncService, realtimeHub)
+	tokenController       = controller.NewTokenController(tokenService)
+	oidcController        = controller.NewOIDCController(oidcService, config.GetConfig().OIDC.PostLoginRedirect)
+	twoFactorController   = controller.NewTwoFactorController(twoFactorService)
+	jwksController        = controller.NewJWKSController()
+	adminController       = controller.NewAdminController(adminService)
+	accountController     = controller.NewAccountController(accountService)
+	preferencesController = controller.NewPreferencesController(preferencesService, realtimeHub)
+	workspaceController   = controller.NewWorkspaceController(workspaceService, realtimeHub, realtimeHub)
+	invitationController  = controller.NewInvitationController(invitationService, realtimeHub)
 )
 
 // registerRoutes는 gin 엔진에 라우트를 등록하는 함수
 func registerRoutes(engine *gin.Engine) {
+	controller.RegisterJWKSRoutes(engine, jwksController)
+
 	v1 := engine.Group("/api/v1")
+	v1.Use(middleware.RateLimitMiddleware("api", middleware.RATE_LIMIT_SCOPE_IP))
 	{
 		auth := v1.Group("/auth")
+		auth.Use(middleware.RateLimitMiddleware("auth", middleware.RATE_LIMIT_SCOPE_IP), middleware.QueryTimeoutMiddleware())
 		{
 			controller.RegisterAuthRoutes(auth, authController)
+			controller.RegisterOIDCRoutes(auth, oidcController)
+			controller.RegisterTwoFactorRoutes(auth.Group("/2fa", middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware()), twoFactorController)
+			controller.RegisterImpersonationRoutes(auth, adminController)
+			controller.RegisterAccountRoutes(auth, accountController)
+			controller.RegisterPreferencesRoutes(auth, preferencesController)
 		}
 		tasks := v1.Group("/tasks")
-		tasks.Use(middleware.AuthMiddleware())
+		tasks.Use(middleware.APIAuthMiddleware(tokenService, model.SCOPE_RESOURCE_TASKS), middleware.RateLimitMiddleware("tasks", middleware.RATE_LIMIT_SCOPE_USER), middleware.QueryTimeoutMiddleware(), middleware.WorkspaceMiddleware(workspaceRepository))
 		{
 			controller.RegisterTaskRoutes(tasks, taskController)
 		}
 		tags := v1.Group("/tags")
-		tags.Use(middleware.AuthMiddleware())
+		tags.Use(middleware.APIAuthMiddleware(tokenService, model.SCOPE_RESOURCE_TAGS), middleware.RateLimitMiddleware("tags", middleware.RATE_LIMIT_SCOPE_USER), middleware.QueryTimeoutMiddleware(), middleware.WorkspaceMiddleware(workspaceRepository))
 		{
 			controller.RegisterTagRoutes(tags, tagController)
 		}
+		// WebSocket은 연결이 오래 유지되므로 요청 단위 제한 시간을 적용하지 않음
+		ws := v1.Group("/ws")
+		ws.Use(middleware.AuthMiddleware())
+		{
+			controller.RegisterWSRoutes(ws, wsController)
+		}
+		sync := v1.Group("/sync")
+		sync.Use(middleware.APIAuthMiddleware(tokenService, model.SCOPE_RESOURCE_SYNC), middleware.RateLimitMiddleware("sync", middleware.RATE_LIMIT_SCOPE_USER), middleware.QueryTimeoutMiddleware(), middleware.WorkspaceMiddleware(workspaceRepository))
+		{
+			controller.RegisterSyncRoutes(sync, syncController)
+		}
+		workspaces := v1.Group("/workspaces")
+		workspaces.Use(middleware.APIAuthMiddleware(tokenService, model.SCOPE_RESOURCE_WORKSPACES), middleware.RateLimitMiddleware("workspaces", middleware.RATE_LIMIT_SCOPE_USER), middleware.QueryTimeoutMiddleware())
+		{
+			controller.RegisterWorkspaceRoutes(workspaces, workspaceController)
+			controller.RegisterInvitationRoutes(workspaces, invitationController)
+		}
+		tokens := v1.Group("/tokens")
+		tokens.Use(middleware.AuthMiddleware(), middleware.NoImpersonationMiddleware(), middleware.RateLimitMiddleware("tokens", middleware.RATE_LIMIT_SCOPE_USER), middleware.QueryTimeoutMiddleware())
+		{
+			controller.RegisterTokenRoutes(tokens, tokenController)
+		}
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware(adminService), middleware.QueryTimeoutMiddleware())
+		{
+			controller.RegisterAdminRoutes(admin, adminController)
+		}
 	}
 }
@@ -3,12 +3,14 @@ package server
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
 	"lux-list/internal/config"
+	"lux-list/internal/middleware"
+	"lux-list/pkg/types"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
@@ -24,6 +26,9 @@ type server struct {
 	Port string
 	Ctx  context.Context
 
+	// 진행 중인 요청의 컨텍스트를 취소하는 함수 (종료 시 남은 쿼리 중단)
+	cancelRequests context.CancelFunc
+
 	Engine *gin.Engine
 	Server *http.Server
 }
@@ -34,16 +39,24 @@ func NewServer(Port string, ctx context.Context) Server {
 	engine := gin.Default()
 	// engine.Use(gin.Logger())
 
+	// 모든 요청 컨텍스트의 부모, 서버 종료 시 취소하여 진행 중인 쿼리를 중단
+	requestCtx, cancelRequests := context.WithCancel(ctx)
+
 	// http server 초기화
 	httpSrv := &http.Server{
 		Addr:    ":" + Port,
 		Handler: engine,
+		BaseContext: func(net.Listener) context.Context {
+			return requestCtx
+		},
 	}
 
 	return &server{
 		Port: Port,
 		Ctx:  ctx,
 
+		cancelRequests: cancelRequests,
+
 		Engine: engine,
 		Server: httpSrv,
 	}
@@ -51,28 +64,26 @@ func NewServer(Port string, ctx context.Context) Server {
 
 // gin 엔진 설정 및 서버를 실행하는 함수
 func (s *server) Run() error {
-	// CORS 설정
-	s.Engine.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://192.168.0.5:3000", "http://localhost:3000", "*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		AllowOriginFunc: func(origin string) bool {
-			return true
-		},
-		MaxAge: 12 * time.Hour,
-	}))
+	// 클라이언트 IP 설정, 믿을 수 있는 프록시가 보낸 X-Forwarded-For만 사용 (IP별 요청 제한 우회 방지)
+	if err := s.Engine.SetTrustedProxies(config.GetConfig().Server.TrustedProxies); err != nil {
+		return err
+	}
+
+	// CORS 설정 (CORS_ALLOWED_ORIGINS)
+	s.Engine.Use(middleware.CORSMiddleware())
 
 	// sessions 설정
 	session_store := cookie.NewStore([]byte(config.GetConfig().Server.SessionKey))
 	session_store.Options(sessions.Options{
-		MaxAge:   3600, // 1시간
+		MaxAge:   int(config.GetConfig().Auth.RefreshTokenTTL.Seconds()), // refresh token과 같은 유효 시간
 		HttpOnly: true,
 		Secure:   false, // 배포 시에는 true로 변경
 		Path:     "/api/v1",
 	})
-	s.Engine.Use(sessions.Sessions("ss-token", session_store))
+	s.Engine.Use(sessions.Sessions(types.SESSION_COOKIE_NAME, session_store))
+
+	// 쿠키 세션 요청의 CSRF 보호
+	s.Engine.Use(middleware.CSRFMiddleware())
 
 	// OPTIONS 설정
 	s.Engine.OPTIONS("/*path", func(c *gin.Context) {
@@ -82,6 +93,9 @@ func (s *server) Run() error {
 	// 라우트 등록
 	registerRoutes(s.Engine)
 
+	// (고루틴) 삭제 유예 기간이 지난 계정과 만료 된 내보내기 파일 정리
+	go runAccountMaintenance(s.Ctx, accountService, config.GetConfig().Account.MaintenanceInterval)
+
 	// 서버 실행
 	if err := s.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return err
@@ -93,6 +107,12 @@ func (s *server) Run() error {
 // 서버를 종료하는 함수
 func (s *server) Shutdown() error {
 	log.Println("Shutting down server...")
+	// WebSocket 연결은 http.Server.Shutdown으로 종료되지 않으므로 먼저 정리
+	realtimeHub.Close()
+
+	// 유예 시간 안에 끝나지 않은 요청은 컨텍스트를 취소하여 쿼리를 중단
+	defer s.cancelRequests()
+
 	shutdownCtx, cancel := context.WithTimeout(s.Ctx, 5*time.Second)
 	defer cancel()
 
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+// 동시에 만드는 내보내기 파일 수, 나머지 작업은 대기 상태로 기다림
+const DATA_EXPORT_CONCURRENCY = 2
+
+// 정리 작업 한 번에 삭제하는 최대 계정 수
+const ACCOUNT_PURGE_BATCH_SIZE = 100
+
+var (
+	// errDataExportNotFound는 내보내기 작업이 없을 때 반환되는 에러
+	errDataExportNotFound = errors.New("export not found")
+	// errDeletionNotScheduled는 삭제 예약이 없는 계정의 삭제를 취소하려 할 때 반환되는 에러
+	errDeletionNotScheduled = errors.New("account deletion is not scheduled")
+	// errAccountNotDue는 정리하는 동안 삭제 예약이 취소 되어 작업 공간 이전을 롤백하기 위한 내부 에러
+	errAccountNotDue = errors.New("account is no longer due for deletion")
+)
+
+// AccountService는 데이터 내보내기와 계정 삭제 관련 메서드를 정의하는 인터페이스
+type AccountService interface {
+	RequestExport(ctx context.Context, userID int) (*model.DataExport, int, error)
+	GetExport(ctx context.Context, userID int, exportID int) (*model.DataExport, int, error)
+	GetExportContent(ctx context.Context, userID int, exportID int) (*model.DataExport, []byte, int, error)
+	ScheduleDeletion(ctx context.Context, userID int, req *model.DeleteAccountRequest) (time.Time, int, error)
+	CancelDeletion(ctx context.Context, userID int) (int, error)
+	PurgeDueAccounts(ctx context.Context) ([]int, error)
+	DeleteExpiredExports(ctx context.Context) (int64, error)
+}
+
+// accountService는 AccountService 인터페이스를 구현하는 구조체
+type accountService struct {
+	accountRepository   repository.AccountRepository
+	authRepository      repository.AuthRepository
+	twoFactorRepository repository.TwoFactorRepository
+	txManager           repository.TxManager
+	accountConfig       config.AccountConfig
+	authConfig          config.AuthConfig
+
+	// 내보내기 파일을 만드는 고루틴 수 제한
+	exportSlots chan struct{}
+}
+
+// NewAccountService는 AccountService의 인스턴스를 생성하는 함수
+func NewAccountService(accountRepository repository.AccountRepository, authRepository repository.AuthRepository, twoFactorRepository repository.TwoFactorRepository, txManager repository.TxManager, accountConfig config.AccountConfig, authConfig config.AuthConfig) AccountService {
+	return &accountService{
+		accountRepository:   accountRepository,
+		authRepository:      authRepository,
+		twoFactorRepository: twoFactorRepository,
+		txManager:           txManager,
+		accountConfig:       accountConfig,
+		authConfig:          authConfig,
+		exportSlots:         make(chan struct{}, DATA_EXPORT_CONCURRENCY),
+	}
+}
+
+// RequestExport는 사용자 데이터 내보내기 작업을 만들고 백그라운드에서 ZIP 파일을 만드는 메서드
+// 이미 대기 중이거나 만들고 있는 작업이 있으면 새로 만들지 않고 그 작업을 반환
+func (s *accountService) RequestExport(ctx context.Context, userID int) (*model.DataExport, int, error) {
+	latest, err := s.accountRepository.GetLatestExport(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if latest != nil && latest.IsActive() {
+		if !latest.IsStale(time.Now(), s.accountConfig.ExportTimeout) {
+			return latest, http.StatusAccepted, nil
+		}
+		// 작업 중 서버가 종료 되어 끝나지 않은 작업은 실패로 처리하고 새로 만듦
+		if err := s.accountRepository.FailExport(ctx, latest.ID, "export was interrupted"); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	export, err := s.accountRepository.CreateExport(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	go s.buildExport(export.ID, userID)
+	return export, http.StatusAccepted, nil
+}
+
+// GetExport는 내보내기 작업의 상태를 조회하는 메서드
+func (s *accountService) GetExport(ctx context.Context, userID int, exportID int) (*model.DataExport, int, error) {
+	export, err := s.accountRepository.GetExport(ctx, userID, exportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errDataExportNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	if export.IsStale(time.Now(), s.accountConfig.ExportTimeout) {
+		export.Status = model.DATA_EXPORT_STATUS_FAILED
+		export.Error = "export was interrupted"
+	}
+	return export, http.StatusOK, nil
+}
+
+// GetExportContent는 완료 된 내보내기 작업의 ZIP 파일 내용을 반환하는 메서드
+// 아직 만들고 있으면 409, 내려받을 수 있는 시간이 지났으면 410을 반환
+func (s *accountService) GetExportContent(ctx context.Context, userID int, exportID int) (*model.DataExport, []byte, int, error) {
+	export, status, err := s.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, nil, status, err
+	}
+	switch {
+	case export.IsActive():
+		return nil, nil, http.StatusConflict, errors.New("export is not ready yet")
+	case export.Status == model.DATA_EXPORT_STATUS_FAILED:
+		return nil, nil, http.StatusConflict, errors.New("export failed, request a new export")
+	case !export.IsDownloadable(time.Now()):
+		return nil, nil, http.StatusGone, errors.New("export has expired, request a new export")
+	}
+
+	content, err := s.accountRepository.GetExportContent(ctx, userID, exportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, http.StatusGone, errors.New("export has expired, request a new export")
+		}
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	return export, content, http.StatusOK, nil
+}
+
+// ScheduleDeletion은 유예 기간 뒤에 계정을 삭제하도록 예약하고 삭제 예정 시각을 반환하는 메서드
+// 확인 문구(사용자 이름), 비밀번호, 2단계 인증 코드를 확인하며, 세션 해제는 호출하는 쪽에서 해야 함
+func (s *accountService) ScheduleDeletion(ctx context.Context, userID int, req *model.DeleteAccountRequest) (time.Time, int, error) {
+	credentials, err := s.authRepository.GetCredentialsByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, http.StatusInternalServerError, err
+	}
+	if credentials == nil {
+		return time.Time{}, http.StatusNotFound, errors.New("user not found")
+	}
+	if credentials.DeletionScheduledAt != nil {
+		return time.Time{}, http.StatusConflict, errors.New("account deletion is already scheduled")
+	}
+	if req.Confirm != credentials.Name {
+		return time.Time{}, http.StatusBadRequest, errors.New("confirm must be your user name")
+	}
+
+	if credentials.HasPassword() {
+		valid, err := auth.VerifyPassword(req.Password, credentials.PasswordHash)
+		if err != nil {
+			return time.Time{}, http.StatusInternalServerError, err
+		}
+		if !valid {
+			return time.Time{}, http.StatusForbidden, errors.New("current password is incorrect")
+		}
+	}
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		return time.Time{}, http.StatusInternalServerError, err
+	}
+	if totp.IsEnabled() {
+		if status, err := verifySecondFactor(ctx, s.twoFactorRepository, totp, &req.TwoFactorCode, s.authConfig); err != nil {
+			return time.Time{}, status, err
+		}
+	}
+
+	deletionAt := time.Now().UTC().Add(s.accountConfig.DeletionGracePeriod)
+	if err := s.accountRepository.ScheduleDeletion(ctx, userID, &deletionAt); err != nil {
+		return time.Time{}, http.StatusInternalServerError, err
+	}
+	return deletionAt, http.StatusAccepted, nil
+}
+
+// CancelDeletion은 유예 기간 안에 계정 삭제 예약을 취소하는 메서드
+func (s *accountService) CancelDeletion(ctx context.Context, userID int) (int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+	if user.DeletionScheduledAt == nil {
+		return http.StatusConflict, errDeletionNotScheduled
+	}
+	if err := s.accountRepository.ScheduleDeletion(ctx, userID, nil); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// PurgeDueAccounts는 삭제 유예 기간이 지난 계정을 삭제하고 삭제한 사용자 ID를 반환하는 메서드
+// 사용자가 owner인 공유 작업 공간은 다른 구성원에게 넘기고, 공유 작업 공간에 만든 작업과 태그는 남김
+// 삭제한 사용자의 세션은 호출하는 쪽에서 해제해야 함
+func (s *accountService) PurgeDueAccounts(ctx context.Context) ([]int, error) {
+	now := time.Now().UTC()
+	userIDs, err := s.accountRepository.GetUsersDueForDeletion(ctx, now, ACCOUNT_PURGE_BATCH_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	purged := make([]int, 0, len(userIDs))
+	for _, userID := range userIDs {
+		err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+			if err := tx.Workspace.HandOverWorkspaces(ctx, userID); err != nil {
+				return err
+			}
+			deleted, err := tx.Account.DeleteUserDueForDeletion(ctx, userID, now)
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				return errAccountNotDue
+			}
+			return nil
+		})
+		if err == errAccountNotDue {
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged = append(purged, userID)
+	}
+	return purged, nil
+}
+
+// DeleteExpiredExports는 내려받을 수 있는 시간이 지난 내보내기 작업을 삭제하는 메서드
+func (s *accountService) DeleteExpiredExports(ctx context.Context) (int64, error) {
+	return s.accountRepository.DeleteExpiredExports(ctx, time.Now())
+}
+
+// buildExport는 내보내기 ZIP 파일을 만들어 저장하는 메서드 (요청과 별개의 고루틴에서 실행)
+func (s *accountService) buildExport(exportID int, userID int) {
+	s.exportSlots <- struct{}{}
+	defer func() { <-s.exportSlots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.accountConfig.ExportTimeout)
+	defer cancel()
+
+	started, err := s.accountRepository.StartExport(ctx, exportID)
+	if err != nil || !started {
+		if err != nil {
+			log.Printf("Data export %d could not be started: %v", exportID, err)
+		}
+		return
+	}
+
+	content, err := s.createExportArchive(ctx, userID)
+	if err == nil {
+		err = s.accountRepository.CompleteExport(ctx, exportID, content, time.Now().Add(s.accountConfig.ExportTTL))
+	}
+	if err != nil {
+		log.Printf("Data export %d failed: %v", exportID, err)
+		// 제한 시간이 지났어도 실패 기록은 남겨야 하므로 새 컨텍스트 사용
+		failCtx, failCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer failCancel()
+		if err := s.accountRepository.FailExport(failCtx, exportID, "failed to build export"); err != nil {
+			log.Printf("Data export %d could not be marked as failed: %v", exportID, err)
+		}
+	}
+}
+
+// createExportArchive는 사용자의 모든 데이터를 읽어 JSON 파일들을 담은 ZIP 파일을 만드는 메서드
+func (s *accountService) createExportArchive(ctx context.Context, userID int) ([]byte, error) {
+	var data model.AccountExportData
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		user, err := tx.Auth.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return errors.New("user not found")
+		}
+		identities, err := tx.Identity.GetIdentitiesByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		tokens, err := tx.Token.GetTokensByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		totp, err := tx.TwoFactor.GetTOTP(ctx, userID)
+		if err != nil {
+			return err
+		}
+		data.Profile = model.AccountExportProfile{
+			ID:                   user.ID,
+			Name:                 user.Name,
+			Email:                user.Email,
+			Role:                 user.Role,
+			CreatedAt:            user.CreatedAt,
+			TwoFactorEnabled:     totp.IsEnabled(),
+			Identities:           identities,
+			PersonalAccessTokens: tokens,
+			ExportedAt:           time.Now().UTC(),
+		}
+
+		if data.Tasks, err = tx.Account.GetExportTasks(ctx, userID); err != nil {
+			return err
+		}
+		if data.Tags, err = tx.Account.GetExportTags(ctx, userID); err != nil {
+			return err
+		}
+		if data.Workspaces, err = tx.Workspace.GetWorkspaces(ctx, userID); err != nil {
+			return err
+		}
+		if data.TaskTemplates, err = tx.Account.GetTaskTemplates(ctx, userID); err != nil {
+			return err
+		}
+
+		stored, err := tx.Preferences.GetPreferences(ctx, userID)
+		if err != nil {
+			return err
+		}
+		preferences, err := model.NewUserPreferencesResult(stored)
+		if err != nil {
+			return err
+		}
+		data.Preferences = preferences.Preferences
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", data.Profile},
+		{"workspaces.json", data.Workspaces},
+		{"tasks.json", data.Tasks},
+		{"tags.json", data.Tags},
+		{"task_templates.json", data.TaskTemplates},
+		{"preferences.json", data.Preferences},
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range files {
+		fileWriter, err := writer.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.value); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
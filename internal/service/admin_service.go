@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+var (
+	// errAdminUserNotFound는 관리 대상 사용자가 없을 때 반환되는 에러
+	errAdminUserNotFound = errors.New("user not found")
+	// errAdminSelfAction은 관리자가 자기 계정을 사용 중지 / 역할 변경 / 대리 로그인하려 할 때 반환되는 에러
+	errAdminSelfAction = errors.New("cannot perform this action on your own account")
+)
+
+// AdminActor는 관리자 작업을 요청한 관리자와 요청 IP (작업 기록용)
+type AdminActor struct {
+	UserID int
+	IP     string
+}
+
+// AdminService는 관리자 기능 관련 메서드를 정의하는 인터페이스
+type AdminService interface {
+	IsAdmin(ctx context.Context, userID int) (bool, error)
+	ListUsers(ctx context.Context, filter *model.AdminUserFilter) (*model.AdminUserListResult, int, error)
+	GetUser(ctx context.Context, userID int) (*model.AdminUser, int, error)
+	DisableUser(ctx context.Context, actor AdminActor, userID int) (*model.AdminUser, int, error)
+	EnableUser(ctx context.Context, actor AdminActor, userID int) (*model.AdminUser, int, error)
+	SetUserRole(ctx context.Context, actor AdminActor, userID int, req *model.SetUserRoleRequest) (*model.AdminUser, int, error)
+	RecordLogout(ctx context.Context, actor AdminActor, userID int, revoked int) (int, error)
+	Impersonate(ctx context.Context, actor AdminActor, userID int, req *model.ImpersonateRequest) (*model.User, string, int, error)
+	EndImpersonation(ctx context.Context, actor AdminActor, userID int) (int, error)
+	IssueClaimToken(ctx context.Context, actor AdminActor, userID int) (*model.ClaimTokenResponse, int, error)
+	GetAuditLogs(ctx context.Context, targetUserID int, limit int, page int) ([]model.AdminAuditLog, int, int, error)
+}
+
+// adminService는 AdminService 인터페이스를 구현하는 구조체
+type adminService struct {
+	adminRepository repository.AdminRepository
+	authRepository  repository.AuthRepository
+	txManager       repository.TxManager
+	authConfig      config.AuthConfig
+}
+
+// NewAdminService는 AdminService의 인스턴스를 생성하는 함수
+func NewAdminService(adminRepository repository.AdminRepository, authRepository repository.AuthRepository, txManager repository.TxManager, authConfig config.AuthConfig) AdminService {
+	return &adminService{
+		adminRepository: adminRepository,
+		authRepository:  authRepository,
+		txManager:       txManager,
+		authConfig:      authConfig,
+	}
+}
+
+// IsAdmin은 사용 중지되지 않은 관리자인지 확인하는 메서드 (AdminMiddleware에서 요청마다 확인)
+func (s *adminService) IsAdmin(ctx context.Context, userID int) (bool, error) {
+	user, err := s.authRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.IsAdmin() && !user.IsDisabled(), nil
+}
+
+// ListUsers는 이름 / 이메일 검색, 역할, 사용 중지 여부로 사용자 목록을 조회하는 메서드
+func (s *adminService) ListUsers(ctx context.Context, filter *model.AdminUserFilter) (*model.AdminUserListResult, int, error) {
+	if filter.Role != "" && !model.ValidUserRole(filter.Role) {
+		return nil, http.StatusBadRequest, errors.New("role must be user or admin")
+	}
+	filter.Limit, filter.Page = adminPagination(filter.Limit, filter.Page)
+
+	users, totalCount, err := s.adminRepository.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result := &model.AdminUserListResult{Users: make([]model.AdminUser, 0, len(users)), TotalCount: totalCount}
+	for i := range users {
+		result.Users = append(result.Users, model.NewAdminUser(&users[i]))
+	}
+	return result, http.StatusOK, nil
+}
+
+// GetUser는 사용자 정보와 사용량을 조회하는 메서드 (활성 세션 수는 호출하는 쪽에서 채움)
+func (s *adminService) GetUser(ctx context.Context, userID int) (*model.AdminUser, int, error) {
+	user, status, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	usage, err := s.adminRepository.GetUserUsage(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	response := model.NewAdminUser(user)
+	response.Usage = usage
+	return &response, http.StatusOK, nil
+}
+
+// DisableUser는 사용자를 사용 중지하는 메서드, 로그인과 개인 액세스 토큰 인증이 거부됨
+// 이미 로그인한 세션은 호출하는 쪽에서 해제해야 함
+func (s *adminService) DisableUser(ctx context.Context, actor AdminActor, userID int) (*model.AdminUser, int, error) {
+	if userID == actor.UserID {
+		return nil, http.StatusBadRequest, errAdminSelfAction
+	}
+	now := time.Now().UTC()
+	return s.updateUser(ctx, actor, userID, model.ADMIN_ACTION_USER_DISABLE, "", func(tx *repository.Repositories) error {
+		return tx.Admin.SetUserDisabled(ctx, userID, &now)
+	})
+}
+
+// EnableUser는 사용 중지한 사용자를 다시 사용할 수 있게 하는 메서드
+func (s *adminService) EnableUser(ctx context.Context, actor AdminActor, userID int) (*model.AdminUser, int, error) {
+	return s.updateUser(ctx, actor, userID, model.ADMIN_ACTION_USER_ENABLE, "", func(tx *repository.Repositories) error {
+		return tx.Admin.SetUserDisabled(ctx, userID, nil)
+	})
+}
+
+// SetUserRole은 사용자의 역할을 바꾸는 메서드, 자기 역할은 바꿀 수 없음 (마지막 관리자가 사라지지 않도록)
+func (s *adminService) SetUserRole(ctx context.Context, actor AdminActor, userID int, req *model.SetUserRoleRequest) (*model.AdminUser, int, error) {
+	if !model.ValidUserRole(req.Role) {
+		return nil, http.StatusBadRequest, errors.New("role must be user or admin")
+	}
+	if userID == actor.UserID {
+		return nil, http.StatusBadRequest, errAdminSelfAction
+	}
+	return s.updateUser(ctx, actor, userID, model.ADMIN_ACTION_ROLE_CHANGE, "role="+req.Role, func(tx *repository.Repositories) error {
+		return tx.Admin.SetUserRole(ctx, userID, req.Role)
+	})
+}
+
+// RecordLogout은 관리자가 사용자의 모든 세션을 해제한 기록을 남기는 메서드
+func (s *adminService) RecordLogout(ctx context.Context, actor AdminActor, userID int, revoked int) (int, error) {
+	if _, status, err := s.getUser(ctx, userID); err != nil {
+		return status, err
+	}
+	detail := "revoked_sessions=" + strconv.Itoa(revoked)
+	if err := s.adminRepository.CreateAuditLog(ctx, newAuditLog(actor, userID, model.ADMIN_ACTION_USER_LOGOUT, detail)); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// Impersonate는 고객 지원을 위해 관리자가 사용자로 로그인하는 메서드, 사유와 함께 작업 기록을 남기고 사용자의 JWT를 반환
+// 관리자 계정과 사용 중지 된 계정으로는 대리 로그인할 수 없음
+func (s *adminService) Impersonate(ctx context.Context, actor AdminActor, userID int, req *model.ImpersonateRequest) (*model.User, string, int, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, "", http.StatusBadRequest, errors.New("reason is required")
+	}
+	if utf8.RuneCountInString(reason) > model.IMPERSONATION_REASON_MAX_LENGTH {
+		return nil, "", http.StatusBadRequest, errors.New("reason is too long")
+	}
+	if userID == actor.UserID {
+		return nil, "", http.StatusBadRequest, errAdminSelfAction
+	}
+
+	user, status, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, "", status, err
+	}
+	if user.IsAdmin() {
+		return nil, "", http.StatusForbidden, errors.New("cannot impersonate another administrator")
+	}
+	if user.IsDisabled() {
+		return nil, "", http.StatusConflict, errAccountDisabled
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	if err := s.adminRepository.CreateAuditLog(ctx, newAuditLog(actor, userID, model.ADMIN_ACTION_IMPERSONATION_START, reason)); err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	return user, token, http.StatusOK, nil
+}
+
+// EndImpersonation은 대리 로그인을 끝낸 기록을 남기는 메서드
+func (s *adminService) EndImpersonation(ctx context.Context, actor AdminActor, userID int) (int, error) {
+	if err := s.adminRepository.CreateAuditLog(ctx, newAuditLog(actor, userID, model.ADMIN_ACTION_IMPERSONATION_END, "")); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// IssueClaimToken은 이름만으로 가입한 기존 사용자의 계정 등록(claim) 토큰을 발급하는 메서드
+// 토큰은 응답으로 한 번만 반환되며, 관리자가 본인 확인을 거친 뒤 앱 밖의 수단으로 사용자에게 전달함
+// 비밀번호가 있거나 외부 ID 공급자로 가입한 계정, 사용 중지 된 계정에는 발급할 수 없음
+func (s *adminService) IssueClaimToken(ctx context.Context, actor AdminActor, userID int) (*model.ClaimTokenResponse, int, error) {
+	credentials, err := s.authRepository.GetCredentialsByID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if credentials == nil {
+		return nil, http.StatusNotFound, errAdminUserNotFound
+	}
+	if credentials.HasPassword() {
+		return nil, http.StatusConflict, repository.ErrAccountAlreadyClaimed
+	}
+	if credentials.HasIdentity {
+		return nil, http.StatusConflict, errSingleSignOnRequired
+	}
+	if credentials.IsDisabled() {
+		return nil, http.StatusConflict, errAccountDisabled
+	}
+
+	token, tokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	expiresAt := time.Now().Add(s.authConfig.ClaimTokenTTL).UTC()
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Auth.CreatePasswordToken(ctx, userID, model.PASSWORD_TOKEN_CLAIM, tokenHash, expiresAt); err != nil {
+			return err
+		}
+		return tx.Admin.CreateAuditLog(ctx, newAuditLog(actor, userID, model.ADMIN_ACTION_CLAIM_TOKEN_ISSUE, "expires_at="+expiresAt.Format(time.RFC3339)))
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &model.ClaimTokenResponse{Token: token, ExpiresAt: expiresAt}, http.StatusCreated, nil
+}
+
+// GetAuditLogs는 관리자 작업 기록을 최신 순으로 조회하는 메서드, targetUserID가 0이면 모든 기록을 조회
+// 기록 목록, 전체 수, 상태 코드를 반환
+func (s *adminService) GetAuditLogs(ctx context.Context, targetUserID int, limit int, page int) ([]model.AdminAuditLog, int, int, error) {
+	limit, page = adminPagination(limit, page)
+	logs, totalCount, err := s.adminRepository.GetAuditLogs(ctx, targetUserID, limit, page)
+	if err != nil {
+		return nil, 0, http.StatusInternalServerError, err
+	}
+	return logs, totalCount, http.StatusOK, nil
+}
+
+// getUser는 관리 대상 사용자를 조회하는 메서드, 없으면 404를 반환
+func (s *adminService) getUser(ctx context.Context, userID int) (*model.User, int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return nil, http.StatusNotFound, errAdminUserNotFound
+	}
+	return user, http.StatusOK, nil
+}
+
+// updateUser는 사용자 변경과 작업 기록을 한 트랜잭션으로 처리하고 바뀐 사용자를 반환하는 메서드
+func (s *adminService) updateUser(ctx context.Context, actor AdminActor, userID int, action string, detail string, update func(tx *repository.Repositories) error) (*model.AdminUser, int, error) {
+	status := http.StatusInternalServerError
+	var user *model.User
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := update(tx); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				status = http.StatusNotFound
+				return errAdminUserNotFound
+			}
+			return err
+		}
+		if err := tx.Admin.CreateAuditLog(ctx, newAuditLog(actor, userID, action, detail)); err != nil {
+			return err
+		}
+
+		var err error
+		user, err = tx.Auth.GetUserByID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	response := model.NewAdminUser(user)
+	return &response, http.StatusOK, nil
+}
+
+// newAuditLog는 관리자 작업 기록을 만드는 함수
+func newAuditLog(actor AdminActor, targetUserID int, action string, detail string) *model.AdminAuditLog {
+	return &model.AdminAuditLog{
+		ActorUserID:  &actor.UserID,
+		TargetUserID: &targetUserID,
+		Action:       action,
+		Detail:       detail,
+		IP:           actor.IP,
+	}
+}
+
+// adminPagination은 관리자 목록 조회의 limit과 page를 기본값과 최대값 안으로 맞추는 함수
+func adminPagination(limit int, page int) (int, int) {
+	if limit <= 0 {
+		limit = model.ADMIN_LIST_DEFAULT_LIMIT
+	}
+	if limit > model.ADMIN_LIST_MAX_LIMIT {
+		limit = model.ADMIN_LIST_MAX_LIMIT
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return limit, page
+}
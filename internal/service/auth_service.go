@@ -1,75 +1,442 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 
+	"lux-list/internal/config"
 	"lux-list/internal/model"
 	"lux-list/internal/repository"
 	"lux-list/pkg/auth"
 )
 
+var (
+	// errInvalidCredentials는 이메일 또는 비밀번호가 틀렸을 때 반환되는 에러 (어느 쪽이 틀렸는지 알려주지 않음)
+	errInvalidCredentials = errors.New("invalid email or password")
+	// errPasswordRequired는 비밀번호를 등록한 계정에 이름만으로 로그인하려 할 때 반환되는 에러
+	errPasswordRequired = errors.New("this account requires email and password")
+	// errLegacyLoginDisabled는 이름만으로 로그인하는 기능이 꺼져 있을 때 반환되는 에러
+	errLegacyLoginDisabled = errors.New("login with name only is no longer supported, use email and password or claim the account with a token from an administrator")
+	// errAccountDisabled는 관리자가 사용 중지한 계정으로 로그인하려 할 때 반환되는 에러
+	errAccountDisabled = errors.New("this account has been disabled")
+	// errSingleSignOnRequired는 외부 ID 공급자로 가입한 계정에 이름만으로 로그인하려 할 때 반환되는 에러
+	errSingleSignOnRequired = errors.New("this account signs in with single sign-on")
+
+	// dummyPasswordHash는 없는 사용자에 대해서도 같은 시간이 걸리도록 검증에 사용하는 해시
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
+)
+
 // AuthService는 사용자 인증 관련 메서드를 정의하는 인터페이스
 type AuthService interface {
-	ExistUser(name string) (bool, error)
-	Login(name string) (*model.User, string, int, error)
-	RegisterAndGenerateJWT(name string) (*model.User, string, int, error)
-	GetUserByName(name string) (*model.User, int, error)
-	GetUserByID(id int) (*model.User, int, error)
+	ExistUser(ctx context.Context, name string) (bool, error)
+	Register(ctx context.Context, req *model.RegisterRequest) (*model.User, string, int, error)
+	Login(ctx context.Context, req *model.LoginRequest) (*model.User, string, bool, int, error)
+	ClaimAccount(ctx context.Context, req *model.ClaimAccountRequest) (*model.User, string, int, error)
+	RequestEmailVerification(ctx context.Context, userID int) (int, error)
+	VerifyEmail(ctx context.Context, req *model.VerifyEmailRequest) (*model.User, int, error)
+	ChangePassword(ctx context.Context, userID int, req *model.ChangePasswordRequest) (string, int, error)
+	RequestPasswordReset(ctx context.Context, email string) (int, error)
+	ResetPassword(ctx context.Context, req *model.ConfirmPasswordResetRequest) (*model.User, int, error)
+	RefreshAccessToken(ctx context.Context, userID int) (string, int, error)
+	GetUserByName(ctx context.Context, name string) (*model.User, int, error)
+	GetUserByID(ctx context.Context, id int) (*model.User, int, error)
 }
 
 // authService는 AuthService 인터페이스를 구현하는 구조체
 type authService struct {
-	authRepository repository.AuthRepository
+	authRepository      repository.AuthRepository
+	twoFactorRepository repository.TwoFactorRepository
+	txManager           repository.TxManager
+	authConfig          config.AuthConfig
+	resetSender         PasswordResetSender
+	verificationSender  EmailVerificationSender
 }
 
 // NewAuthService는 AuthService의 인스턴스를 생성하는 함수
-func NewAuthService(authRepository repository.AuthRepository) AuthService {
+func NewAuthService(authRepository repository.AuthRepository, twoFactorRepository repository.TwoFactorRepository, txManager repository.TxManager, authConfig config.AuthConfig, resetSender PasswordResetSender, verificationSender EmailVerificationSender) AuthService {
 	return &authService{
-		authRepository: authRepository,
+		authRepository:      authRepository,
+		twoFactorRepository: twoFactorRepository,
+		txManager:           txManager,
+		authConfig:          authConfig,
+		resetSender:         resetSender,
+		verificationSender:  verificationSender,
 	}
 }
 
 // ExistUser는 사용자가 존재하는지 확인하는 메서드
-func (s *authService) ExistUser(name string) (bool, error) {
-	exist, err := s.authRepository.ExistUser(name)
+func (s *authService) ExistUser(ctx context.Context, name string) (bool, error) {
+	exist, err := s.authRepository.ExistUser(ctx, name)
 	if err != nil {
 		return false, err
 	}
 	return exist, nil
 }
 
-// Login은 JWT 토큰 발급을 위해 사용자 로그인 요청을 처리하는 메서드
-func (s *authService) Login(name string) (*model.User, string, int, error) {
-	user, err := s.authRepository.GetUserByName(name)
-	if err != nil {
+// Register는 이메일과 비밀번호로 새로운 사용자를 생성하고 JWT 토큰을 발급하는 메서드
+// 가입한 이메일로 인증 토큰을 보내며, 인증하기 전까지 이메일은 인증 되지 않은 상태로 남음
+func (s *authService) Register(ctx context.Context, req *model.RegisterRequest) (*model.User, string, int, error) {
+	if err := req.CheckValidRegisterRequest(); err != nil {
 		return nil, "", http.StatusBadRequest, err
 	}
 
-	token, err := auth.GenerateJWT(user.ID)
+	passwordHash, err := auth.HashPassword(req.Password)
 	if err != nil {
 		return nil, "", http.StatusInternalServerError, err
 	}
 
-	return user, token, http.StatusOK, nil
+	var (
+		user   *model.User
+		status = http.StatusInternalServerError
+	)
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		exist, err := tx.Auth.ExistUser(ctx, req.Name)
+		if err != nil {
+			return err
+		}
+		if exist {
+			status = http.StatusConflict
+			return errors.New("name is already taken")
+		}
+
+		exist, err = tx.Auth.ExistEmail(ctx, req.Email)
+		if err != nil {
+			return err
+		}
+		if exist {
+			status = http.StatusConflict
+			return errors.New("email is already registered")
+		}
+
+		user, err = tx.Auth.CreateUserWithPassword(ctx, req.Name, req.Email, passwordHash)
+		return err
+	})
+	if err != nil {
+		return nil, "", status, err
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	// 인증 메일을 보내지 못해도 가입은 끝났고 나중에 다시 요청할 수 있으므로 실패로 처리하지 않음
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("Failed to send email verification to user %d: %v", user.ID, err)
+	}
+	return user, token, http.StatusCreated, nil
 }
 
-// RegisterAndGenerateJWT는 새로운 사용자를 생성하고 JWT 토큰을 발급하는 메서드
-func (s *authService) RegisterAndGenerateJWT(name string) (*model.User, string, int, error) {
-	user, err := s.authRepository.CreateUser(name)
+// Login은 JWT 토큰 발급을 위해 사용자 로그인 요청을 처리하는 메서드
+// email이 있으면 비밀번호와 (켜져 있으면) 2단계 인증 코드를 검증하고, 없으면 비밀번호를 등록하지 않은 기존 사용자의 이름 로그인으로 처리
+// 두 번째 bool 반환값은 기존 사용자가 계정 등록(claim)을 해야 하는지 여부
+func (s *authService) Login(ctx context.Context, req *model.LoginRequest) (*model.User, string, bool, int, error) {
+	var (
+		credentials *model.UserCredentials
+		err         error
+	)
+
+	if req.Email != "" {
+		credentials, err = s.authRepository.GetCredentialsByEmail(ctx, model.NormalizeEmail(req.Email))
+		if err != nil {
+			return nil, "", false, http.StatusInternalServerError, err
+		}
+		if !s.verifyPassword(credentials, req.Password) {
+			return nil, "", false, http.StatusUnauthorized, errInvalidCredentials
+		}
+
+		// 비밀번호가 맞으면 JWT를 발급하기 전에 2단계 인증 확인
+		totp, err := s.twoFactorRepository.GetTOTP(ctx, credentials.ID)
+		if err != nil {
+			return nil, "", false, http.StatusInternalServerError, err
+		}
+		if totp.IsEnabled() {
+			if status, err := verifySecondFactor(ctx, s.twoFactorRepository, totp, &req.TwoFactorCode, s.authConfig); err != nil {
+				return nil, "", false, status, err
+			}
+		}
+	} else {
+		if req.Name == "" {
+			return nil, "", false, http.StatusBadRequest, errors.New("email and password are required")
+		}
+		if !s.authConfig.LegacyNameLogin {
+			return nil, "", false, http.StatusUnauthorized, errLegacyLoginDisabled
+		}
+
+		credentials, err = s.authRepository.GetCredentialsByName(ctx, req.Name)
+		if err != nil {
+			return nil, "", false, http.StatusInternalServerError, err
+		}
+		if credentials == nil {
+			return nil, "", false, http.StatusUnauthorized, errors.New("user not found, register with email and password")
+		}
+		if credentials.HasPassword() {
+			return nil, "", false, http.StatusUnauthorized, errPasswordRequired
+		}
+		if credentials.HasIdentity {
+			return nil, "", false, http.StatusUnauthorized, errSingleSignOnRequired
+		}
+	}
+
+	if credentials.IsDisabled() {
+		return nil, "", false, http.StatusForbidden, errAccountDisabled
+	}
+
+	token, err := auth.GenerateJWT(credentials.ID)
 	if err != nil {
+		return nil, "", false, http.StatusInternalServerError, err
+	}
+
+	return &credentials.User, token, !credentials.HasPassword(), http.StatusOK, nil
+}
+
+// ClaimAccount는 이름만으로 가입한 기존 사용자가 관리자에게 받은 일회성 토큰으로 이메일과 비밀번호를 등록하고 JWT 토큰을 발급하는 메서드
+// 토큰이 계정을 가리키므로 이름 로그인 세션 없이 사용하며, 등록한 이메일로 인증 토큰을 보냄
+func (s *authService) ClaimAccount(ctx context.Context, req *model.ClaimAccountRequest) (*model.User, string, int, error) {
+	if err := req.CheckValidClaimAccountRequest(); err != nil {
 		return nil, "", http.StatusBadRequest, err
 	}
 
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+
+	var (
+		user   *model.User
+		status = http.StatusInternalServerError
+	)
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		userID, err := tx.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_CLAIM, auth.HashOpaqueToken(req.Token))
+		if err != nil {
+			if errors.Is(err, repository.ErrPasswordTokenInvalid) {
+				status = http.StatusBadRequest
+			}
+			return err
+		}
+
+		current, err := tx.Auth.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			status = http.StatusBadRequest
+			return repository.ErrPasswordTokenInvalid
+		}
+		if current.IsDisabled() {
+			status = http.StatusForbidden
+			return errAccountDisabled
+		}
+
+		exist, err := tx.Auth.ExistEmail(ctx, req.Email)
+		if err != nil {
+			return err
+		}
+		if exist {
+			status = http.StatusConflict
+			return errors.New("email is already registered")
+		}
+
+		user, err = tx.Auth.ClaimAccount(ctx, userID, req.Email, passwordHash)
+		if errors.Is(err, repository.ErrAccountAlreadyClaimed) {
+			status = http.StatusConflict
+		}
+		return err
+	})
+	if err != nil {
+		return nil, "", status, err
+	}
+
 	token, err := auth.GenerateJWT(user.ID)
 	if err != nil {
 		return nil, "", http.StatusInternalServerError, err
 	}
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("Failed to send email verification to user %d: %v", user.ID, err)
+	}
 	return user, token, http.StatusOK, nil
 }
 
+// RequestEmailVerification은 사용자의 이메일로 인증 토큰을 다시 보내는 메서드
+func (s *authService) RequestEmailVerification(ctx context.Context, userID int) (int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+	if user.Email == "" {
+		return http.StatusConflict, errors.New("account has no email, claim the account first")
+	}
+	if user.IsEmailVerified() {
+		return http.StatusConflict, errors.New("email is already verified")
+	}
+
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusAccepted, nil
+}
+
+// VerifyEmail은 일회성 인증 토큰으로 사용자의 이메일을 인증하는 메서드
+// 토큰은 한 번만 사용할 수 있으며, 같은 사용자의 다른 인증 토큰도 함께 무효화됨
+func (s *authService) VerifyEmail(ctx context.Context, req *model.VerifyEmailRequest) (*model.User, int, error) {
+	if req.Token == "" {
+		return nil, http.StatusBadRequest, errors.New("token is required")
+	}
+
+	var user *model.User
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		userID, err := tx.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_VERIFY_EMAIL, auth.HashOpaqueToken(req.Token))
+		if err != nil {
+			return err
+		}
+		if err := tx.Auth.MarkEmailVerified(ctx, userID); err != nil {
+			return err
+		}
+		user, err = tx.Auth.GetUserByID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrPasswordTokenInvalid) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return user, http.StatusOK, nil
+}
+
+// ChangePassword는 현재 비밀번호를 확인한 뒤 비밀번호를 변경하고 새 JWT 토큰을 발급하는 메서드
+// 새 토큰으로 인증 세션을 교체하면 다른 기기의 기존 세션은 만료됨
+func (s *authService) ChangePassword(ctx context.Context, userID int, req *model.ChangePasswordRequest) (string, int, error) {
+	if err := model.ValidatePassword(req.NewPassword); err != nil {
+		return "", http.StatusBadRequest, err
+	}
+
+	credentials, err := s.authRepository.GetCredentialsByID(ctx, userID)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	if credentials == nil {
+		return "", http.StatusNotFound, errors.New("user not found")
+	}
+	if !credentials.HasPassword() {
+		return "", http.StatusConflict, errors.New("account has no password, claim the account first")
+	}
+	if !s.verifyPassword(credentials, req.CurrentPassword) {
+		return "", http.StatusForbidden, errors.New("current password is incorrect")
+	}
+
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	if err := s.authRepository.UpdatePassword(ctx, userID, passwordHash); err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	token, err := auth.GenerateJWT(userID)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return token, http.StatusOK, nil
+}
+
+// RequestPasswordReset은 비밀번호 재설정 토큰을 발급하여 사용자에게 전달하는 메서드
+// 가입 여부를 노출하지 않도록 사용자가 없어도 같은 상태 코드를 반환
+func (s *authService) RequestPasswordReset(ctx context.Context, email string) (int, error) {
+	email = model.NormalizeEmail(email)
+	if err := model.ValidateEmail(email); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	credentials, err := s.authRepository.GetCredentialsByEmail(ctx, email)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if credentials == nil || !credentials.HasPassword() {
+		return http.StatusAccepted, nil
+	}
+
+	token, tokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	expiresAt := time.Now().Add(s.authConfig.PasswordResetTTL).UTC()
+	if err := s.authRepository.CreatePasswordToken(ctx, credentials.ID, model.PASSWORD_TOKEN_RESET, tokenHash, expiresAt); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := s.resetSender.SendPasswordReset(ctx, &credentials.User, token, expiresAt); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusAccepted, nil
+}
+
+// ResetPassword는 일회성 재설정 토큰으로 비밀번호를 변경하는 메서드
+// 토큰은 한 번만 사용할 수 있으며, 같은 사용자의 다른 재설정 토큰도 함께 무효화됨
+func (s *authService) ResetPassword(ctx context.Context, req *model.ConfirmPasswordResetRequest) (*model.User, int, error) {
+	if req.Token == "" {
+		return nil, http.StatusBadRequest, errors.New("token is required")
+	}
+	if err := model.ValidatePassword(req.NewPassword); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	var user *model.User
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		userID, err := tx.Auth.ConsumePasswordToken(ctx, model.PASSWORD_TOKEN_RESET, auth.HashOpaqueToken(req.Token))
+		if err != nil {
+			return err
+		}
+		if err := tx.Auth.UpdatePassword(ctx, userID, passwordHash); err != nil {
+			return err
+		}
+		user, err = tx.Auth.GetUserByID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrPasswordTokenInvalid) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return user, http.StatusOK, nil
+}
+
+// RefreshAccessToken은 refresh token으로 갱신할 사용자의 새 JWT 토큰을 발급하는 메서드
+// 그 사이 탈퇴했거나 사용 중지 된 사용자면 401을 반환
+func (s *authService) RefreshAccessToken(ctx context.Context, userID int) (string, int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return "", http.StatusUnauthorized, errors.New("user not found")
+	}
+	if user.IsDisabled() {
+		return "", http.StatusUnauthorized, errAccountDisabled
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return token, http.StatusOK, nil
+}
+
 // GetUserByName은 사용자 이름으로 사용자를 조회하는 메서드
-func (s *authService) GetUserByName(name string) (*model.User, int, error) {
-	user, err := s.authRepository.GetUserByName(name)
+func (s *authService) GetUserByName(ctx context.Context, name string) (*model.User, int, error) {
+	user, err := s.authRepository.GetUserByName(ctx, name)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -77,10 +444,38 @@ func (s *authService) GetUserByName(name string) (*model.User, int, error) {
 }
 
 // GetUserByID는 사용자 ID로 사용자를 조회하는 메서드
-func (s *authService) GetUserByID(id int) (*model.User, int, error) {
-	user, err := s.authRepository.GetUserByID(id)
+func (s *authService) GetUserByID(ctx context.Context, id int) (*model.User, int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
 	return user, http.StatusOK, nil
 }
+
+// sendEmailVerification은 이메일 인증 토큰을 발급하여 사용자의 이메일로 보내는 메서드
+func (s *authService) sendEmailVerification(ctx context.Context, user *model.User) error {
+	token, tokenHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.authConfig.EmailVerificationTTL).UTC()
+	if err := s.authRepository.CreatePasswordToken(ctx, user.ID, model.PASSWORD_TOKEN_VERIFY_EMAIL, tokenHash, expiresAt); err != nil {
+		return err
+	}
+	return s.verificationSender.SendEmailVerification(ctx, user, token, expiresAt)
+}
+
+// verifyPassword는 password가 사용자의 비밀번호와 일치하는지 확인하는 메서드
+// 사용자가 없거나 비밀번호가 없어도 해시 검증을 수행하여 응답 시간으로 가입 여부가 드러나지 않게 함
+func (s *authService) verifyPassword(credentials *model.UserCredentials, password string) bool {
+	if credentials == nil || !credentials.HasPassword() {
+		dummyPasswordHashOnce.Do(func() {
+			dummyPasswordHash, _ = auth.HashPassword("lux-list-dummy-password")
+		})
+		_, _ = auth.VerifyPassword(password, dummyPasswordHash)
+		return false
+	}
+
+	ok, err := auth.VerifyPassword(password, credentials.PasswordHash)
+	return err == nil && ok
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+// EmailVerificationSender는 이메일 인증 토큰을 사용자의 이메일로 전달하는 인터페이스
+type EmailVerificationSender interface {
+	SendEmailVerification(ctx context.Context, user *model.User, token string, expiresAt time.Time) error
+}
+
+// logEmailVerificationSender는 이메일 인증 요청을 서버 로그에 남기는 EmailVerificationSender
+// 로그를 볼 수 있는 사람이 다른 사람의 이메일을 인증할 수 없도록 토큰은 남기지 않음
+// 메일 발송 수단이 없는 개발 환경용이며, 운영 환경에서는 메일 발송 구현으로 교체해야 함
+type logEmailVerificationSender struct{}
+
+// NewLogEmailVerificationSender는 로그 기반 EmailVerificationSender의 인스턴스를 생성하는 함수
+func NewLogEmailVerificationSender() EmailVerificationSender {
+	return &logEmailVerificationSender{}
+}
+
+// SendEmailVerification은 이메일 인증 요청을 로그로 출력하는 메서드
+func (s *logEmailVerificationSender) SendEmailVerification(ctx context.Context, user *model.User, token string, expiresAt time.Time) error {
+	log.Printf("Email verification requested for user %d (%s), expires_at=%s (no mail sender configured, token not delivered)",
+		user.ID, user.Email, expiresAt.Format(time.RFC3339))
+	return nil
+}
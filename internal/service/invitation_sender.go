@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+// InvitationSender는 작업 공간 초대 링크를 초대 받은 사람의 이메일로 전달하는 인터페이스
+type InvitationSender interface {
+	SendWorkspaceInvitation(ctx context.Context, email string, invitation *model.WorkspaceInvitation, link string) error
+}
+
+// logInvitationSender는 초대를 보냈다는 사실만 서버 로그에 남기는 InvitationSender
+// 메일 발송 수단이 없는 개발 환경용이며, 로그를 볼 수 있는 사람이 초대를 수락할 수 없도록 링크는 기록하지 않음
+// 초대한 사람은 초대 생성 응답의 링크를 직접 전달할 수 있고, 운영 환경에서는 메일 발송 구현으로 교체해야 함
+type logInvitationSender struct{}
+
+// NewLogInvitationSender는 로그 기반 InvitationSender의 인스턴스를 생성하는 함수
+func NewLogInvitationSender() InvitationSender {
+	return &logInvitationSender{}
+}
+
+// SendWorkspaceInvitation은 초대 정보를 링크 없이 로그로 출력하는 메서드
+func (s *logInvitationSender) SendWorkspaceInvitation(ctx context.Context, email string, invitation *model.WorkspaceInvitation, link string) error {
+	log.Printf("Workspace invitation %d to %q (%s) sent to %s, expires_at=%s",
+		invitation.ID, invitation.WorkspaceName, invitation.Role, email, invitation.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
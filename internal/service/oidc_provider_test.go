@@ -0,0 +1,197 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// 테스트 공급자에 등록 된 클라이언트
+	TEST_OIDC_CLIENT_ID     = "lux-list-test"
+	TEST_OIDC_CLIENT_SECRET = "lux-list-test-secret"
+	TEST_OIDC_REDIRECT_URL  = "http://lux-list.test/api/v1/auth/oidc/callback"
+	// 테스트 공급자의 키 ID
+	testOIDCKeyID = "test-key"
+)
+
+// testAuthorizationCode는 testOIDCProvider가 발급하고 토큰 요청에서 교환하는 인가 코드 정보
+type testAuthorizationCode struct {
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+	// 기본 ID 토큰 클레임을 덮어쓸 값, nil이면 클레임을 뺌
+	claims map[string]interface{}
+}
+
+// testOIDCProvider는 CompleteLogin 테스트용 OpenID Connect 공급자 (httptest.Server)
+// 로그인 화면 없이 issueCode로 인가 코드를 발급하며, 토큰 요청에서 클라이언트 인증, redirect_uri, PKCE를 확인함
+type testOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mutex sync.Mutex
+	codes map[string]*testAuthorizationCode
+}
+
+// newTestOIDCProvider는 테스트 공급자를 실행하는 함수, 테스트가 끝나면 종료함
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &testOIDCProvider{key: key, codes: make(map[string]*testAuthorizationCode)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// issuer는 공급자의 issuer URL을 반환하는 메서드
+func (p *testOIDCProvider) issuer() string {
+	return p.server.URL
+}
+
+// issueCode는 BeginLogin이 만든 authURL의 요청을 승인하고 인가 코드를 발급하는 메서드
+// claims는 기본 ID 토큰 클레임을 덮어씀
+func (p *testOIDCProvider) issueCode(t *testing.T, authURL string, claims map[string]interface{}) string {
+	t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != TEST_OIDC_CLIENT_ID || query.Get("response_type") != "code" {
+		t.Fatalf("unexpected authorization request %s", authURL)
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization request without PKCE S256: %s", authURL)
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	code := hex.EncodeToString(buf)
+	p.mutex.Lock()
+	p.codes[code] = &testAuthorizationCode{
+		nonce:         query.Get("nonce"),
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		claims:        claims,
+	}
+	p.mutex.Unlock()
+	return code
+}
+
+// discovery는 공급자 설정 문서를 반환하는 메서드
+func (p *testOIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer(),
+		"authorization_endpoint":                p.issuer() + "/authorize",
+		"token_endpoint":                        p.issuer() + "/token",
+		"jwks_uri":                              p.issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// jwks는 ID 토큰 서명 검증용 공개 키를 반환하는 메서드
+func (p *testOIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": testOIDCKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.PublicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.PublicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// token은 인가 코드를 검증하고 ID 토큰을 발급하는 메서드, 인가 코드는 한 번만 사용할 수 있음
+func (p *testOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+		writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != TEST_OIDC_CLIENT_ID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(TEST_OIDC_CLIENT_SECRET)) != 1 {
+		writeTestJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mutex.Lock()
+	code, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mutex.Unlock()
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || code.redirectURI != r.PostForm.Get("redirect_uri") || base64.RawURLEncoding.EncodeToString(challenge[:]) != code.codeChallenge {
+		writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.issuer(),
+		"sub":            "subject-1",
+		"aud":            TEST_OIDC_CLIENT_ID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          code.nonce,
+		"email":          "sso-user@example.com",
+		"email_verified": true,
+		"name":           "sso-user",
+	}
+	for name, value := range code.claims {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = testOIDCKeyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeTestJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+// writeTestJSON은 JSON 응답을 보내는 함수
+func writeTestJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
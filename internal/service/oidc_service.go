@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// ID 공급자(discovery, JWKS, 토큰 교환) 요청 제한 시간
+	oidcHTTPTimeout = 10 * time.Second
+	// 새로 가입하는 사용자의 이름이 겹칠 때 다른 이름을 시도하는 횟수
+	oidcUserNameAttempts = 5
+	// 사용자 이름 최대 길이 (users.name), 겹칠 때 붙이는 접미사 자리를 남겨둠
+	oidcUserNameMaxLength = 90
+)
+
+var (
+	// errOIDCDisabled는 OIDC 로그인이 꺼져 있을 때 반환되는 에러
+	errOIDCDisabled = errors.New("single sign-on is not enabled")
+	// errOIDCAccountNotLinked는 가입이 꺼져 있고 연결 된 계정이 없을 때 반환되는 에러
+	errOIDCAccountNotLinked = errors.New("no account is linked to this identity")
+	// errLastSignInMethod는 비밀번호가 없는 사용자가 마지막 외부 계정 연결을 해제하려 할 때 반환되는 에러
+	errLastSignInMethod = errors.New("cannot unlink the only sign-in method, set a password first")
+)
+
+// OIDCService는 OpenID Connect 로그인 관련 메서드를 정의하는 인터페이스
+type OIDCService interface {
+	BeginLogin(ctx context.Context, deviceName string, loginHint string) (string, *model.OIDCLoginState, int, error)
+	CompleteLogin(ctx context.Context, loginState *model.OIDCLoginState, code string) (*model.User, string, int, error)
+	CompleteTwoFactorLogin(ctx context.Context, pending *model.OIDCPendingLogin, code *model.TwoFactorCode) (*model.User, string, int, error)
+	GetIdentities(ctx context.Context, userID int) ([]model.UserIdentity, int, error)
+	UnlinkIdentity(ctx context.Context, userID int, identityID int) (int, error)
+}
+
+// oidcService는 OIDCService 인터페이스를 구현하는 구조체
+type oidcService struct {
+	txManager           repository.TxManager
+	identityRepository  repository.IdentityRepository
+	authRepository      repository.AuthRepository
+	twoFactorRepository repository.TwoFactorRepository
+	oidcConfig          config.OIDCConfig
+	authConfig          config.AuthConfig
+	httpClient          *http.Client
+
+	// ID 공급자 설정 (discovery), 처음 사용할 때 가져오며 실패하면 다음 요청에서 다시 시도
+	provider      *oidc.Provider
+	providerMutex sync.Mutex
+}
+
+// NewOIDCService는 OIDCService의 인스턴스를 생성하는 함수
+func NewOIDCService(txManager repository.TxManager, identityRepository repository.IdentityRepository, authRepository repository.AuthRepository, twoFactorRepository repository.TwoFactorRepository, oidcConfig config.OIDCConfig, authConfig config.AuthConfig) OIDCService {
+	return &oidcService{
+		txManager:           txManager,
+		identityRepository:  identityRepository,
+		authRepository:      authRepository,
+		twoFactorRepository: twoFactorRepository,
+		oidcConfig:          oidcConfig,
+		authConfig:          authConfig,
+		httpClient:          &http.Client{Timeout: oidcHTTPTimeout},
+	}
+}
+
+// BeginLogin은 ID 공급자의 로그인 주소와 콜백에서 검증할 로그인 상태(state, nonce, PKCE verifier)를 만드는 메서드
+func (s *oidcService) BeginLogin(ctx context.Context, deviceName string, loginHint string) (string, *model.OIDCLoginState, int, error) {
+	if !s.oidcConfig.Enabled {
+		return "", nil, http.StatusNotFound, errOIDCDisabled
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return "", nil, http.StatusBadGateway, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", nil, http.StatusInternalServerError, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", nil, http.StatusInternalServerError, err
+	}
+	loginState := &model.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		DeviceName:   deviceName,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	options := []oauth2.AuthCodeOption{
+		oidc.Nonce(loginState.Nonce),
+		oauth2.S256ChallengeOption(loginState.CodeVerifier),
+	}
+	if loginHint != "" {
+		options = append(options, oauth2.SetAuthURLParam("login_hint", loginHint))
+	}
+	authURL := s.oauth2Config(provider).AuthCodeURL(loginState.State, options...)
+	return authURL, loginState, http.StatusOK, nil
+}
+
+// CompleteLogin은 인가 코드를 토큰으로 교환하고 ID 토큰을 검증한 뒤 연결 된 사용자로 로그인하는 메서드
+// 연결 된 사용자가 없으면 인증 된 이메일로 기존 사용자에 연결하거나 새로 가입시킴
+// 2단계 인증을 켠 사용자면 JWT 없이 사용자와 ErrTwoFactorRequired를 반환하며, CompleteTwoFactorLogin으로 코드를 확인해야 함
+func (s *oidcService) CompleteLogin(ctx context.Context, loginState *model.OIDCLoginState, code string) (*model.User, string, int, error) {
+	if !s.oidcConfig.Enabled {
+		return nil, "", http.StatusNotFound, errOIDCDisabled
+	}
+	if code == "" {
+		return nil, "", http.StatusBadRequest, errors.New("authorization code is required")
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return nil, "", http.StatusBadGateway, err
+	}
+
+	clientCtx := oidc.ClientContext(ctx, s.httpClient)
+	oauth2Token, err := s.oauth2Config(provider).Exchange(clientCtx, code, oauth2.VerifierOption(loginState.CodeVerifier))
+	if err != nil {
+		return nil, "", http.StatusUnauthorized, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, "", http.StatusUnauthorized, errors.New("token response does not contain an id_token")
+	}
+
+	// 서명, issuer, audience(client_id), 만료 시간 검증
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.oidcConfig.ClientID}).Verify(clientCtx, rawIDToken)
+	if err != nil {
+		return nil, "", http.StatusUnauthorized, fmt.Errorf("invalid id_token: %w", err)
+	}
+	if idToken.Nonce != loginState.Nonce {
+		return nil, "", http.StatusUnauthorized, errors.New("invalid id_token: nonce does not match")
+	}
+
+	var claims model.OIDCClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, "", http.StatusUnauthorized, fmt.Errorf("invalid id_token claims: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, "", http.StatusUnauthorized, errors.New("invalid id_token: subject is missing")
+	}
+
+	user, status, err := s.linkUser(ctx, idToken.Issuer, &claims)
+	if err != nil {
+		return nil, "", status, err
+	}
+	if user.IsDisabled() {
+		return nil, "", http.StatusForbidden, errAccountDisabled
+	}
+
+	// 외부 계정으로 로그인해도 비밀번호 로그인과 같이 2단계 인증을 거쳐야 함
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	if totp.IsEnabled() {
+		return user, "", http.StatusUnauthorized, ErrTwoFactorRequired
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	return user, token, http.StatusOK, nil
+}
+
+// CompleteTwoFactorLogin은 2단계 인증 코드를 기다리는 OIDC 로그인의 코드를 확인하고 JWT 토큰을 발급하는 메서드
+func (s *oidcService) CompleteTwoFactorLogin(ctx context.Context, pending *model.OIDCPendingLogin, code *model.TwoFactorCode) (*model.User, string, int, error) {
+	user, err := s.authRepository.GetUserByID(ctx, pending.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", http.StatusUnauthorized, errors.New("user not found")
+		}
+		return nil, "", http.StatusInternalServerError, err
+	}
+	if user.IsDisabled() {
+		return nil, "", http.StatusForbidden, errAccountDisabled
+	}
+
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	// 그 사이 2단계 인증을 껐으면 코드 없이 로그인
+	if totp.IsEnabled() {
+		if status, err := verifySecondFactor(ctx, s.twoFactorRepository, totp, code, s.authConfig); err != nil {
+			return nil, "", status, err
+		}
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	return user, token, http.StatusOK, nil
+}
+
+// GetIdentities는 사용자에게 연결 된 외부 계정 목록을 반환하는 메서드
+func (s *oidcService) GetIdentities(ctx context.Context, userID int) ([]model.UserIdentity, int, error) {
+	identities, err := s.identityRepository.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return identities, http.StatusOK, nil
+}
+
+// UnlinkIdentity는 사용자에게 연결 된 외부 계정을 해제하는 메서드
+// 비밀번호가 없는 사용자의 마지막 외부 계정은 로그인할 방법이 없어지므로 해제할 수 없음
+func (s *oidcService) UnlinkIdentity(ctx context.Context, userID int, identityID int) (int, error) {
+	status := http.StatusInternalServerError
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		credentials, err := tx.Auth.GetCredentialsByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if credentials == nil {
+			status = http.StatusNotFound
+			return errors.New("user not found")
+		}
+
+		identities, err := tx.Identity.GetIdentitiesByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !credentials.HasPassword() && len(identities) <= 1 {
+			status = http.StatusConflict
+			return errLastSignInMethod
+		}
+
+		err = tx.Identity.DeleteIdentity(ctx, userID, identityID)
+		if errors.Is(err, sql.ErrNoRows) {
+			status = http.StatusNotFound
+			return errors.New("identity not found")
+		}
+		return err
+	})
+	if err != nil {
+		return status, err
+	}
+	return http.StatusOK, nil
+}
+
+// linkUser는 외부 계정에 연결 된 사용자를 찾거나, 연결하거나, 새로 가입시키는 메서드
+func (s *oidcService) linkUser(ctx context.Context, issuer string, claims *model.OIDCClaims) (*model.User, int, error) {
+	var (
+		user   *model.User
+		status = http.StatusInternalServerError
+	)
+	email := claims.VerifiedEmail()
+	now := time.Now().UTC()
+
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		// 1. 이미 연결 된 외부 계정
+		identity, err := tx.Identity.GetIdentity(ctx, issuer, claims.Subject)
+		if err != nil {
+			return err
+		}
+		if identity != nil {
+			if err := tx.Identity.UpdateLastLogin(ctx, identity.ID, email, now); err != nil {
+				return err
+			}
+			user, err = tx.Auth.GetUserByID(ctx, identity.UserID)
+			return err
+		}
+
+		// 2. ID 공급자가 인증한 이메일과 같은 이메일을 인증한 기존 사용자
+		// 인증하지 않은 이메일은 누구나 가입할 때 적을 수 있으므로 연결하지 않음 (먼저 가입한 사람이 외부 계정을 가로챌 수 있음)
+		if email != "" && s.oidcConfig.LinkByEmail {
+			credentials, err := tx.Auth.GetCredentialsByEmail(ctx, email)
+			if err != nil {
+				return err
+			}
+			if credentials != nil && credentials.IsEmailVerified() {
+				user = &credentials.User
+			}
+		}
+
+		// 3. 새로 가입
+		if user == nil {
+			if !s.oidcConfig.AllowSignup {
+				status = http.StatusForbidden
+				return errOIDCAccountNotLinked
+			}
+			user, err = s.createUser(ctx, tx, claims, email)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Identity.CreateIdentity(ctx, &model.UserIdentity{
+			UserID:      user.ID,
+			Issuer:      issuer,
+			Subject:     claims.Subject,
+			Email:       email,
+			LastLoginAt: &now,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return user, http.StatusOK, nil
+}
+
+// createUser는 외부 계정의 클레임으로 비밀번호 없는 사용자를 만드는 메서드
+// 이름이 겹치면 임의의 접미사를 붙이고, 이메일이 이미 사용 중이면 이메일 없이 만듦
+func (s *oidcService) createUser(ctx context.Context, tx *repository.Repositories, claims *model.OIDCClaims, email string) (*model.User, error) {
+	if email != "" {
+		exist, err := tx.Auth.ExistEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			email = ""
+		}
+	}
+
+	baseName := claims.UserNameCandidate()
+	if len(baseName) > oidcUserNameMaxLength {
+		baseName = baseName[:oidcUserNameMaxLength]
+		for !utf8.ValidString(baseName) {
+			baseName = baseName[:len(baseName)-1]
+		}
+	}
+
+	name := baseName
+	for attempt := 0; attempt < oidcUserNameAttempts; attempt++ {
+		exist, err := tx.Auth.ExistUser(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return tx.Auth.CreateUserWithEmail(ctx, name, email)
+		}
+
+		suffix, err := randomString()
+		if err != nil {
+			return nil, err
+		}
+		name = baseName + "-" + suffix[:6]
+	}
+	return nil, errors.New("failed to choose a unique user name")
+}
+
+// getProvider는 ID 공급자 설정을 반환하는 메서드, 아직 가져오지 않았으면 discovery 문서를 가져옴
+func (s *oidcService) getProvider() (*oidc.Provider, error) {
+	s.providerMutex.Lock()
+	defer s.providerMutex.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	// 공급자는 JWKS를 다시 가져올 때도 이 컨텍스트를 사용하므로 요청 컨텍스트를 쓰지 않음
+	provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), s.httpClient), s.oidcConfig.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover identity provider: %w", err)
+	}
+	s.provider = provider
+	return provider, nil
+}
+
+// oauth2Config는 ID 공급자의 엔드포인트로 OAuth2 클라이언트 설정을 만드는 메서드
+func (s *oidcService) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.oidcConfig.ClientID,
+		ClientSecret: s.oidcConfig.ClientSecret,
+		RedirectURL:  s.oidcConfig.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       s.oidcConfig.Scopes,
+	}
+}
+
+// randomString은 state와 nonce에 사용할 임의의 문자열을 만드는 함수
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
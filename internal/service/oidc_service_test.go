@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/database"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+// TestMain은 config.GetConfig가 읽을 .env를 임시 디렉터리에 만들고 그 디렉터리에서 테스트를 실행하는 함수
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "lux-list-service-test")
+	if err != nil {
+		panic(err)
+	}
+	env := "JWT_SECRET=service-test-jwt-secret-0123456789abcdef\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// oidcTest는 테스트 공급자와 sqlite 데이터베이스로 만든 OIDCService
+type oidcTest struct {
+	service  OIDCService
+	provider *testOIDCProvider
+	db       repository.DB
+}
+
+// newTestDB는 임시 디렉터리에 sqlite 데이터베이스를 만들고 마이그레이션하는 함수
+func newTestDB(t *testing.T) repository.DB {
+	t.Helper()
+	sqlDB, err := database.Open(config.PostgresConfig{DRIVER: database.DRIVER_SQLITE, SQLITE_PATH: filepath.Join(t.TempDir(), "lux-list.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	migrator, err := database.NewMigrator(sqlDB, database.DIALECT_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return repository.NewDB(sqlDB, database.DIALECT_SQLITE)
+}
+
+// newOIDCTest는 테스트 공급자와 마이그레이션한 sqlite 데이터베이스로 OIDCService를 만드는 함수
+// configure로 OIDC 설정을 바꿀 수 있음
+func newOIDCTest(t *testing.T, configure func(oidcConfig *config.OIDCConfig)) *oidcTest {
+	t.Helper()
+	db := newTestDB(t)
+	provider := newTestOIDCProvider(t)
+	oidcConfig := config.OIDCConfig{
+		Enabled:      true,
+		Issuer:       provider.issuer(),
+		ClientID:     TEST_OIDC_CLIENT_ID,
+		ClientSecret: TEST_OIDC_CLIENT_SECRET,
+		RedirectURL:  TEST_OIDC_REDIRECT_URL,
+		Scopes:       []string{"openid", "email", "profile"},
+		AllowSignup:  true,
+	}
+	if configure != nil {
+		configure(&oidcConfig)
+	}
+	authConfig := config.AuthConfig{TOTPEncryptionKey: "service-test-totp-key-0123456789abcdef"}
+
+	service := NewOIDCService(repository.NewTxManager(db), repository.NewIdentityRepository(db), repository.NewAuthRepository(db), repository.NewTwoFactorRepository(db), oidcConfig, authConfig)
+	return &oidcTest{service: service, provider: provider, db: db}
+}
+
+// login은 BeginLogin부터 CompleteLogin까지 진행하는 메서드
+// claims는 ID 토큰 클레임을 덮어쓰고, tamper는 콜백 전에 저장 된 로그인 상태를 바꿈
+func (o *oidcTest) login(t *testing.T, claims map[string]interface{}, tamper func(loginState *model.OIDCLoginState)) (*model.User, string, int, error) {
+	t.Helper()
+	ctx := context.Background()
+	authURL, loginState, status, err := o.service.BeginLogin(ctx, "test device", "")
+	if err != nil {
+		t.Fatalf("BeginLogin = (%d, %v)", status, err)
+	}
+	code := o.provider.issueCode(t, authURL, claims)
+	if tamper != nil {
+		tamper(loginState)
+	}
+	return o.service.CompleteLogin(ctx, loginState, code)
+}
+
+func TestOIDCCompleteLogin(t *testing.T) {
+	o := newOIDCTest(t, nil)
+
+	user, token, status, err := o.login(t, nil, nil)
+	if err != nil || status != http.StatusOK || token == "" {
+		t.Fatalf("CompleteLogin = (%d, %v), want a new user and token", status, err)
+	}
+	if user.Email != "sso-user@example.com" || !user.IsEmailVerified() {
+		t.Fatalf("expected new user with the verified email, got %+v", user)
+	}
+	claims, err := auth.ValidateAndParseJWT(token)
+	if err != nil || claims.UserID != user.ID {
+		t.Fatalf("expected JWT for user %d, got %+v (%v)", user.ID, claims, err)
+	}
+
+	// 같은 외부 계정으로 다시 로그인하면 같은 사용자
+	again, _, status, err := o.login(t, nil, nil)
+	if err != nil || again.ID != user.ID {
+		t.Fatalf("expected linked user %d, got %+v (%d, %v)", user.ID, again, status, err)
+	}
+}
+
+func TestOIDCCompleteLoginRejectsInvalidTokens(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims map[string]interface{}
+		tamper func(loginState *model.OIDCLoginState)
+	}{
+		{"nonce mismatch", map[string]interface{}{"nonce": "other-nonce"}, nil},
+		{"missing nonce", map[string]interface{}{"nonce": nil}, nil},
+		{"PKCE verifier mismatch", nil, func(loginState *model.OIDCLoginState) {
+			loginState.CodeVerifier = "other-verifier-0123456789abcdef0123456789abcdef"
+		}},
+		{"wrong issuer", map[string]interface{}{"iss": "https://other-issuer.example.com"}, nil},
+		{"wrong audience", map[string]interface{}{"aud": "other-client"}, nil},
+		{"expired", map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()}, nil},
+		{"missing subject", map[string]interface{}{"sub": nil}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			o := newOIDCTest(t, nil)
+			user, token, status, err := o.login(t, c.claims, c.tamper)
+			if err == nil || status != http.StatusUnauthorized || user != nil || token != "" {
+				t.Fatalf("CompleteLogin = (%+v, %q, %d, %v), want 401", user, token, status, err)
+			}
+			// 거부한 로그인으로 외부 계정을 연결하지 않음
+			identity, err := repository.NewIdentityRepository(o.db).GetIdentity(context.Background(), o.provider.issuer(), "subject-1")
+			if err != nil || identity != nil {
+				t.Fatalf("expected no linked identity, got %+v (%v)", identity, err)
+			}
+		})
+	}
+}
+
+func TestOIDCCompleteLoginLinkByEmail(t *testing.T) {
+	ctx := context.Background()
+	o := newOIDCTest(t, func(oidcConfig *config.OIDCConfig) { oidcConfig.LinkByEmail = true })
+	authRepository := repository.NewAuthRepository(o.db)
+
+	// 이메일을 인증하지 않은 기존 사용자에는 연결하지 않고 새로 가입시킴
+	unverified, err := authRepository.CreateUserWithPassword(ctx, "unverified", "unverified@example.com", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, status, err := o.login(t, map[string]interface{}{"sub": "subject-unverified", "email": "unverified@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("CompleteLogin = (%d, %v)", status, err)
+	}
+	if user.ID == unverified.ID || user.Email != "" {
+		t.Fatalf("must not link to the unverified local account, got %+v", user)
+	}
+
+	// 이메일을 인증한 기존 사용자에는 연결함
+	verified, err := authRepository.CreateUserWithPassword(ctx, "verified", "verified@example.com", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := authRepository.MarkEmailVerified(ctx, verified.ID); err != nil {
+		t.Fatal(err)
+	}
+	user, _, status, err = o.login(t, map[string]interface{}{"sub": "subject-verified", "email": "verified@example.com"}, nil)
+	if err != nil || user.ID != verified.ID {
+		t.Fatalf("expected link to user %d, got %+v (%d, %v)", verified.ID, user, status, err)
+	}
+
+	// ID 공급자가 인증하지 않은 이메일로는 인증한 기존 사용자에도 연결하지 않음
+	user, _, status, err = o.login(t, map[string]interface{}{"sub": "subject-claimed", "email": "verified@example.com", "email_verified": false}, nil)
+	if err != nil || user.ID == verified.ID {
+		t.Fatalf("must not link with an unverified claim, got %+v (%d, %v)", user, status, err)
+	}
+}
+
+func TestOIDCCompleteLoginDisabledAccount(t *testing.T) {
+	o := newOIDCTest(t, nil)
+	user, _, _, err := o.login(t, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disabledAt := time.Now()
+	if err := repository.NewAdminRepository(o.db).SetUserDisabled(context.Background(), user.ID, &disabledAt); err != nil {
+		t.Fatal(err)
+	}
+
+	_, token, status, err := o.login(t, nil, nil)
+	if !errors.Is(err, errAccountDisabled) || status != http.StatusForbidden || token != "" {
+		t.Fatalf("CompleteLogin = (%q, %d, %v), want 403 for a disabled account", token, status, err)
+	}
+}
+
+func TestOIDCCompleteLoginTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	o := newOIDCTest(t, nil)
+	user, _, _, err := o.login(t, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	twoFactorRepository := repository.NewTwoFactorRepository(o.db)
+	if err := twoFactorRepository.CreatePendingTOTP(ctx, user.ID, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := twoFactorRepository.EnableTOTP(ctx, user.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := twoFactorRepository.ReplaceRecoveryCodes(ctx, user.ID, []string{auth.HashRecoveryCode("recovery-code-1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2단계 인증을 켠 사용자는 JWT 없이 보류 됨
+	pendingUser, token, status, err := o.login(t, nil, nil)
+	if !errors.Is(err, ErrTwoFactorRequired) || status != http.StatusUnauthorized || token != "" || pendingUser.ID != user.ID {
+		t.Fatalf("CompleteLogin = (%+v, %q, %d, %v), want ErrTwoFactorRequired", pendingUser, token, status, err)
+	}
+
+	pending := &model.OIDCPendingLogin{ID: "pending", UserID: user.ID}
+	codes := []struct {
+		name   string
+		code   model.TwoFactorCode
+		status int
+	}{
+		{"missing code", model.TwoFactorCode{}, http.StatusUnauthorized},
+		{"wrong recovery code", model.TwoFactorCode{RecoveryCode: "wrong-code"}, http.StatusUnauthorized},
+		{"recovery code", model.TwoFactorCode{RecoveryCode: "recovery-code-1"}, http.StatusOK},
+		{"used recovery code", model.TwoFactorCode{RecoveryCode: "recovery-code-1"}, http.StatusUnauthorized},
+	}
+	for _, c := range codes {
+		_, token, status, err := o.service.CompleteTwoFactorLogin(ctx, pending, &c.code)
+		if status != c.status || (status == http.StatusOK) != (err == nil && token != "") {
+			t.Fatalf("%s: CompleteTwoFactorLogin = (%q, %d, %v), want %d", c.name, token, status, err, c.status)
+		}
+	}
+}
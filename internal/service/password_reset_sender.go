@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+// PasswordResetSender는 비밀번호 재설정 토큰을 사용자에게 전달하는 인터페이스
+type PasswordResetSender interface {
+	SendPasswordReset(ctx context.Context, user *model.User, token string, expiresAt time.Time) error
+}
+
+// logPasswordResetSender는 재설정 요청을 서버 로그에 남기는 PasswordResetSender
+// 로그를 볼 수 있는 사람이 계정을 가로챌 수 없도록 토큰은 남기지 않음
+// 메일 발송 수단이 없는 개발 환경용이며, 운영 환경에서는 메일 발송 구현으로 교체해야 함
+type logPasswordResetSender struct{}
+
+// NewLogPasswordResetSender는 로그 기반 PasswordResetSender의 인스턴스를 생성하는 함수
+func NewLogPasswordResetSender() PasswordResetSender {
+	return &logPasswordResetSender{}
+}
+
+// SendPasswordReset은 재설정 요청을 로그로 출력하는 메서드
+func (s *logPasswordResetSender) SendPasswordReset(ctx context.Context, user *model.User, token string, expiresAt time.Time) error {
+	log.Printf("Password reset requested for user %d (%s), expires_at=%s (no mail sender configured, token not delivered)",
+		user.ID, user.Email, expiresAt.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+)
+
+// PreferencesService는 사용자 설정 관련 메서드를 정의하는 인터페이스
+type PreferencesService interface {
+	GetPreferences(ctx context.Context, userID int) (*model.UserPreferencesResult, int, error)
+	UpdatePreferences(ctx context.Context, userID int, preferences *model.UserPreferences, version int) (*model.UserPreferencesResult, int, error)
+}
+
+// preferencesService는 PreferencesService 인터페이스를 구현하는 구조체
+type preferencesService struct {
+	preferencesRepository repository.PreferencesRepository
+}
+
+// NewPreferencesService는 PreferencesService의 인스턴스를 생성하는 함수
+func NewPreferencesService(preferencesRepository repository.PreferencesRepository) PreferencesService {
+	return &preferencesService{
+		preferencesRepository: preferencesRepository,
+	}
+}
+
+// GetPreferences는 기본값이 적용된 사용자 설정을 조회하는 메서드
+func (s *preferencesService) GetPreferences(ctx context.Context, userID int) (*model.UserPreferencesResult, int, error) {
+	stored, err := s.preferencesRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	result, err := model.NewUserPreferencesResult(stored)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// UpdatePreferences는 사용자 설정 전체를 저장하는 메서드
+// version은 수정 전에 조회한 설정의 버전이며, 그 사이 다른 요청이 먼저 저장했으면 412를 반환
+func (s *preferencesService) UpdatePreferences(ctx context.Context, userID int, preferences *model.UserPreferences, version int) (*model.UserPreferencesResult, int, error) {
+	if err := preferences.CheckValidUserPreferences(); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	document, err := json.Marshal(preferences)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	stored, err := s.preferencesRepository.SavePreferences(ctx, userID, document, version)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return nil, http.StatusPreconditionFailed, err
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &model.UserPreferencesResult{
+		Preferences: *preferences,
+		Version:     stored.Version,
+		UpdatedAt:   &stored.UpdatedAt,
+	}, http.StatusOK, nil
+}
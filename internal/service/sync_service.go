@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/utils"
+)
+
+const (
+	// 한 번에 적용할 수 있는 최대 변경 사항 수
+	MAX_SYNC_MUTATIONS = 500
+)
+
+// SyncService는 오프라인 클라이언트 동기화 관련 메서드를 정의하는 인터페이스
+type SyncService interface {
+	GetChanges(ctx context.Context, userID int, workspaceID int, since string) (*model.SyncChanges, int, error)
+	ApplyMutations(ctx context.Context, userID int, workspaceID int, req *model.SyncRequest) (*model.SyncResponse, int, error)
+}
+
+// syncService는 SyncService 인터페이스를 구현하는 구조체
+type syncService struct {
+	syncRepository repository.SyncRepository
+}
+
+// NewSyncService는 SyncService의 인스턴스를 생성하는 함수
+func NewSyncService(syncRepository repository.SyncRepository) SyncService {
+	return &syncService{
+		syncRepository: syncRepository,
+	}
+}
+
+// GetChanges는 작업 공간의 since 토큰 이후 변경 내역을 조회하는 메서드 (토큰은 작업 공간마다 따로 발급됨)
+// 이전 버전이나 다른 작업 공간의 토큰이면 410을 반환해 클라이언트가 전체 동기화를 다시 하도록 함
+func (s *syncService) GetChanges(ctx context.Context, userID int, workspaceID int, since string) (*model.SyncChanges, int, error) {
+	var seq int64
+	if since != "" {
+		parsed, err := model.ParseSyncToken(since, workspaceID)
+		if err != nil {
+			if errors.Is(err, model.ErrSyncTokenExpired) {
+				return nil, http.StatusGone, err
+			}
+			return nil, http.StatusBadRequest, err
+		}
+		seq = parsed
+	}
+
+	changes, err := s.syncRepository.GetChanges(ctx, userID, workspaceID, seq)
+	if err != nil {
+		return nil, workspaceErrorStatus(err), err
+	}
+	return changes, http.StatusOK, nil
+}
+
+// ApplyMutations는 클라이언트 변경 사항을 순서대로 적용하고 항목 별 결과를 반환하는 메서드
+// 각 변경 사항은 독립적인 트랜잭션으로 적용되며, 하나가 실패해도 나머지는 계속 적용됨
+// updated_at은 서버 시각 이하로 맞추며, SYNC_MAX_CLOCK_SKEW보다 미래인 변경 사항이 있으면 아무것도 적용하지 않고 400을 반환
+func (s *syncService) ApplyMutations(ctx context.Context, userID int, workspaceID int, req *model.SyncRequest) (*model.SyncResponse, int, error) {
+	if len(req.Mutations) == 0 {
+		return nil, http.StatusBadRequest, errors.New("mutations are required")
+	}
+	if len(req.Mutations) > MAX_SYNC_MUTATIONS {
+		return nil, http.StatusBadRequest, errors.New("too many mutations (max " + utils.InterfaceToString(MAX_SYNC_MUTATIONS) + ")")
+	}
+
+	now := time.Now().UTC()
+	for index := range req.Mutations {
+		if err := req.Mutations[index].ClampUpdatedAt(now); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("mutation %d: %w", index, err)
+		}
+	}
+
+	results := make([]model.SyncResult, 0, len(req.Mutations))
+	for index := range req.Mutations {
+		mutation := &req.Mutations[index]
+		result := s.applyMutation(ctx, userID, workspaceID, mutation)
+		result.Index = index
+		results = append(results, *result)
+	}
+
+	seq, err := s.syncRepository.GetChangeSeq(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, workspaceErrorStatus(err), err
+	}
+
+	return &model.SyncResponse{
+		Results:   results,
+		NextToken: model.FormatSyncToken(workspaceID, seq),
+	}, http.StatusOK, nil
+}
+
+// applyMutation은 변경 사항 하나를 엔티티 종류에 맞게 적용하는 메서드
+func (s *syncService) applyMutation(ctx context.Context, userID int, workspaceID int, mutation *model.SyncMutation) *model.SyncResult {
+	failed := func(err error) *model.SyncResult {
+		return &model.SyncResult{
+			Status:   model.SYNC_STATUS_ERROR,
+			Entity:   mutation.Entity,
+			ID:       mutation.ID,
+			ClientID: mutation.ClientID,
+			Error:    err.Error(),
+		}
+	}
+
+	if err := mutation.CheckValidSyncMutation(); err != nil {
+		return failed(err)
+	}
+
+	var (
+		result *model.SyncResult
+		err    error
+	)
+	switch mutation.Entity {
+	case model.SYNC_ENTITY_TASK:
+		result, err = s.syncRepository.ApplyTaskMutation(ctx, userID, workspaceID, mutation)
+	case model.SYNC_ENTITY_TAG:
+		result, err = s.syncRepository.ApplyTagMutation(ctx, userID, workspaceID, mutation)
+	case model.SYNC_ENTITY_TASK_TAG:
+		result, err = s.syncRepository.ApplyTaskTagMutation(ctx, userID, workspaceID, mutation)
+	}
+	if err != nil {
+		return failed(err)
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+)
+
+// TestApplyMutationsClampsClientTime은 시계가 앞선 클라이언트의 updated_at을 서버 시각으로 맞추는지 확인하는 테스트
+func TestApplyMutationsClampsClientTime(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	user, err := repository.NewAuthRepository(db).CreateUser(ctx, "sync-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := repository.NewWorkspaceRepository(db).GetPersonalWorkspace(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := NewSyncService(repository.NewSyncRepository(db))
+
+	apply := func(mutation model.SyncMutation) (*model.SyncResponse, int, error) {
+		return service.ApplyMutations(ctx, user.ID, workspace.ID, &model.SyncRequest{Mutations: []model.SyncMutation{mutation}})
+	}
+	fields := func(title string) map[string]json.RawMessage {
+		return map[string]json.RawMessage{"title": json.RawMessage(`"` + title + `"`), "due_date": json.RawMessage(`"2030-01-01"`)}
+	}
+
+	// 허용 범위를 넘은 미래 시각은 적용하지 않음
+	response, status, err := apply(model.SyncMutation{Entity: model.SYNC_ENTITY_TASK, Op: model.SYNC_OP_UPSERT, ClientID: "far", UpdatedAt: time.Now().Add(model.SYNC_MAX_CLOCK_SKEW + time.Minute), Fields: fields("far future")})
+	if err == nil || status != http.StatusBadRequest || response != nil {
+		t.Fatalf("ApplyMutations = (%+v, %d, %v), want 400", response, status, err)
+	}
+
+	// 허용 범위 안의 미래 시각은 서버 시각으로 기록되어 이후의 수정을 막지 않음
+	response, status, err = apply(model.SyncMutation{Entity: model.SYNC_ENTITY_TASK, Op: model.SYNC_OP_UPSERT, ClientID: "ahead", UpdatedAt: time.Now().Add(model.SYNC_MAX_CLOCK_SKEW / 2), Fields: fields("ahead")})
+	if err != nil || response.Results[0].Status != model.SYNC_STATUS_APPLIED {
+		t.Fatalf("ApplyMutations = (%+v, %d, %v), want applied", response, status, err)
+	}
+	taskID := response.Results[0].ID
+
+	response, status, err = apply(model.SyncMutation{Entity: model.SYNC_ENTITY_TASK, Op: model.SYNC_OP_UPSERT, ID: taskID, UpdatedAt: time.Now(), Fields: map[string]json.RawMessage{"title": json.RawMessage(`"later"`)}})
+	if err != nil || response.Results[0].Status != model.SYNC_STATUS_APPLIED {
+		t.Fatalf("ApplyMutations = (%+v, %d, %v), want the later edit to be applied", response, status, err)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"lux-list/internal/model"
@@ -10,12 +11,12 @@ import (
 
 // TagService는 태그 관련 메서드를 정의하는 인터페이스
 type TagService interface {
-	GetTagsByTagID(userID int, tagID int) (*model.Tag, int, error)
-	GetTagsByUserID(userID int) ([]model.Tag, int, error)
-	GetTagsByTaskID(userID int, taskID int) ([]model.Tag, int, error)
-	CreateTags(userID int, tag *model.Tag) (*model.Tag, int, error)
-	DeleteTags(userID int, tagID int) (int, error)
-	UpdateTags(userID int, tagID int, tag *model.Tag) (*model.Tag, int, error)
+	GetTagsByTagID(ctx context.Context, userID int, workspaceID int, tagID int) (*model.Tag, int, error)
+	GetTagsByWorkspaceID(ctx context.Context, userID int, workspaceID int) ([]model.Tag, int, error)
+	GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, int, error)
+	CreateTags(ctx context.Context, userID int, workspaceID int, tag *model.Tag) (*model.Tag, int, error)
+	DeleteTags(ctx context.Context, userID int, workspaceID int, tagID int, version int) (int, error)
+	UpdateTags(ctx context.Context, userID int, workspaceID int, tagID int, tag *model.Tag) (*model.Tag, int, error)
 }
 
 // tagService는 TagService 인터페이스를 구현하는 구조체
@@ -31,8 +32,8 @@ func NewTagService(tagRepository repository.TagRepository) TagService {
 }
 
 // GetTagsByTagID는 태그 ID로 태그를 조회하는 메서드
-func (s *tagService) GetTagsByTagID(userID int, tagID int) (*model.Tag, int, error) {
-	tag, err := s.tagRepository.GetTagsByTagID(userID, tagID)
+func (s *tagService) GetTagsByTagID(ctx context.Context, userID int, workspaceID int, tagID int) (*model.Tag, int, error) {
+	tag, err := s.tagRepository.GetTagsByTagID(ctx, userID, workspaceID, tagID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, http.StatusNotFound, errors.New("tag not found")
@@ -42,9 +43,9 @@ func (s *tagService) GetTagsByTagID(userID int, tagID int) (*model.Tag, int, err
 	return tag, http.StatusOK, nil
 }
 
-// GetTagsByUserID는 사용자의 모든 태그를 조회하는 메서드
-func (s *tagService) GetTagsByUserID(userID int) ([]model.Tag, int, error) {
-	tags, err := s.tagRepository.GetTagsByUserID(userID)
+// GetTagsByWorkspaceID는 작업 공간의 모든 태그를 조회하는 메서드
+func (s *tagService) GetTagsByWorkspaceID(ctx context.Context, userID int, workspaceID int) ([]model.Tag, int, error) {
+	tags, err := s.tagRepository.GetTagsByWorkspaceID(ctx, userID, workspaceID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -52,40 +53,50 @@ func (s *tagService) GetTagsByUserID(userID int) ([]model.Tag, int, error) {
 }
 
 // GetTagsByTaskID는 특정 작업에 연결된 태그를 조회하는 메서드
-func (s *tagService) GetTagsByTaskID(userID int, taskID int) ([]model.Tag, int, error) {
-	tags, err := s.tagRepository.GetTagsByTaskID(userID, taskID)
+func (s *tagService) GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, int, error) {
+	tags, err := s.tagRepository.GetTagsByTaskID(ctx, userID, workspaceID, taskID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
 	return tags, http.StatusOK, nil
 }
 
-// CreateTags는 사용자의 태그를 생성하는 메서드
-func (s *tagService) CreateTags(userID int, tag *model.Tag) (*model.Tag, int, error) {
-	createdTag, err := s.tagRepository.CreateTags(userID, tag)
+// CreateTags는 작업 공간에 태그를 생성하는 메서드
+func (s *tagService) CreateTags(ctx context.Context, userID int, workspaceID int, tag *model.Tag) (*model.Tag, int, error) {
+	createdTag, err := s.tagRepository.CreateTags(ctx, userID, workspaceID, tag)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := tagErrorStatus(err)
+		return nil, status, err
 	}
 	return createdTag, http.StatusCreated, nil
 }
 
-// DeleteTags는 태그를 삭제하는 메서드
-func (s *tagService) DeleteTags(userID int, tagID int) (int, error) {
-	err := s.tagRepository.DeleteTags(userID, tagID)
+// DeleteTags는 태그를 삭제하는 메서드, version이 0이 아니면 해당 버전일 때만 삭제
+func (s *tagService) DeleteTags(ctx context.Context, userID int, workspaceID int, tagID int, version int) (int, error) {
+	err := s.tagRepository.DeleteTags(ctx, userID, workspaceID, tagID, version)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return http.StatusNotFound, errors.New("tag not found")
-		}
-		return http.StatusInternalServerError, err
+		return tagErrorStatus(err)
 	}
 	return http.StatusNoContent, nil
 }
 
 // UpdateTags는 태그를 업데이트하는 메서드
-func (s *tagService) UpdateTags(userID int, tagID int, tag *model.Tag) (*model.Tag, int, error) {
-	updatedTag, err := s.tagRepository.UpdateTags(userID, tagID, tag)
+func (s *tagService) UpdateTags(ctx context.Context, userID int, workspaceID int, tagID int, tag *model.Tag) (*model.Tag, int, error) {
+	updatedTag, err := s.tagRepository.UpdateTags(ctx, userID, workspaceID, tagID, tag)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := tagErrorStatus(err)
+		return nil, status, err
 	}
 	return updatedTag, http.StatusOK, nil
 }
+
+// tagErrorStatus는 태그 저장소 에러를 HTTP 상태 코드와 사용자 메시지로 변환하는 함수
+func tagErrorStatus(err error) (int, error) {
+	switch err {
+	case sql.ErrNoRows:
+		return http.StatusNotFound, errors.New("tag not found")
+	case repository.ErrVersionConflict:
+		return http.StatusPreconditionFailed, repository.ErrVersionConflict
+	}
+	return workspaceErrorStatus(err), err
+}
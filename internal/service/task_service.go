@@ -1,50 +1,76 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"lux-list/internal/model"
 	"lux-list/internal/repository"
 )
 
+var (
+	// errTagNotFound는 요청한 태그가 없거나 다른 작업 공간의 태그일 때 반환되는 에러
+	errTagNotFound = errors.New("tag not found")
+)
+
 // TaskService는 작업 관련 메서드를 정의하는 인터페이스
 type TaskService interface {
-	GetTasks(userID int, search_query map[string]interface{}) (*model.TaskListResult, int, error)
-	GetTasksByTaskID(userID int, taskID int) (*model.Task, int, error)
-	CreateTasks(userID int, task *model.Task) (*model.Task, int, error)
-	DeleteTasks(userID int, taskID int) (int, error)
-	UpdateTasks(userID int, taskID int, task *model.Task) (*model.Task, int, error)
-	CompleteTasks(userID int, taskID int) (*model.Task, int, error)
-	InCompleteTasks(userID int, taskID int) (*model.Task, int, error)
+	GetTasks(ctx context.Context, userID int, workspaceID int, search_query map[string]interface{}) (*model.TaskListResult, int, error)
+	GetTasksByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) (*model.Task, int, error)
+	GetTaskWorkspaceID(ctx context.Context, taskID int) (int, int, error)
+	CreateTasks(ctx context.Context, userID int, workspaceID int, task *model.Task, tagIDs []int) (*model.Task, int, error)
+	DeleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (int, error)
+	UpdateTasks(ctx context.Context, userID int, workspaceID int, taskID int, task *model.Task) (*model.Task, int, error)
+	CompleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (*model.Task, int, error)
+	InCompleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (*model.Task, int, error)
+	BulkTasks(ctx context.Context, userID int, workspaceID int, req *model.BulkTaskRequest) (*model.BulkTaskResponse, int, error)
+	GetUserLocation(ctx context.Context, userID int) (*time.Location, int, error)
 }
 
 // taskService는 TaskService 인터페이스를 구현하는 구조체
+// 작업의 마감일은 사용자 설정 시간대 기준으로 응답하고, 마감일 필터(today, overdue, due_date)도 같은 시간대로 해석함
 type taskService struct {
-	taskRepository repository.TaskRepository
+	taskRepository        repository.TaskRepository
+	preferencesRepository repository.PreferencesRepository
+	txManager             repository.TxManager
 }
 
 // NewTaskService는 TaskService의 인스턴스를 생성하는 함수
-func NewTaskService(taskRepository repository.TaskRepository) TaskService {
+func NewTaskService(taskRepository repository.TaskRepository, preferencesRepository repository.PreferencesRepository, txManager repository.TxManager) TaskService {
 	return &taskService{
-		taskRepository: taskRepository,
+		taskRepository:        taskRepository,
+		preferencesRepository: preferencesRepository,
+		txManager:             txManager,
 	}
 }
 
-// GetTasks는 사용자의 모든 작업을 조회하는 메서드
-func (s *taskService) GetTasks(userID int, search_query map[string]interface{}) (*model.TaskListResult, int, error) {
-	taskListResult, err := s.taskRepository.GetTasks(userID, search_query)
+// GetTasks는 작업 공간의 모든 작업을 조회하는 메서드
+func (s *taskService) GetTasks(ctx context.Context, userID int, workspaceID int, search_query map[string]interface{}) (*model.TaskListResult, int, error) {
+	loc, status, err := s.GetUserLocation(ctx, userID)
+	if err != nil {
+		return nil, status, err
+	}
+	if err := model.ResolveTaskDueFilters(search_query, loc, time.Now()); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	taskListResult, err := s.taskRepository.GetTasks(ctx, userID, workspaceID, search_query)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	for i := range taskListResult.Tasks {
+		taskListResult.Tasks[i].LocalizeDueDate(loc)
+	}
 
 	return taskListResult, http.StatusOK, nil
 }
 
-// GetTasksByTaskID는 사용자의 특정 작업을 조회하는 메서드
-func (s *taskService) GetTasksByTaskID(userID int, taskID int) (*model.Task, int, error) {
-	task, err := s.taskRepository.GetTasksByTaskID(userID, taskID)
+// GetTasksByTaskID는 작업 공간의 특정 작업을 조회하는 메서드
+func (s *taskService) GetTasksByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) (*model.Task, int, error) {
+	task, err := s.taskRepository.GetTasksByTaskID(ctx, userID, workspaceID, taskID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, http.StatusNotFound, errors.New("task not found")
@@ -52,76 +78,201 @@ func (s *taskService) GetTasksByTaskID(userID int, taskID int) (*model.Task, int
 		return nil, http.StatusInternalServerError, err
 	}
 
-	return task, http.StatusOK, nil
+	return s.localize(ctx, userID, task)
 }
 
-// CreateTasks는 사용자의 작업을 생성하는 매서드
-func (s *taskService) CreateTasks(userID int, task *model.Task) (*model.Task, int, error) {
-	created_task, err := s.taskRepository.CreateTasks(userID, task)
+// GetTaskWorkspaceID는 작업이 속한 작업 공간 ID를 조회하는 메서드, 구성원인지는 확인하지 않으므로 조회 권한 확인에만 사용해야 함
+func (s *taskService) GetTaskWorkspaceID(ctx context.Context, taskID int) (int, int, error) {
+	workspaceID, err := s.taskRepository.GetTaskWorkspaceID(ctx, taskID)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := taskErrorStatus(err)
+		return 0, status, err
 	}
-
-	return created_task, http.StatusCreated, nil
+	return workspaceID, http.StatusOK, nil
 }
 
-// DeleteTasks는 사용자의 작업을 삭제하는 메서드
-func (s *taskService) DeleteTasks(userID int, taskID int) (int, error) {
-	err := s.taskRepository.DeleteTasks(userID, taskID)
+// CreateTasks는 작업 공간에 작업을 생성하는 매서드
+func (s *taskService) CreateTasks(ctx context.Context, userID int, workspaceID int, task *model.Task, tagIDs []int) (*model.Task, int, error) {
+	var createdTask *model.Task
+	// 작업 생성과 태그 연결은 하나의 트랜잭션으로 처리하여 일부만 반영되지 않도록 함
+	err := s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		var err error
+		createdTask, err = tx.Task.CreateTasks(ctx, userID, workspaceID, task)
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			createdTask.Tags = []model.Tag{}
+			return nil
+		}
+
+		for _, tagID := range uniqueIDs(tagIDs) {
+			if _, err := tx.Tag.GetTagsByTagID(ctx, userID, workspaceID, tagID); err != nil {
+				if err == sql.ErrNoRows {
+					return errTagNotFound
+				}
+				return err
+			}
+			if err := tx.TaskTag.AddTagToTask(ctx, userID, workspaceID, createdTask.ID, tagID); err != nil {
+				return err
+			}
+		}
+
+		// 태그 연결 시 작업 버전이 증가하므로 최신 상태를 다시 조회
+		createdTask, err = tx.Task.GetTasksByTaskID(ctx, userID, workspaceID, createdTask.ID)
+		if err != nil {
+			return err
+		}
+		createdTask.Tags, err = tx.TaskTag.GetTagsByTaskID(ctx, userID, workspaceID, createdTask.ID)
+		return err
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return http.StatusNotFound, errors.New("task not found")
+		if err == errTagNotFound {
+			return nil, http.StatusNotFound, err
 		}
-		return http.StatusInternalServerError, err
+		return nil, workspaceErrorStatus(err), err
+	}
+
+	createdTask, status, err := s.localize(ctx, userID, createdTask)
+	if err != nil {
+		return nil, status, err
+	}
+	return createdTask, http.StatusCreated, nil
+}
+
+// DeleteTasks는 작업 공간의 작업을 삭제하는 메서드, version이 0이 아니면 해당 버전일 때만 삭제
+func (s *taskService) DeleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (int, error) {
+	err := s.taskRepository.DeleteTasks(ctx, userID, workspaceID, taskID, version)
+	if err != nil {
+		return taskErrorStatus(err)
 	}
 
 	return http.StatusNoContent, nil
 }
 
-// UpdateTasks는 사용자의 작업을 업데이트하는 메서드
-func (s *taskService) UpdateTasks(userID int, taskID int, task *model.Task) (*model.Task, int, error) {
-	updatedTask, err := s.taskRepository.UpdateTasks(userID, taskID, task)
+// UpdateTasks는 작업 공간의 작업을 업데이트하는 메서드
+func (s *taskService) UpdateTasks(ctx context.Context, userID int, workspaceID int, taskID int, task *model.Task) (*model.Task, int, error) {
+	updatedTask, err := s.taskRepository.UpdateTasks(ctx, userID, workspaceID, taskID, task)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := taskErrorStatus(err)
+		return nil, status, err
 	}
 
-	return updatedTask, http.StatusOK, nil
+	return s.localize(ctx, userID, updatedTask)
+}
+
+// CompleteTasks는 작업 공간의 작업을 완료 상태로 변경하는 메서드, version이 0이 아니면 해당 버전일 때만 변경
+func (s *taskService) CompleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (*model.Task, int, error) {
+	return s.setCompleted(ctx, userID, workspaceID, taskID, version, true)
 }
 
-// CompleteTasks는 사용자의 작업을 완료 상태로 변경하는 메서드
-func (s *taskService) CompleteTasks(userID int, taskID int) (*model.Task, int, error) {
-	task, err := s.taskRepository.GetTasksByTaskID(userID, taskID)
+// InCompleteTasks는 작업 공간의 작업을 완료 상태에서 미완료 상태로 변경하는 메서드, version이 0이 아니면 해당 버전일 때만 변경
+func (s *taskService) InCompleteTasks(ctx context.Context, userID int, workspaceID int, taskID int, version int) (*model.Task, int, error) {
+	return s.setCompleted(ctx, userID, workspaceID, taskID, version, false)
+}
+
+// setCompleted는 작업의 완료 여부를 변경하는 메서드
+func (s *taskService) setCompleted(ctx context.Context, userID int, workspaceID int, taskID int, version int, completed bool) (*model.Task, int, error) {
+	task, err := s.taskRepository.GetTasksByTaskID(ctx, userID, workspaceID, taskID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, http.StatusNotFound, errors.New("task not found")
-		}
-		return nil, http.StatusInternalServerError, err
+		status, err := taskErrorStatus(err)
+		return nil, status, err
+	}
+	if version != 0 && task.Version != version {
+		return nil, http.StatusPreconditionFailed, repository.ErrVersionConflict
 	}
 
-	task.IsCompleted = true
-	updatedTask, err := s.taskRepository.UpdateTasks(userID, taskID, task)
+	task.IsCompleted = completed
+	updatedTask, err := s.taskRepository.UpdateTasks(ctx, userID, workspaceID, taskID, task)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := taskErrorStatus(err)
+		return nil, status, err
 	}
 
-	return updatedTask, http.StatusOK, nil
+	return s.localize(ctx, userID, updatedTask)
 }
 
-// InCompleteTasks는 사용자의 작업을 완료 상태에서 미완료 상태로 변경하는 메서드
-func (s *taskService) InCompleteTasks(userID int, taskID int) (*model.Task, int, error) {
-	task, err := s.taskRepository.GetTasksByTaskID(userID, taskID)
+// BulkTasks는 여러 작업에 같은 변경을 한 번에 적용하는 메서드
+// 찾을 수 없는 작업이 있으면 아무것도 적용하지 않고 422와 항목 별 결과를 반환
+func (s *taskService) BulkTasks(ctx context.Context, userID int, workspaceID int, req *model.BulkTaskRequest) (*model.BulkTaskResponse, int, error) {
+	loc, status, err := s.GetUserLocation(ctx, userID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, http.StatusNotFound, errors.New("task not found")
+		return nil, status, err
+	}
+	var search_query map[string]interface{}
+	if req.Filter != nil {
+		search_query = req.Filter.ToSearchQuery()
+		if err := model.ResolveTaskDueFilters(search_query, loc, time.Now()); err != nil {
+			return nil, http.StatusBadRequest, err
 		}
-		return nil, http.StatusInternalServerError, err
 	}
 
-	task.IsCompleted = false
-	updatedTask, err := s.taskRepository.UpdateTasks(userID, taskID, task)
+	response, err := s.taskRepository.BulkTasks(ctx, userID, workspaceID, req, search_query)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, http.StatusNotFound, errors.New("tag not found")
+		case repository.ErrTooManyBulkTasks:
+			return nil, http.StatusBadRequest, repository.ErrTooManyBulkTasks
+		}
+		return nil, workspaceErrorStatus(err), err
+	}
+
+	if !response.Committed {
+		return response, http.StatusUnprocessableEntity, errors.New("some tasks were not found, no changes were applied")
+	}
+	for _, result := range response.Results {
+		if result.Task != nil {
+			result.Task.LocalizeDueDate(loc)
+		}
+	}
+	return response, http.StatusOK, nil
+}
+
+// GetUserLocation은 사용자 설정의 시간대를 반환하는 메서드, 설정하지 않았으면 기본 시간대(UTC)를 반환
+func (s *taskService) GetUserLocation(ctx context.Context, userID int) (*time.Location, int, error) {
+	stored, err := s.preferencesRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	preferences, err := model.NewUserPreferencesResult(stored)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	return preferences.Preferences.UserLocation(), http.StatusOK, nil
+}
 
-	return updatedTask, http.StatusOK, nil
+// localize는 응답할 작업의 마감일을 사용자 시간대로 바꾸는 메서드
+func (s *taskService) localize(ctx context.Context, userID int, task *model.Task) (*model.Task, int, error) {
+	loc, status, err := s.GetUserLocation(ctx, userID)
+	if err != nil {
+		return nil, status, err
+	}
+	task.LocalizeDueDate(loc)
+	return task, http.StatusOK, nil
+}
+
+// uniqueIDs는 순서를 유지하면서 중복 ID를 제거하는 함수
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// taskErrorStatus는 작업 저장소 에러를 HTTP 상태 코드와 사용자 메시지로 변환하는 함수
+func taskErrorStatus(err error) (int, error) {
+	switch err {
+	case sql.ErrNoRows:
+		return http.StatusNotFound, errors.New("task not found")
+	case repository.ErrVersionConflict:
+		return http.StatusPreconditionFailed, repository.ErrVersionConflict
+	}
+	return workspaceErrorStatus(err), err
 }
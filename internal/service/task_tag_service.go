@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"lux-list/internal/model"
@@ -10,9 +11,9 @@ import (
 
 // TaskTagService는 작업 태그 관련 메서드를 정의하는 인터페이스
 type TaskTagService interface {
-	AddTagToTask(taskID int, tagID int) (int, error)
-	RemoveTagFromTask(taskID int, tagID int) (int, error)
-	GetTagsByTaskID(taskID int) ([]model.Tag, int, error)
+	AddTagToTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) (int, error)
+	RemoveTagFromTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) (int, error)
+	GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, int, error)
 }
 
 // taskTagService는 TaskTagService 인터페이스를 구현하는 구조체
@@ -27,33 +28,36 @@ func NewTaskTagService(taskTagRepository repository.TaskTagRepository) TaskTagSe
 	}
 }
 
-// AddTagToTask는 작업에 태그를 추가하는 메서드
-func (s *taskTagService) AddTagToTask(taskID int, tagID int) (int, error) {
-	err := s.taskTagRepository.AddTagToTask(taskID, tagID)
+// AddTagToTask는 작업에 같은 작업 공간의 태그를 추가하는 메서드
+func (s *taskTagService) AddTagToTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) (int, error) {
+	err := s.taskTagRepository.AddTagToTask(ctx, userID, workspaceID, taskID, tagID)
 	if err != nil {
-		if err == repository.ErrTagAlreadyLinked {
+		switch err {
+		case repository.ErrTagAlreadyLinked:
 			return http.StatusConflict, repository.ErrTagAlreadyLinked
+		case sql.ErrNoRows:
+			return http.StatusNotFound, errors.New("task or tag not found")
 		}
-		return http.StatusInternalServerError, err
+		return workspaceErrorStatus(err), err
 	}
 	return http.StatusCreated, nil
 }
 
 // RemoveTagFromTask는 작업에서 태그를 제거하는 메서드
-func (s *taskTagService) RemoveTagFromTask(taskID int, tagID int) (int, error) {
-	err := s.taskTagRepository.RemoveTagFromTask(taskID, tagID)
+func (s *taskTagService) RemoveTagFromTask(ctx context.Context, userID int, workspaceID int, taskID int, tagID int) (int, error) {
+	err := s.taskTagRepository.RemoveTagFromTask(ctx, userID, workspaceID, taskID, tagID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return http.StatusNotFound, errors.New("tag not found in task")
 		}
-		return http.StatusInternalServerError, err
+		return workspaceErrorStatus(err), err
 	}
 	return http.StatusOK, nil
 }
 
 // GetTagsByTaskID는 특정 작업에 연결된 태그를 조회하는 메서드
-func (s *taskTagService) GetTagsByTaskID(taskID int) ([]model.Tag, int, error) {
-	tags, err := s.taskTagRepository.GetTagsByTaskID(taskID)
+func (s *taskTagService) GetTagsByTaskID(ctx context.Context, userID int, workspaceID int, taskID int) ([]model.Tag, int, error) {
+	tags, err := s.taskTagRepository.GetTagsByTaskID(ctx, userID, workspaceID, taskID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
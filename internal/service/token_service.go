@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+const (
+	// 목록에 표시하는 토큰 앞부분 길이 (접두사 포함)
+	personalAccessTokenDisplayLength = 12
+	// last_used_at을 갱신하는 최소 간격 (요청마다 쓰기가 발생하지 않도록)
+	personalAccessTokenTouchInterval = time.Minute
+)
+
+// errInvalidPersonalAccessToken은 토큰이 없거나 만료 되었을 때 반환되는 에러
+var errInvalidPersonalAccessToken = errors.New("invalid or expired access token")
+
+// TokenService는 개인 액세스 토큰 관련 메서드를 정의하는 인터페이스
+type TokenService interface {
+	GetTokens(ctx context.Context, userID int) ([]model.PersonalAccessToken, int, error)
+	CreateToken(ctx context.Context, userID int, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, int, error)
+	DeleteToken(ctx context.Context, userID int, tokenID int) (int, error)
+	Authenticate(ctx context.Context, rawToken string, ip string) (*model.PersonalAccessToken, int, error)
+}
+
+// tokenService는 TokenService 인터페이스를 구현하는 구조체
+type tokenService struct {
+	tokenRepository repository.TokenRepository
+}
+
+// NewTokenService는 TokenService의 인스턴스를 생성하는 함수
+func NewTokenService(tokenRepository repository.TokenRepository) TokenService {
+	return &tokenService{
+		tokenRepository: tokenRepository,
+	}
+}
+
+// GetTokens는 사용자의 개인 액세스 토큰 목록을 조회하는 메서드
+func (s *tokenService) GetTokens(ctx context.Context, userID int) ([]model.PersonalAccessToken, int, error) {
+	tokens, err := s.tokenRepository.GetTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return tokens, http.StatusOK, nil
+}
+
+// CreateToken은 개인 액세스 토큰을 발급하는 메서드, 토큰 원문은 응답에서 한 번만 반환
+func (s *tokenService) CreateToken(ctx context.Context, userID int, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, int, error) {
+	if err := req.CheckValidCreatePersonalAccessTokenRequest(time.Now()); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	count, err := s.tokenRepository.CountTokens(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if count >= model.PERSONAL_ACCESS_TOKEN_MAX_PER_USER {
+		return nil, http.StatusConflict, errors.New("too many access tokens, revoke unused tokens first")
+	}
+
+	secret, _, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	rawToken := model.PERSONAL_ACCESS_TOKEN_PREFIX + secret
+
+	token, err := s.tokenRepository.CreateToken(ctx, &model.PersonalAccessToken{
+		UserID:      userID,
+		Name:        req.Name,
+		TokenPrefix: rawToken[:personalAccessTokenDisplayLength],
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+	}, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &model.CreatePersonalAccessTokenResponse{
+		PersonalAccessToken: *token,
+		Token:               rawToken,
+	}, http.StatusCreated, nil
+}
+
+// DeleteToken은 개인 액세스 토큰을 폐기하는 메서드
+func (s *tokenService) DeleteToken(ctx context.Context, userID int, tokenID int) (int, error) {
+	if err := s.tokenRepository.DeleteToken(ctx, userID, tokenID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return http.StatusNotFound, errors.New("access token not found")
+		}
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusNoContent, nil
+}
+
+// Authenticate는 Authorization 헤더의 토큰을 검증하고 토큰 정보를 반환하는 메서드
+// 마지막 사용 시각은 personalAccessTokenTouchInterval마다 한 번씩만 기록함
+func (s *tokenService) Authenticate(ctx context.Context, rawToken string, ip string) (*model.PersonalAccessToken, int, error) {
+	if !strings.HasPrefix(rawToken, model.PERSONAL_ACCESS_TOKEN_PREFIX) {
+		return nil, http.StatusUnauthorized, errInvalidPersonalAccessToken
+	}
+
+	token, err := s.tokenRepository.GetTokenByHash(ctx, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusUnauthorized, errInvalidPersonalAccessToken
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	now := time.Now().UTC()
+	if token.IsExpired(now) {
+		return nil, http.StatusUnauthorized, errInvalidPersonalAccessToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= personalAccessTokenTouchInterval || token.LastUsedIP != ip {
+		if err := s.tokenRepository.UpdateLastUsed(ctx, token.ID, now, ip); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		token.LastUsedAt = &now
+		token.LastUsedIP = ip
+	}
+	return token, http.StatusOK, nil
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+var (
+	// ErrTwoFactorRequired는 2단계 인증을 켠 계정에 코드 없이 로그인하려 할 때 반환되는 에러
+	ErrTwoFactorRequired = errors.New("two-factor authentication code is required")
+	// errInvalidTwoFactorCode는 TOTP 코드 또는 복구 코드가 틀렸거나 이미 사용 되었을 때 반환되는 에러
+	errInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+	// errTwoFactorNotEnabled는 2단계 인증을 켜지 않은 계정에 끄기 / 복구 코드 요청을 할 때 반환되는 에러
+	errTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+)
+
+// TwoFactorService는 TOTP 2단계 인증 관련 메서드를 정의하는 인터페이스
+type TwoFactorService interface {
+	GetStatus(ctx context.Context, userID int) (*model.TwoFactorStatusResponse, int, error)
+	Enroll(ctx context.Context, userID int) (*model.TwoFactorEnrollResponse, int, error)
+	Confirm(ctx context.Context, userID int, req *model.ConfirmTwoFactorRequest) ([]string, int, error)
+	Disable(ctx context.Context, userID int, req *model.ReauthenticateRequest) (int, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID int, req *model.ReauthenticateRequest) ([]string, int, error)
+}
+
+// twoFactorService는 TwoFactorService 인터페이스를 구현하는 구조체
+type twoFactorService struct {
+	twoFactorRepository repository.TwoFactorRepository
+	authRepository      repository.AuthRepository
+	txManager           repository.TxManager
+	authConfig          config.AuthConfig
+}
+
+// NewTwoFactorService는 TwoFactorService의 인스턴스를 생성하는 함수
+func NewTwoFactorService(twoFactorRepository repository.TwoFactorRepository, authRepository repository.AuthRepository, txManager repository.TxManager, authConfig config.AuthConfig) TwoFactorService {
+	return &twoFactorService{
+		twoFactorRepository: twoFactorRepository,
+		authRepository:      authRepository,
+		txManager:           txManager,
+		authConfig:          authConfig,
+	}
+}
+
+// GetStatus는 2단계 인증 사용 여부와 남은 복구 코드 수를 반환하는 메서드
+func (s *twoFactorService) GetStatus(ctx context.Context, userID int) (*model.TwoFactorStatusResponse, int, error) {
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !totp.IsEnabled() {
+		return &model.TwoFactorStatusResponse{}, http.StatusOK, nil
+	}
+
+	remaining, err := s.twoFactorRepository.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &model.TwoFactorStatusResponse{
+		Enabled:                true,
+		EnabledAt:              totp.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, http.StatusOK, nil
+}
+
+// Enroll은 새 TOTP secret을 만들어 확인 전 상태로 저장하고 인증 앱 등록 정보를 반환하는 메서드
+// 확인(Confirm) 전까지는 로그인에 사용하지 않으며, 다시 요청하면 secret을 새로 만듦
+func (s *twoFactorService) Enroll(ctx context.Context, userID int) (*model.TwoFactorEnrollResponse, int, error) {
+	credentials, err := s.authRepository.GetCredentialsByID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if credentials == nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+	// 2단계 인증을 끌 때 비밀번호로 다시 인증해야 하므로 비밀번호가 있는 계정만 사용할 수 있음
+	if !credentials.HasPassword() {
+		return nil, http.StatusConflict, errors.New("account has no password, claim the account first")
+	}
+
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if totp.IsEnabled() {
+		return nil, http.StatusConflict, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	secretEncrypted, err := auth.EncryptTOTPSecret(secret, s.authConfig.TOTPEncryptionKey)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := s.twoFactorRepository.CreatePendingTOTP(ctx, userID, secretEncrypted); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	account := credentials.Email
+	if account == "" {
+		account = credentials.Name
+	}
+	return &model.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(s.authConfig.TOTPIssuer, account, secret),
+	}, http.StatusOK, nil
+}
+
+// Confirm은 인증 앱의 코드로 등록을 확인하여 2단계 인증을 켜고 복구 코드를 발급하는 메서드
+// 복구 코드 원문은 이 응답에서 한 번만 반환됨
+func (s *twoFactorService) Confirm(ctx context.Context, userID int, req *model.ConfirmTwoFactorRequest) ([]string, int, error) {
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if totp == nil || totp.IsEnabled() {
+		return nil, http.StatusConflict, repository.ErrTOTPNotPending
+	}
+
+	secret, _, err := auth.DecryptTOTPSecretWithKeys(totp.SecretEncrypted, s.authConfig.TOTPEncryptionKey, s.authConfig.TOTPPreviousEncryptionKeys)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	step, ok := auth.ValidateTOTP(secret, req.TOTPCode, time.Now())
+	if !ok {
+		return nil, http.StatusUnauthorized, errInvalidTwoFactorCode
+	}
+
+	codes, codeHashes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	status := http.StatusInternalServerError
+	err = s.txManager.WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := tx.TwoFactor.EnableTOTP(ctx, userID, step); err != nil {
+			if errors.Is(err, repository.ErrTOTPNotPending) {
+				status = http.StatusConflict
+			}
+			return err
+		}
+		return tx.TwoFactor.ReplaceRecoveryCodes(ctx, userID, codeHashes)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return codes, http.StatusOK, nil
+}
+
+// Disable은 비밀번호와 2단계 인증 코드로 다시 인증한 뒤 2단계 인증을 끄는 메서드
+func (s *twoFactorService) Disable(ctx context.Context, userID int, req *model.ReauthenticateRequest) (int, error) {
+	if status, err := s.reauthenticate(ctx, userID, req); err != nil {
+		return status, err
+	}
+
+	if err := s.twoFactorRepository.DeleteTOTP(ctx, userID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// RegenerateRecoveryCodes는 비밀번호와 2단계 인증 코드로 다시 인증한 뒤 복구 코드를 모두 새로 발급하는 메서드
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int, req *model.ReauthenticateRequest) ([]string, int, error) {
+	if status, err := s.reauthenticate(ctx, userID, req); err != nil {
+		return nil, status, err
+	}
+
+	codes, codeHashes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := s.twoFactorRepository.ReplaceRecoveryCodes(ctx, userID, codeHashes); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return codes, http.StatusOK, nil
+}
+
+// reauthenticate는 비밀번호와 2단계 인증 코드(TOTP 또는 복구 코드)를 확인하는 메서드
+func (s *twoFactorService) reauthenticate(ctx context.Context, userID int, req *model.ReauthenticateRequest) (int, error) {
+	credentials, err := s.authRepository.GetCredentialsByID(ctx, userID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if credentials == nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+	if !credentials.HasPassword() {
+		return http.StatusConflict, errors.New("account has no password, claim the account first")
+	}
+	valid, err := auth.VerifyPassword(req.Password, credentials.PasswordHash)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !valid {
+		return http.StatusForbidden, errors.New("current password is incorrect")
+	}
+
+	totp, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !totp.IsEnabled() {
+		return http.StatusConflict, errTwoFactorNotEnabled
+	}
+	return verifySecondFactor(ctx, s.twoFactorRepository, totp, &req.TwoFactorCode, s.authConfig)
+}
+
+// verifySecondFactor는 2단계 인증을 켠 사용자의 TOTP 코드 또는 복구 코드를 확인하고 사용 처리하는 함수
+// 확인에 성공하면 http.StatusOK와 nil을 반환
+func verifySecondFactor(ctx context.Context, twoFactorRepository repository.TwoFactorRepository, totp *model.UserTOTP, code *model.TwoFactorCode, authConfig config.AuthConfig) (int, error) {
+	if code.IsEmpty() {
+		return http.StatusUnauthorized, ErrTwoFactorRequired
+	}
+
+	if code.RecoveryCode != "" {
+		used, err := twoFactorRepository.UseRecoveryCode(ctx, totp.UserID, auth.HashRecoveryCode(code.RecoveryCode))
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if !used {
+			return http.StatusUnauthorized, errInvalidTwoFactorCode
+		}
+		return http.StatusOK, nil
+	}
+
+	secret, rotated, err := auth.DecryptTOTPSecretWithKeys(totp.SecretEncrypted, authConfig.TOTPEncryptionKey, authConfig.TOTPPreviousEncryptionKeys)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	step, ok := auth.ValidateTOTP(secret, code.TOTPCode, time.Now())
+	if !ok || step <= totp.LastUsedStep {
+		return http.StatusUnauthorized, errInvalidTwoFactorCode
+	}
+	// 같은 코드로 동시에 요청하면 하나만 성공
+	used, err := twoFactorRepository.UseTOTPStep(ctx, totp.UserID, step)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !used {
+		return http.StatusUnauthorized, errInvalidTwoFactorCode
+	}
+
+	// 교체 전 키로 암호화 된 secret은 현재 키로 다시 암호화, 실패해도 교체 전 키로 계속 복호화할 수 있으므로 인증은 성공으로 처리
+	if rotated {
+		if err := reencryptTOTPSecret(ctx, twoFactorRepository, totp, secret, authConfig.TOTPEncryptionKey); err != nil {
+			log.Printf("Failed to re-encrypt totp secret of user %d: %v", totp.UserID, err)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// reencryptTOTPSecret은 TOTP secret을 현재 키로 다시 암호화해 저장하는 함수
+func reencryptTOTPSecret(ctx context.Context, twoFactorRepository repository.TwoFactorRepository, totp *model.UserTOTP, secret string, encryptionKey string) error {
+	secretEncrypted, err := auth.EncryptTOTPSecret(secret, encryptionKey)
+	if err != nil {
+		return err
+	}
+	return twoFactorRepository.UpdateTOTPSecret(ctx, totp.UserID, totp.SecretEncrypted, secretEncrypted)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+	"lux-list/pkg/auth"
+)
+
+// errInvitationNotFound는 초대가 없거나 요청 사용자가 볼 수 없는 초대일 때 반환되는 에러
+var errInvitationNotFound = errors.New("invitation not found")
+
+// WorkspaceInvitationService는 작업 공간 초대 관련 메서드를 정의하는 인터페이스
+type WorkspaceInvitationService interface {
+	GetWorkspaceInvitations(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceInvitation, int, error)
+	CreateInvitation(ctx context.Context, userID int, workspaceID int, req *model.CreateWorkspaceInvitationRequest) (*model.CreateWorkspaceInvitationResponse, int, error)
+	RevokeInvitation(ctx context.Context, userID int, workspaceID int, invitationID int) (*model.WorkspaceInvitation, int, error)
+	GetPendingInvitations(ctx context.Context, userID int) ([]model.WorkspaceInvitation, int, error)
+	RespondInvitation(ctx context.Context, userID int, invitationID int, accept bool) (*model.WorkspaceInvitation, int, error)
+	RespondInvitationByToken(ctx context.Context, userID int, token string, accept bool) (*model.WorkspaceInvitation, int, error)
+}
+
+// workspaceInvitationService는 WorkspaceInvitationService 인터페이스를 구현하는 구조체
+type workspaceInvitationService struct {
+	invitationRepository repository.WorkspaceInvitationRepository
+	workspaceRepository  repository.WorkspaceRepository
+	authRepository       repository.AuthRepository
+	workspaceConfig      config.WorkspaceConfig
+	sender               InvitationSender
+}
+
+// NewWorkspaceInvitationService는 WorkspaceInvitationService의 인스턴스를 생성하는 함수
+func NewWorkspaceInvitationService(invitationRepository repository.WorkspaceInvitationRepository, workspaceRepository repository.WorkspaceRepository, authRepository repository.AuthRepository, workspaceConfig config.WorkspaceConfig, sender InvitationSender) WorkspaceInvitationService {
+	return &workspaceInvitationService{
+		invitationRepository: invitationRepository,
+		workspaceRepository:  workspaceRepository,
+		authRepository:       authRepository,
+		workspaceConfig:      workspaceConfig,
+		sender:               sender,
+	}
+}
+
+// GetWorkspaceInvitations는 작업 공간의 대기 중인 초대 목록을 조회하는 메서드, owner / admin만 가능
+func (s *workspaceInvitationService) GetWorkspaceInvitations(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceInvitation, int, error) {
+	if _, status, err := s.getManagedWorkspace(ctx, userID, workspaceID); err != nil {
+		return nil, status, err
+	}
+	invitations, err := s.invitationRepository.GetWorkspaceInvitations(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return invitations, http.StatusOK, nil
+}
+
+// CreateInvitation은 사용자 이름 또는 이메일로 작업 공간에 초대하고 서명 된 초대 링크를 전달하는 메서드
+// 가입하지 않은 이메일도 초대할 수 있으며, 같은 이메일로 가입한 뒤 수락할 수 있음
+// admin은 admin 역할로 초대할 수 없음
+func (s *workspaceInvitationService) CreateInvitation(ctx context.Context, userID int, workspaceID int, req *model.CreateWorkspaceInvitationRequest) (*model.CreateWorkspaceInvitationResponse, int, error) {
+	if err := req.CheckValidCreateWorkspaceInvitationRequest(); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	workspace, status, err := s.getManagedWorkspace(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, status, err
+	}
+	if workspace.Personal {
+		return nil, http.StatusConflict, errors.New("personal workspace cannot have other members")
+	}
+	if workspace.Role == model.WORKSPACE_ROLE_ADMIN && req.Role == model.WORKSPACE_ROLE_ADMIN {
+		return nil, http.StatusForbidden, repository.ErrWorkspaceAccessDenied
+	}
+
+	invitee, status, err := s.findInvitee(ctx, req)
+	if err != nil {
+		return nil, status, err
+	}
+	if invitee != nil {
+		if invitee.ID == userID {
+			return nil, http.StatusBadRequest, errors.New("cannot invite yourself")
+		}
+		if _, err := s.workspaceRepository.GetMember(ctx, userID, workspaceID, invitee.ID); err == nil {
+			return nil, http.StatusConflict, repository.ErrWorkspaceMemberExists
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	count, err := s.invitationRepository.CountPendingInvitations(ctx, workspaceID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if count >= model.WORKSPACE_INVITATION_MAX_PENDING {
+		return nil, http.StatusConflict, errors.New("too many pending invitations, revoke unused invitations first")
+	}
+
+	invitation := &model.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       req.Email,
+		Role:        req.Role,
+		ExpiresAt:   time.Now().Add(s.workspaceConfig.InvitationTTL).UTC(),
+	}
+	if invitee != nil {
+		invitation.InviteeUserID = &invitee.ID
+	}
+	created, err := s.invitationRepository.CreateInvitation(ctx, userID, invitation)
+	if err != nil {
+		return nil, workspaceErrorStatus(err), err
+	}
+
+	link := s.invitationLink(created)
+	recipient := req.Email
+	if recipient == "" {
+		recipient = invitee.Email
+	}
+	// 메일을 보내지 못해도 초대는 초대 받은 사용자의 초대 목록과 응답의 링크로 전달할 수 있으므로 실패로 처리하지 않음
+	if recipient != "" {
+		if err := s.sender.SendWorkspaceInvitation(ctx, recipient, created, link); err != nil {
+			log.Printf("Failed to send workspace invitation %d: %v", created.ID, err)
+		}
+	}
+
+	return &model.CreateWorkspaceInvitationResponse{Invitation: created, Link: link}, http.StatusCreated, nil
+}
+
+// RevokeInvitation은 대기 중인 초대를 취소하는 메서드, owner / admin만 가능하며 취소한 초대의 링크는 더 이상 사용할 수 없음
+func (s *workspaceInvitationService) RevokeInvitation(ctx context.Context, userID int, workspaceID int, invitationID int) (*model.WorkspaceInvitation, int, error) {
+	if _, status, err := s.getManagedWorkspace(ctx, userID, workspaceID); err != nil {
+		return nil, status, err
+	}
+	invitation, err := s.invitationRepository.RevokeInvitation(ctx, userID, workspaceID, invitationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errors.New("pending invitation not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return invitation, http.StatusOK, nil
+}
+
+// GetPendingInvitations는 사용자가 받은 대기 중인 초대 목록을 조회하는 메서드
+func (s *workspaceInvitationService) GetPendingInvitations(ctx context.Context, userID int) ([]model.WorkspaceInvitation, int, error) {
+	invitations, err := s.invitationRepository.GetPendingInvitations(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return invitations, http.StatusOK, nil
+}
+
+// RespondInvitation은 초대 목록의 초대를 수락하거나 거절하는 메서드
+// 이메일 초대는 같은 이메일을 인증한 사용자만 응답할 수 있으며, 다른 사용자에게 보낸 초대는 존재 여부를 드러내지 않도록 404를 반환
+func (s *workspaceInvitationService) RespondInvitation(ctx context.Context, userID int, invitationID int, accept bool) (*model.WorkspaceInvitation, int, error) {
+	invitation, err := s.invitationRepository.RespondInvitation(ctx, userID, invitationID, accept, false)
+	if errors.Is(err, repository.ErrInvitationNotForUser) {
+		return nil, http.StatusNotFound, errInvitationNotFound
+	}
+	return invitationResponse(invitation, err)
+}
+
+// RespondInvitationByToken은 초대 링크의 서명 된 토큰으로 초대를 수락하거나 거절하는 메서드
+// 링크는 초대 받은 계정(이메일 초대는 같은 이메일의 계정, 이메일 인증 여부와 무관)으로 로그인해야 사용할 수 있음
+func (s *workspaceInvitationService) RespondInvitationByToken(ctx context.Context, userID int, token string, accept bool) (*model.WorkspaceInvitation, int, error) {
+	invitationID, err := auth.VerifyInvitationToken([]byte(s.workspaceConfig.InvitationSigningKey), token, time.Now())
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	invitation, err := s.invitationRepository.RespondInvitation(ctx, userID, invitationID, accept, true)
+	if errors.Is(err, repository.ErrInvitationNotForUser) {
+		return nil, http.StatusForbidden, err
+	}
+	return invitationResponse(invitation, err)
+}
+
+// getManagedWorkspace는 요청 사용자가 owner / admin인 작업 공간을 조회하는 메서드
+func (s *workspaceInvitationService) getManagedWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, int, error) {
+	workspace, err := s.workspaceRepository.GetWorkspace(ctx, userID, workspaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errWorkspaceNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	if !model.CanManageWorkspace(workspace.Role) {
+		return nil, http.StatusForbidden, repository.ErrWorkspaceAccessDenied
+	}
+	return workspace, http.StatusOK, nil
+}
+
+// findInvitee는 초대 받을 사용자를 찾는 메서드
+// 사용자 이름으로 초대했는데 사용자가 없으면 404, 이메일로 초대했는데 그 이메일을 인증한 사용자가 없으면 nil을 반환
+// 인증하지 않은 이메일은 누구나 등록할 수 있으므로 초대를 그 계정에 묶지 않고, 초대 링크를 받은 사람만 응답할 수 있게 함
+func (s *workspaceInvitationService) findInvitee(ctx context.Context, req *model.CreateWorkspaceInvitationRequest) (*model.User, int, error) {
+	if req.Username != "" {
+		user, err := s.authRepository.GetUserByName(ctx, req.Username)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if user == nil || user.DisabledAt != nil {
+			return nil, http.StatusNotFound, errors.New("user not found")
+		}
+		return user, http.StatusOK, nil
+	}
+
+	credentials, err := s.authRepository.GetCredentialsByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if credentials == nil || credentials.DisabledAt != nil || !credentials.IsEmailVerified() {
+		return nil, http.StatusOK, nil
+	}
+	return &credentials.User, http.StatusOK, nil
+}
+
+// invitationLink는 초대의 서명 된 링크를 만드는 메서드, 링크는 초대와 같은 시각에 만료 됨
+func (s *workspaceInvitationService) invitationLink(invitation *model.WorkspaceInvitation) string {
+	token := auth.SignInvitationToken([]byte(s.workspaceConfig.InvitationSigningKey), invitation.ID, invitation.ExpiresAt)
+	return s.workspaceConfig.InvitationURL + "?token=" + url.QueryEscape(token)
+}
+
+// invitationResponse는 초대 응답 결과를 상태 코드와 함께 반환하는 함수
+func invitationResponse(invitation *model.WorkspaceInvitation, err error) (*model.WorkspaceInvitation, int, error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, http.StatusNotFound, errInvitationNotFound
+		case errors.Is(err, repository.ErrInvitationNotPending):
+			return nil, http.StatusConflict, err
+		case errors.Is(err, repository.ErrInvitationExpired):
+			return nil, http.StatusGone, err
+		}
+		return nil, workspaceErrorStatus(err), err
+	}
+	return invitation, http.StatusOK, nil
+}
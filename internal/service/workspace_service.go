@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
+)
+
+var (
+	// errWorkspaceNotFound는 작업 공간이 없거나 요청 사용자가 구성원이 아닐 때 반환되는 에러
+	errWorkspaceNotFound = errors.New("workspace not found")
+	// errWorkspaceMemberNotFound는 작업 공간에 해당 구성원이 없을 때 반환되는 에러
+	errWorkspaceMemberNotFound = errors.New("workspace member not found")
+)
+
+// WorkspaceService는 작업 공간과 구성원 관련 메서드를 정의하는 인터페이스
+type WorkspaceService interface {
+	GetWorkspaces(ctx context.Context, userID int) ([]model.Workspace, int, error)
+	GetWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, int, error)
+	GetPersonalWorkspace(ctx context.Context, userID int) (*model.Workspace, int, error)
+	CreateWorkspace(ctx context.Context, userID int, req *model.CreateWorkspaceRequest) (*model.Workspace, int, error)
+	UpdateWorkspace(ctx context.Context, userID int, workspaceID int, req *model.UpdateWorkspaceRequest) (*model.Workspace, int, error)
+	DeleteWorkspace(ctx context.Context, userID int, workspaceID int) (int, error)
+	GetMembers(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceMember, int, error)
+	UpdateMember(ctx context.Context, userID int, workspaceID int, memberUserID int, req *model.UpdateWorkspaceMemberRequest) (*model.WorkspaceMember, int, error)
+	RemoveMember(ctx context.Context, userID int, workspaceID int, memberUserID int) (int, error)
+}
+
+// workspaceService는 WorkspaceService 인터페이스를 구현하는 구조체
+type workspaceService struct {
+	workspaceRepository repository.WorkspaceRepository
+}
+
+// NewWorkspaceService는 WorkspaceService의 인스턴스를 생성하는 함수
+func NewWorkspaceService(workspaceRepository repository.WorkspaceRepository) WorkspaceService {
+	return &workspaceService{
+		workspaceRepository: workspaceRepository,
+	}
+}
+
+// GetWorkspaces는 사용자가 구성원인 작업 공간 목록을 조회하는 메서드, 개인 작업 공간이 맨 앞에 옴
+func (s *workspaceService) GetWorkspaces(ctx context.Context, userID int) ([]model.Workspace, int, error) {
+	workspaces, err := s.workspaceRepository.GetWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return workspaces, http.StatusOK, nil
+}
+
+// GetWorkspace는 사용자가 구성원인 작업 공간을 조회하는 메서드, 구성원이 아니면 존재 여부를 드러내지 않도록 404를 반환
+func (s *workspaceService) GetWorkspace(ctx context.Context, userID int, workspaceID int) (*model.Workspace, int, error) {
+	workspace, err := s.workspaceRepository.GetWorkspace(ctx, userID, workspaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errWorkspaceNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return workspace, http.StatusOK, nil
+}
+
+// GetPersonalWorkspace는 사용자의 개인 작업 공간을 조회하는 메서드
+func (s *workspaceService) GetPersonalWorkspace(ctx context.Context, userID int) (*model.Workspace, int, error) {
+	workspace, err := s.workspaceRepository.GetPersonalWorkspace(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errWorkspaceNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return workspace, http.StatusOK, nil
+}
+
+// CreateWorkspace는 공유 작업 공간을 만들고 요청 사용자를 owner로 추가하는 메서드
+func (s *workspaceService) CreateWorkspace(ctx context.Context, userID int, req *model.CreateWorkspaceRequest) (*model.Workspace, int, error) {
+	name, err := model.CheckValidWorkspaceName(req.Name)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	count, err := s.workspaceRepository.CountOwnedWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if count >= model.WORKSPACE_MAX_PER_USER {
+		return nil, http.StatusConflict, errors.New("too many workspaces, delete unused workspaces first")
+	}
+
+	workspace, err := s.workspaceRepository.CreateWorkspace(ctx, userID, name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return workspace, http.StatusCreated, nil
+}
+
+// UpdateWorkspace는 작업 공간 이름을 바꾸는 메서드, owner / admin만 가능
+func (s *workspaceService) UpdateWorkspace(ctx context.Context, userID int, workspaceID int, req *model.UpdateWorkspaceRequest) (*model.Workspace, int, error) {
+	name, err := model.CheckValidWorkspaceName(req.Name)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if _, status, err := s.GetWorkspace(ctx, userID, workspaceID); err != nil {
+		return nil, status, err
+	}
+	if err := s.workspaceRepository.UpdateWorkspace(ctx, userID, workspaceID, name); err != nil {
+		return nil, workspaceErrorStatus(err), err
+	}
+	return s.GetWorkspace(ctx, userID, workspaceID)
+}
+
+// DeleteWorkspace는 작업 공간과 그 안의 작업 / 태그를 모두 삭제하는 메서드, owner만 가능하며 개인 작업 공간은 삭제할 수 없음
+func (s *workspaceService) DeleteWorkspace(ctx context.Context, userID int, workspaceID int) (int, error) {
+	workspace, status, err := s.GetWorkspace(ctx, userID, workspaceID)
+	if err != nil {
+		return status, err
+	}
+	if workspace.Personal {
+		return http.StatusConflict, errors.New("personal workspace cannot be deleted")
+	}
+	if err := s.workspaceRepository.DeleteWorkspace(ctx, userID, workspaceID); err != nil {
+		return workspaceErrorStatus(err), err
+	}
+	return http.StatusNoContent, nil
+}
+
+// GetMembers는 작업 공간의 구성원 목록을 조회하는 메서드
+func (s *workspaceService) GetMembers(ctx context.Context, userID int, workspaceID int) ([]model.WorkspaceMember, int, error) {
+	if _, status, err := s.GetWorkspace(ctx, userID, workspaceID); err != nil {
+		return nil, status, err
+	}
+	members, err := s.workspaceRepository.GetMembers(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return members, http.StatusOK, nil
+}
+
+// UpdateMember는 구성원의 역할을 바꾸는 메서드, role이 owner이면 소유권을 이전
+// admin은 member / viewer의 역할만 member / viewer로 바꿀 수 있음
+func (s *workspaceService) UpdateMember(ctx context.Context, userID int, workspaceID int, memberUserID int, req *model.UpdateWorkspaceMemberRequest) (*model.WorkspaceMember, int, error) {
+	if err := model.CheckValidWorkspaceRole(req.Role); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	workspace, member, status, err := s.getWorkspaceAndMember(ctx, userID, workspaceID, memberUserID)
+	if err != nil {
+		return nil, status, err
+	}
+	if memberUserID == userID {
+		return nil, http.StatusBadRequest, errors.New("cannot change your own role")
+	}
+	if member.Role == req.Role {
+		return member, http.StatusOK, nil
+	}
+
+	switch {
+	case req.Role == model.WORKSPACE_ROLE_OWNER:
+		if workspace.Role != model.WORKSPACE_ROLE_OWNER {
+			return nil, http.StatusForbidden, repository.ErrWorkspaceAccessDenied
+		}
+		err = s.workspaceRepository.TransferOwnership(ctx, userID, workspaceID, memberUserID)
+	case workspace.Role == model.WORKSPACE_ROLE_ADMIN && (member.Role == model.WORKSPACE_ROLE_ADMIN || req.Role == model.WORKSPACE_ROLE_ADMIN):
+		return nil, http.StatusForbidden, repository.ErrWorkspaceAccessDenied
+	default:
+		err = s.workspaceRepository.UpdateMemberRole(ctx, userID, workspaceID, memberUserID, req.Role)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, errWorkspaceMemberNotFound
+		}
+		return nil, workspaceErrorStatus(err), err
+	}
+
+	member.Role = req.Role
+	return member, http.StatusOK, nil
+}
+
+// RemoveMember는 구성원을 작업 공간에서 내보내는 메서드, 자기 자신이면 작업 공간을 떠남
+// owner는 떠날 수 없고 먼저 소유권을 이전해야 하며, admin은 member / viewer만 내보낼 수 있음
+func (s *workspaceService) RemoveMember(ctx context.Context, userID int, workspaceID int, memberUserID int) (int, error) {
+	workspace, member, status, err := s.getWorkspaceAndMember(ctx, userID, workspaceID, memberUserID)
+	if err != nil {
+		return status, err
+	}
+
+	if memberUserID == userID {
+		if workspace.Role == model.WORKSPACE_ROLE_OWNER {
+			return http.StatusConflict, errors.New("owner cannot leave the workspace, transfer ownership first")
+		}
+		err = s.workspaceRepository.LeaveWorkspace(ctx, userID, workspaceID)
+	} else {
+		if workspace.Role == model.WORKSPACE_ROLE_ADMIN && member.Role == model.WORKSPACE_ROLE_ADMIN {
+			return http.StatusForbidden, repository.ErrWorkspaceAccessDenied
+		}
+		err = s.workspaceRepository.RemoveMember(ctx, userID, workspaceID, memberUserID)
+	}
+	if err != nil {
+		return workspaceErrorStatus(err), err
+	}
+	return http.StatusNoContent, nil
+}
+
+// getWorkspaceAndMember는 요청 사용자의 작업 공간(역할 포함)과 대상 구성원을 함께 조회하는 메서드
+func (s *workspaceService) getWorkspaceAndMember(ctx context.Context, userID int, workspaceID int, memberUserID int) (*model.Workspace, *model.WorkspaceMember, int, error) {
+	workspace, status, err := s.GetWorkspace(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, nil, status, err
+	}
+	member, err := s.workspaceRepository.GetMember(ctx, userID, workspaceID, memberUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, http.StatusNotFound, errWorkspaceMemberNotFound
+		}
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	return workspace, member, http.StatusOK, nil
+}
+
+// workspaceErrorStatus는 작업 공간 권한 에러를 403으로, 그 밖의 에러를 500으로 변환하는 함수
+func workspaceErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrWorkspaceAccessDenied) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
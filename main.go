@@ -2,14 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"text/tabwriter"
 
 	"lux-list/internal/config"
 	"lux-list/internal/database"
+	"lux-list/internal/model"
+	"lux-list/internal/repository"
 	"lux-list/internal/server"
+	"lux-list/pkg/auth"
 	"lux-list/pkg/redis"
 )
 
@@ -24,14 +31,42 @@ func main() {
 		log.Fatal("Failed to load config")
 	}
 
+	// 마이그레이션 명령 (go run . migrate up|down [n]|status)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		cancel()
+		return
+	}
+
+	// 사용자 역할 변경 명령 (go run . set-role <name> user|admin), 첫 관리자를 지정할 때 사용
+	if len(os.Args) > 1 && os.Args[1] == "set-role" {
+		if err := runSetRole(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Set role failed: %v", err)
+		}
+		cancel()
+		return
+	}
+
+	// 기본값이 없는 비밀 키 설정 확인 (TOTP_ENCRYPTION_KEY, WORKSPACE_INVITATION_SIGNING_KEY)
+	if err := config.ValidateSecrets(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// access token 서명 / 검증 키 로딩 (JWT_ALGORITHM, JWT_SIGNING_KEY_FILE, JWT_VERIFICATION_KEY_FILES)
+	if err := auth.LoadJWTKeys(); err != nil {
+		log.Fatalf("JWT key initialization failed: %v", err)
+	}
+
 	// 데이터베이스 초기화
 	if err := database.InitDB(); err != nil {
 		log.Fatalf("Database initialization failed: %v", err)
 	}
 
-	// Redis 초기화
+	// 인증 세션 저장소 초기화 (SESSION_STORE: redis 또는 memory)
 	if err := redis.InitRedis(ctx); err != nil {
-		log.Fatalf("Redis initialization failed: %v", err)
+		log.Fatalf("Session store initialization failed: %v", err)
 	}
 
 	// 서버 생성
@@ -56,3 +91,99 @@ func main() {
 	cancel()
 	log.Println("Server shutdown complete")
 }
+
+// runMigrate는 migrate 하위 명령을 실행하는 함수
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down [n]|status")
+	}
+
+	if err := database.Connect(); err != nil {
+		return err
+	}
+	migrator, err := database.NewMigrator(database.GetDB(), database.Dialect())
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return errors.New("down steps must be a positive number")
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(writer, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+		}
+		return writer.Flush()
+	}
+	return fmt.Errorf("unknown migrate command: %s", args[0])
+}
+
+// runSetRole은 이름으로 찾은 사용자의 역할을 바꾸고 관리자 작업 기록을 남기는 함수 (기록의 관리자는 비어 있음)
+// 데이터가 프로세스 안에만 있는 memory 드라이버에서는 의미가 없음
+func runSetRole(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: set-role <name> user|admin")
+	}
+	name, role := args[0], args[1]
+	if !model.ValidUserRole(role) {
+		return errors.New("role must be user or admin")
+	}
+
+	if err := database.InitDB(); err != nil {
+		return err
+	}
+	db := repository.NewDB(database.GetDB(), database.Dialect())
+	user, err := repository.NewAuthRepository(db).GetUserByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", name)
+	}
+
+	err = repository.NewTxManager(db).WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Admin.SetUserRole(ctx, user.ID, role); err != nil {
+			return err
+		}
+		return tx.Admin.CreateAuditLog(ctx, &model.AdminAuditLog{
+			TargetUserID: &user.ID,
+			Action:       model.ADMIN_ACTION_ROLE_CHANGE,
+			Detail:       "role=" + role + " (set-role command)",
+		})
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("user %d (%s) role: %s -> %s\n", user.ID, user.Name, user.Role, role)
+	return nil
+}
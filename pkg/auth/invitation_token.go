@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInvitationToken은 초대 토큰의 형식이나 서명이 올바르지 않거나 만료 되었을 때 반환되는 에러
+var ErrInvalidInvitationToken = errors.New("invalid or expired invitation link")
+
+// invitationTokenPurpose는 같은 키로 만든 다른 서명과 섞이지 않도록 서명 대상 앞에 붙이는 값
+const invitationTokenPurpose = "workspace-invitation:"
+
+// SignInvitationToken은 초대 ID와 만료 시각에 HMAC-SHA256 서명을 붙인 초대 토큰을 반환하는 함수
+// 형식: <초대 ID>.<만료 unix 초>.<서명>, 토큰을 따로 저장하지 않고 서명으로 위조 여부를 확인함
+func SignInvitationToken(key []byte, invitationID int, expiresAt time.Time) string {
+	payload := strconv.Itoa(invitationID) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + signInvitationPayload(key, payload)
+}
+
+// VerifyInvitationToken은 초대 토큰의 서명과 만료 시각을 확인하고 초대 ID를 반환하는 함수
+func VerifyInvitationToken(key []byte, token string, now time.Time) (int, error) {
+	payload, signature, ok := cutLast(token, ".")
+	if !ok {
+		return 0, ErrInvalidInvitationToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(signInvitationPayload(key, payload))) {
+		return 0, ErrInvalidInvitationToken
+	}
+
+	idValue, expiresValue, ok := strings.Cut(payload, ".")
+	if !ok {
+		return 0, ErrInvalidInvitationToken
+	}
+	invitationID, err := strconv.Atoi(idValue)
+	if err != nil || invitationID <= 0 {
+		return 0, ErrInvalidInvitationToken
+	}
+	expiresAt, err := strconv.ParseInt(expiresValue, 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return 0, ErrInvalidInvitationToken
+	}
+	return invitationID, nil
+}
+
+// signInvitationPayload는 초대 토큰 본문의 URL에 안전한 서명을 반환하는 함수
+func signInvitationPayload(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(invitationTokenPurpose + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// cutLast는 마지막 sep을 기준으로 문자열을 나누는 함수
+func cutLast(s string, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
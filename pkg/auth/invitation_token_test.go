@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvitationToken(t *testing.T) {
+	key := []byte("invitation-signing-key-0123456789abcdef")
+	now := time.Unix(1700000000, 0)
+	expiresAt := now.Add(time.Hour)
+
+	token := SignInvitationToken(key, 42, expiresAt)
+	if !strings.HasPrefix(token, "42."+strconv.FormatInt(expiresAt.Unix(), 10)+".") {
+		t.Fatalf("unexpected token format %q", token)
+	}
+	if strings.ContainsAny(token, "+/=") {
+		t.Fatalf("token must be URL safe, got %q", token)
+	}
+
+	// 서명은 용도 접두사를 붙인 본문의 HMAC-SHA256
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("workspace-invitation:42." + strconv.FormatInt(expiresAt.Unix(), 10)))
+	if want := base64.RawURLEncoding.EncodeToString(mac.Sum(nil)); !strings.HasSuffix(token, "."+want) {
+		t.Fatalf("expected signature %s in %s", want, token)
+	}
+
+	invitationID, err := VerifyInvitationToken(key, token, now)
+	if err != nil || invitationID != 42 {
+		t.Fatalf("VerifyInvitationToken = (%d, %v), want 42", invitationID, err)
+	}
+
+	payload, signature, _ := cutLast(token, ".")
+	resign := func(payload string) string {
+		return payload + "." + signInvitationPayload(key, payload)
+	}
+	cases := []struct {
+		name  string
+		key   []byte
+		token string
+		now   time.Time
+	}{
+		{"wrong key", []byte("other-signing-key-0123456789abcdef"), token, now},
+		{"empty key", nil, token, now},
+		{"tampered invitation ID", key, "43" + strings.TrimPrefix(token, "42"), now},
+		{"tampered expiry", key, "42." + strconv.FormatInt(expiresAt.Add(time.Hour).Unix(), 10) + "." + signature, now},
+		{"tampered signature", key, payload + "." + strings.ToUpper(signature), now},
+		{"missing signature", key, payload, now},
+		{"empty signature", key, payload + ".", now},
+		{"expired", key, token, expiresAt.Add(time.Second)},
+		{"expires now", key, token, expiresAt},
+		{"non numeric ID", key, resign("abc." + strconv.FormatInt(expiresAt.Unix(), 10)), now},
+		{"zero ID", key, resign("0." + strconv.FormatInt(expiresAt.Unix(), 10)), now},
+		{"negative ID", key, resign("-1." + strconv.FormatInt(expiresAt.Unix(), 10)), now},
+		{"missing expiry", key, resign("42"), now},
+		{"non numeric expiry", key, resign("42.soon"), now},
+		{"other purpose", key, payload + "." + base64.RawURLEncoding.EncodeToString(hmacSHA256(key, payload)), now},
+		{"empty", key, "", now},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := VerifyInvitationToken(c.key, c.token, c.now); !errors.Is(err, ErrInvalidInvitationToken) {
+				t.Fatalf("expected ErrInvalidInvitationToken, got %v", err)
+			}
+		})
+	}
+}
+
+// hmacSHA256은 용도 접두사 없이 payload를 서명하는 함수 (다른 용도의 서명 재사용 확인용)
+func hmacSHA256(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -8,38 +8,47 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var (
-	jwtSecret []byte = []byte(config.GetConfig().JWTSecret)
-)
-
 // TokenClaims는 JWT 토큰의 클레임을 정의하는 구조체
 type TokenClaims struct {
 	UserID int `json:"userID"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken은 JWT 토큰을 생성하는 함수
+// GenerateToken은 JWT 토큰을 생성하는 함수, 유효 시간은 ACCESS_TOKEN_TTL
+// JWT_ALGORITHM으로 서명하며, RS256 / EdDSA면 kid 헤더로 서명 키를 알려줌
 func GenerateJWT(userID int) (string, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return "", err
+	}
+
+	authConfig := config.GetConfig().Auth
 	claims := TokenClaims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 1)), // 1시간 후 만료
+			Issuer:    authConfig.JWTIssuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(authConfig.AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return keys.sign(claims)
 }
 
 // ValidateAndParseJWT은 토큰을 검증하고, 유효하면 데이터를 반환하는 함수
+// 서명 키와 JWT_VERIFICATION_KEY_FILES의 이전 키로 서명 된 토큰을 모두 받아들임
 func ValidateAndParseJWT(tokenString string) (*TokenClaims, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(keys.validMethods()), jwt.WithExpirationRequired()}
+	if issuer := config.GetConfig().Auth.JWTIssuer; issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+
 	// 토큰을 파싱하고 클레임을 추출
-	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("올바르지 않은 요청입니다")
-		}
-		return jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, keys.keyFunc, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"lux-list/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// access token 서명 알고리즘 (JWT_ALGORITHM)
+const (
+	JWT_ALGORITHM_HS256 = "HS256" // JWT_SECRET 공유 키, 다른 서비스는 검증할 수 없음
+	JWT_ALGORITHM_RS256 = "RS256"
+	JWT_ALGORITHM_EDDSA = "EdDSA"
+)
+
+var (
+	// jwtKeys는 설정에서 읽은 서명 / 검증 키, 처음 사용할 때 한 번만 읽음
+	jwtKeys     *JWTKeySet
+	jwtKeysErr  error
+	jwtKeysOnce sync.Once
+)
+
+// JSONWebKey는 JWKS로 공개하는 공개 키 (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// JSONWebKeySet은 /.well-known/jwks.json 응답
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// jwtKey는 kid로 찾는 서명 / 검증 키 하나
+type jwtKey struct {
+	id         string
+	method     jwt.SigningMethod
+	signingKey crypto.PrivateKey // 검증 전용 키면 nil
+	publicKey  crypto.PublicKey
+	jwk        JSONWebKey
+}
+
+// JWTKeySet은 access token 서명 키와 검증 키 목록
+// 키를 교체할 때는 새 키로 서명하고, 이전 키를 JWT_VERIFICATION_KEY_FILES에 남겨 두면
+// 이미 발급한 토큰이 만료될 때까지 계속 검증됨
+type JWTKeySet struct {
+	algorithm    string
+	hmacSecret   []byte
+	signing      *jwtKey
+	verification map[string]*jwtKey
+	order        []string // JWKS 응답 순서 (서명 키가 먼저)
+}
+
+// LoadJWTKeys는 설정에서 JWT 키를 읽는 함수, 서버 시작 시 호출하여 잘못된 키 설정을 바로 알 수 있게 함
+func LoadJWTKeys() error {
+	_, err := getJWTKeys()
+	return err
+}
+
+// getJWTKeys는 설정에서 읽은 JWT 키를 반환하는 함수
+func getJWTKeys() (*JWTKeySet, error) {
+	jwtKeysOnce.Do(func() {
+		cfg := config.GetConfig()
+		jwtKeys, jwtKeysErr = NewJWTKeySet(cfg.Auth, cfg.JWTSecret)
+	})
+	return jwtKeys, jwtKeysErr
+}
+
+// PublicJWKS는 검증에 사용하는 공개 키 목록을 반환하는 함수 (HS256이면 빈 목록)
+func PublicJWKS() (JSONWebKeySet, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+	return keys.JWKS(), nil
+}
+
+// NewJWTKeySet은 서명 알고리즘과 키 파일 설정으로 JWTKeySet을 만드는 함수
+func NewJWTKeySet(authConfig config.AuthConfig, secret string) (*JWTKeySet, error) {
+	keys := &JWTKeySet{
+		algorithm:    authConfig.JWTAlgorithm,
+		verification: make(map[string]*jwtKey),
+	}
+
+	switch authConfig.JWTAlgorithm {
+	case JWT_ALGORITHM_HS256:
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET is required for HS256")
+		}
+		keys.hmacSecret = []byte(secret)
+	case JWT_ALGORITHM_RS256, JWT_ALGORITHM_EDDSA:
+		if authConfig.JWTSigningKeyFile == "" {
+			return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE is required for %s", authConfig.JWTAlgorithm)
+		}
+		key, err := loadJWTKeyFile(authConfig.JWTSigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if key.signingKey == nil {
+			return nil, fmt.Errorf("%s: signing key must be a private key", authConfig.JWTSigningKeyFile)
+		}
+		if key.method.Alg() != authConfig.JWTAlgorithm {
+			return nil, fmt.Errorf("%s: key type does not match JWT_ALGORITHM %s", authConfig.JWTSigningKeyFile, authConfig.JWTAlgorithm)
+		}
+		keys.signing = key
+		keys.add(key)
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM: %s", authConfig.JWTAlgorithm)
+	}
+
+	for _, file := range authConfig.JWTVerificationKeyFiles {
+		key, err := loadJWTKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key.signingKey = nil // 검증에만 사용
+		keys.add(key)
+	}
+	return keys, nil
+}
+
+// JWKS는 검증에 사용하는 공개 키 목록을 반환하는 메서드
+func (k *JWTKeySet) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(k.order))}
+	for _, id := range k.order {
+		set.Keys = append(set.Keys, k.verification[id].jwk)
+	}
+	return set
+}
+
+// add는 검증 키를 추가하는 메서드, 같은 kid가 이미 있으면 무시함
+func (k *JWTKeySet) add(key *jwtKey) {
+	if _, ok := k.verification[key.id]; ok {
+		return
+	}
+	k.verification[key.id] = key
+	k.order = append(k.order, key.id)
+}
+
+// sign은 claims를 서명 키로 서명하는 메서드, 비대칭 키면 kid 헤더를 붙임
+func (k *JWTKeySet) sign(claims jwt.Claims) (string, error) {
+	if k.hmacSecret != nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(k.signing.method, claims)
+	token.Header["kid"] = k.signing.id
+	return token.SignedString(k.signing.signingKey)
+}
+
+// validMethods는 검증할 때 허용하는 알고리즘 목록을 반환하는 메서드
+func (k *JWTKeySet) validMethods() []string {
+	if k.hmacSecret != nil {
+		return []string{JWT_ALGORITHM_HS256}
+	}
+	methods := []string{}
+	seen := map[string]bool{}
+	for _, key := range k.verification {
+		if alg := key.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// keyFunc는 토큰의 알고리즘과 kid 헤더로 검증 키를 찾는 메서드
+func (k *JWTKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	if k.hmacSecret != nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("올바르지 않은 요청입니다")
+		}
+		return k.hmacSecret, nil
+	}
+
+	keyID, _ := token.Header["kid"].(string)
+	key, ok := k.verification[keyID]
+	if !ok || key.method.Alg() != token.Method.Alg() {
+		return nil, errors.New("올바르지 않은 요청입니다")
+	}
+	return key.publicKey, nil
+}
+
+// loadJWTKeyFile은 PEM 파일에서 RSA 또는 Ed25519 키를 읽는 함수
+// 개인 키(PKCS#8, PKCS#1)와 공개 키(PKIX, PKCS#1)를 모두 읽을 수 있으며, kid는 RFC 7638 thumbprint
+func loadJWTKeyFile(path string) (*jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	key, err := newJWTKey(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// newJWTKey는 RSA / Ed25519 키로 jwtKey를 만드는 함수
+func newJWTKey(parsed interface{}) (*jwtKey, error) {
+	key := &jwtKey{}
+	switch typed := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.signingKey = typed
+		key.publicKey = &typed.PublicKey
+	case *rsa.PublicKey:
+		key.publicKey = typed
+	case ed25519.PrivateKey:
+		key.signingKey = typed
+		key.publicKey = typed.Public()
+	case ed25519.PublicKey:
+		key.publicKey = typed
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.N.BitLen() < 2048 {
+			return nil, errors.New("RSA key must be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+		key.jwk = JSONWebKey{
+			KeyType:   "RSA",
+			Algorithm: JWT_ALGORITHM_RS256,
+			N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.jwk = JSONWebKey{
+			KeyType:   "OKP",
+			Algorithm: JWT_ALGORITHM_EDDSA,
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(publicKey),
+		}
+	}
+	key.jwk.Use = "sig"
+	key.id = jwkThumbprint(key.jwk)
+	key.jwk.KeyID = key.id
+	return key, nil
+}
+
+// jwkThumbprint는 공개 키의 RFC 7638 thumbprint(SHA-256, base64url)를 계산하는 함수
+// 필수 멤버만 사전 순으로 직렬화하므로 같은 키는 항상 같은 kid를 가짐
+func jwkThumbprint(jwk JSONWebKey) string {
+	var members interface{}
+	if jwk.KeyType == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	}
+
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
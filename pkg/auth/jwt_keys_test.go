@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lux-list/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWKThumbprintRFCVectors(t *testing.T) {
+	cases := []struct {
+		name string
+		jwk  JSONWebKey
+		want string
+	}{
+		// RFC 7638 3.1
+		{"RFC 7638 RSA", JSONWebKey{
+			KeyType: "RSA",
+			N:       "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			E:       "AQAB",
+		}, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"},
+		// RFC 8037 A.3
+		{"RFC 8037 Ed25519", JSONWebKey{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+		}, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := jwkThumbprint(c.jwk); got != c.want {
+				t.Fatalf("jwkThumbprint = %s, want %s", got, c.want)
+			}
+
+			// 필수 멤버가 아닌 값은 thumbprint에 영향을 주지 않음
+			c.jwk.Use, c.jwk.Algorithm, c.jwk.KeyID = "sig", "ignored", "ignored"
+			if got := jwkThumbprint(c.jwk); got != c.want {
+				t.Fatalf("jwkThumbprint with optional members = %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+// RFC 8037 A.4의 Ed25519 서명 예시
+func TestEdDSARFC8037Signature(t *testing.T) {
+	seed, _ := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	key, err := newJWTKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.jwk.X != "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo" || key.id != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" {
+		t.Fatalf("unexpected public key x=%s kid=%s", key.jwk.X, key.id)
+	}
+
+	const signingString = "eyJhbGciOiJFZERTQSJ9.RXhhbXBsZSBvZiBFZDI1NTE5IHNpZ25pbmc"
+	const want = "hgyY0il_MGCjP0JzlnLWG1PPOt7-09PGcvMg3AIbQR6dWbhijcNR4ki4iylGjg5BhVsPt9g7sVvpAr_MuM0KAg"
+	signature, err := key.method.Sign(signingString, key.signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(signature); got != want {
+		t.Fatalf("signature = %s, want %s", got, want)
+	}
+	if err := key.method.Verify(signingString, signature, key.publicKey); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+// writePEM은 키를 PEM 파일로 저장하고 경로를 반환하는 함수
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), strings.ReplaceAll(strings.ToLower(blockType), " ", "_")+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writePrivateKey는 개인 키를 PKCS#8 PEM 파일로 저장하는 함수
+func writePrivateKey(t *testing.T, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "PRIVATE KEY", der)
+}
+
+// writePublicKey는 공개 키를 PKIX PEM 파일로 저장하는 함수
+func writePublicKey(t *testing.T, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "PUBLIC KEY", der)
+}
+
+// parseWithKeySet은 ValidateAndParseJWT와 같은 옵션으로 토큰을 검증하는 함수
+func parseWithKeySet(keys *JWTKeySet, token string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, keys.keyFunc, jwt.WithValidMethods(keys.validMethods()), jwt.WithExpirationRequired())
+	return claims, err
+}
+
+// testClaims는 expiresIn 뒤에 만료되는 클레임을 만드는 함수
+func testClaims(userID int, expiresIn time.Duration) TokenClaims {
+	return TokenClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+}
+
+// tamper는 토큰의 segment번째 부분의 한 글자를 바꾸는 함수
+func tamper(token string, segment int) string {
+	parts := strings.Split(token, ".")
+	part := []byte(parts[segment])
+	if part[1] == 'A' {
+		part[1] = 'B'
+	} else {
+		part[1] = 'A'
+	}
+	parts[segment] = string(part)
+	return strings.Join(parts, ".")
+}
+
+func TestJWTKeySetSignAndVerify(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		algorithm string
+		keyFile   string
+	}{
+		{"EdDSA", JWT_ALGORITHM_EDDSA, writePrivateKey(t, edKey)},
+		{"RS256", JWT_ALGORITHM_RS256, writePrivateKey(t, rsaKey)},
+		{"RS256 PKCS#1", JWT_ALGORITHM_RS256, writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			keys, err := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: c.algorithm, JWTSigningKeyFile: c.keyFile}, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := keys.sign(testClaims(42, time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &TokenClaims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Header["alg"] != c.algorithm || parsed.Header["kid"] != keys.signing.id {
+				t.Fatalf("unexpected header %v", parsed.Header)
+			}
+
+			claims, err := parseWithKeySet(keys, token)
+			if err != nil || claims.UserID != 42 {
+				t.Fatalf("parse = (%v, %v), want user 42", claims.UserID, err)
+			}
+
+			expired, _ := keys.sign(testClaims(42, -time.Minute))
+			noExpiry, _ := keys.sign(TokenClaims{UserID: 42})
+			invalid := map[string]string{
+				"tampered header":    tamper(token, 0),
+				"tampered payload":   tamper(token, 1),
+				"tampered signature": tamper(token, 2),
+				"expired":            expired,
+				"missing expiry":     noExpiry,
+				"malformed":          "not.a.token",
+			}
+			for name, token := range invalid {
+				if _, err := parseWithKeySet(keys, token); err == nil {
+					t.Errorf("%s: expected an error", name)
+				}
+			}
+
+			jwks := keys.JWKS()
+			if len(jwks.Keys) != 1 || jwks.Keys[0].KeyID != keys.signing.id || jwks.Keys[0].Algorithm != c.algorithm || jwks.Keys[0].Use != "sig" {
+				t.Fatalf("unexpected JWKS %+v", jwks)
+			}
+		})
+	}
+}
+
+func TestJWTKeySetRejectsForeignTokens(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	keys, err := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePrivateKey(t, edKey)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := testClaims(42, time.Minute)
+
+	// 설정에 없는 키로 서명한 토큰
+	unknown := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	unknown.Header["kid"] = "unknown"
+	unknownToken, _ := unknown.SignedString(otherKey)
+
+	// 다른 키로 서명하고 kid만 서명 키로 바꾼 토큰
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	forged.Header["kid"] = keys.signing.id
+	forgedToken, _ := forged.SignedString(otherKey)
+
+	// kid 없는 토큰
+	noKid, _ := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(edKey)
+
+	// 공개 키를 HMAC 비밀 값으로 사용하는 알고리즘 혼동 공격
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	confused.Header["kid"] = keys.signing.id
+	confusedToken, _ := confused.SignedString([]byte(edKey.Public().(ed25519.PublicKey)))
+
+	// 서명 없는 토큰
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	none.Header["kid"] = keys.signing.id
+	noneToken, _ := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	cases := map[string]string{
+		"unknown kid":     unknownToken,
+		"forged kid":      forgedToken,
+		"missing kid":     noKid,
+		"HS256 confusion": confusedToken,
+		"alg none":        noneToken,
+	}
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseWithKeySet(keys, token); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestJWTKeySetRotation(t *testing.T) {
+	oldRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	oldKeys, err := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_RS256, JWTSigningKeyFile: writePrivateKey(t, oldRSA)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, _ := oldKeys.sign(testClaims(7, time.Minute))
+
+	// 이전 키는 공개 키 파일만 남겨도 검증할 수 있고, 같은 키를 두 번 설정해도 한 번만 공개
+	keys, err := NewJWTKeySet(config.AuthConfig{
+		JWTAlgorithm:            JWT_ALGORITHM_EDDSA,
+		JWTSigningKeyFile:       writePrivateKey(t, newKey),
+		JWTVerificationKeyFiles: []string{writePublicKey(t, &oldRSA.PublicKey), writePEM(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&oldRSA.PublicKey))},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims, err := parseWithKeySet(keys, oldToken); err != nil || claims.UserID != 7 {
+		t.Fatalf("token signed with previous key: (%v, %v)", claims.UserID, err)
+	}
+	newToken, _ := keys.sign(testClaims(8, time.Minute))
+	if claims, err := parseWithKeySet(keys, newToken); err != nil || claims.UserID != 8 {
+		t.Fatalf("token signed with new key: (%v, %v)", claims.UserID, err)
+	}
+
+	jwks := keys.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].KeyID != keys.signing.id || jwks.Keys[1].KeyID != oldKeys.signing.id {
+		t.Fatalf("expected signing key first and previous key once, got %+v", jwks)
+	}
+	if keys.verification[oldKeys.signing.id].signingKey != nil {
+		t.Fatal("verification key must not be usable for signing")
+	}
+
+	// 이전 키를 제거하면 이전 토큰은 더 이상 검증되지 않음
+	withoutOld, _ := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePrivateKey(t, newKey)}, "")
+	if _, err := parseWithKeySet(withoutOld, oldToken); err == nil {
+		t.Fatal("token signed with removed key must be rejected")
+	}
+}
+
+func TestNewJWTKeySetErrors(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		config config.AuthConfig
+		secret string
+	}{
+		{"HS256 without secret", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_HS256}, ""},
+		{"unsupported algorithm", config.AuthConfig{JWTAlgorithm: "ES256"}, "secret"},
+		{"missing signing key file", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA}, ""},
+		{"signing key file not found", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: filepath.Join(t.TempDir(), "missing.pem")}, ""},
+		{"not PEM", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePEM(t, "PRIVATE KEY", []byte("garbage"))}, ""},
+		{"unsupported PEM block", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePEM(t, "CERTIFICATE", []byte("garbage"))}, ""},
+		{"public key as signing key", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePublicKey(t, edKey.Public())}, ""},
+		{"algorithm mismatch", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_RS256, JWTSigningKeyFile: writePrivateKey(t, edKey)}, ""},
+		{"RSA key too small", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_RS256, JWTSigningKeyFile: writePrivateKey(t, smallRSA)}, ""},
+		{"invalid verification key", config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_EDDSA, JWTSigningKeyFile: writePrivateKey(t, edKey), JWTVerificationKeyFiles: []string{writePublicKey(t, &smallRSA.PublicKey)}}, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewJWTKeySet(c.config, c.secret); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestJWTKeySetHS256(t *testing.T) {
+	keys, err := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_HS256}, "hs256-test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := keys.sign(testClaims(3, time.Minute))
+	if claims, err := parseWithKeySet(keys, token); err != nil || claims.UserID != 3 {
+		t.Fatalf("parse = (%v, %v), want user 3", claims.UserID, err)
+	}
+
+	other, _ := NewJWTKeySet(config.AuthConfig{JWTAlgorithm: JWT_ALGORITHM_HS256}, "other-secret")
+	if _, err := parseWithKeySet(other, token); err == nil {
+		t.Fatal("token signed with another secret must be rejected")
+	}
+	if len(keys.JWKS().Keys) != 0 {
+		t.Fatal("HS256 must not publish keys")
+	}
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidRefreshToken은 refresh token 형식이 올바르지 않을 때 반환되는 에러
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// GenerateRefreshToken은 세션(토큰 family)에 묶인 refresh token과 저장용 해시를 반환하는 함수
+// 형식: <sessionID>.<임의 값>, 세션 ID로 family를 찾고 해시로 현재 토큰인지 확인함
+func GenerateRefreshToken(sessionID string) (string, string, error) {
+	secret, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	token := sessionID + "." + secret
+	return token, HashOpaqueToken(token), nil
+}
+
+// ParseRefreshToken은 refresh token에서 세션 ID를 꺼내는 함수
+func ParseRefreshToken(token string) (string, error) {
+	sessionID, secret, ok := strings.Cut(token, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return "", ErrInvalidRefreshToken
+	}
+	return sessionID, nil
+}
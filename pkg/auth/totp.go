@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP 파라미터 (RFC 6238, 인증 앱 대부분이 지원하는 기본값)
+const (
+	TOTP_PERIOD    = 30 * time.Second
+	TOTP_DIGITS    = 6
+	totpModulo     = 1000000 // 10^TOTP_DIGITS
+	totpSecretLen  = 20      // 160bit, RFC 4226 권장 길이
+	totpSkewPeriod = 1       // 시계 오차를 고려해 앞뒤로 허용하는 주기 수
+)
+
+// 복구 코드 파라미터
+const (
+	RECOVERY_CODE_COUNT = 10
+	recoveryCodeLen     = 10 // base32 10자리 (50bit), 읽기 쉽도록 5자리씩 '-'로 구분
+)
+
+var (
+	// ErrInvalidTOTPSecret은 저장 된 TOTP secret을 복호화할 수 없을 때 반환되는 에러
+	ErrInvalidTOTPSecret = errors.New("invalid totp secret")
+
+	totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateTOTPSecret은 base32로 인코딩 된 새 TOTP secret을 생성하는 함수
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPURI는 인증 앱에 등록할 otpauth:// URI를 만드는 함수 (QR 코드로 표시)
+func TOTPURI(issuer string, account string, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTP_DIGITS))
+	query.Set("period", fmt.Sprint(int(TOTP_PERIOD.Seconds())))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateTOTP는 code가 now 기준으로 유효한 TOTP인지 확인하고, 일치한 시간 단계(step)를 반환하는 함수
+// 같은 코드를 다시 사용하지 못하도록 호출하는 쪽에서 마지막으로 사용한 step보다 큰지 확인해야 함
+func ValidateTOTP(secret string, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != TOTP_DIGITS {
+		return 0, false
+	}
+
+	current := now.Unix() / int64(TOTP_PERIOD.Seconds())
+	for step := current - totpSkewPeriod; step <= current+totpSkewPeriod; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode는 key와 시간 단계로 TOTP 코드를 계산하는 함수 (RFC 4226 HOTP)
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTP_DIGITS, value%totpModulo)
+}
+
+// GenerateRecoveryCodes는 2단계 인증 복구 코드와 저장용 해시를 생성하는 함수
+func GenerateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, RECOVERY_CODE_COUNT)
+	hashes := make([]string, 0, RECOVERY_CODE_COUNT)
+	for i := 0; i < RECOVERY_CODE_COUNT; i++ {
+		buf := make([]byte, recoveryCodeLen)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(buf))[:recoveryCodeLen]
+		code = code[:recoveryCodeLen/2] + "-" + code[recoveryCodeLen/2:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode는 복구 코드를 정규화(대소문자, 공백, '-' 무시)하여 SHA-256 해시로 반환하는 함수
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return HashOpaqueToken(code)
+}
+
+// EncryptTOTPSecret은 TOTP secret을 key로 암호화하는 함수 (AES-256-GCM, base64)
+// secret은 로그인 검증에 원문이 필요하므로 해시 대신 암호화하여 저장
+func EncryptTOTPSecret(secret string, key string) (string, error) {
+	aead, err := newSecretAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptTOTPSecret은 EncryptTOTPSecret으로 암호화 된 secret을 복호화하는 함수
+func DecryptTOTPSecret(encrypted string, key string) (string, error) {
+	aead, err := newSecretAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidTOTPSecret
+	}
+	secret, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrInvalidTOTPSecret
+	}
+	return string(secret), nil
+}
+
+// DecryptTOTPSecretWithKeys는 현재 키와 교체 전 키들로 차례로 복호화를 시도하는 함수
+// 교체 전 키로 복호화했으면 rotated가 true이며, 호출하는 쪽에서 현재 키로 다시 암호화해 저장해야 함
+func DecryptTOTPSecretWithKeys(encrypted string, key string, previousKeys []string) (secret string, rotated bool, err error) {
+	if secret, err = DecryptTOTPSecret(encrypted, key); err == nil {
+		return secret, false, nil
+	}
+	for _, previousKey := range previousKeys {
+		if secret, err := DecryptTOTPSecret(encrypted, previousKey); err == nil {
+			return secret, true, nil
+		}
+	}
+	return "", false, err
+}
+
+// newSecretAEAD는 설정 된 키 문자열에서 AES-256-GCM 암호기를 만드는 함수
+func newSecretAEAD(key string) (cipher.AEAD, error) {
+	derived := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 부록 B의 SHA-1 시드 "12345678901234567890" (base32)
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// RFC 6238 부록 B의 SHA-1 예시, 8자리 코드의 마지막 6자리 (10^6은 10^8의 약수이므로 6자리 코드와 같음)
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},          // 94287082
+	{1111111109, "081804"},  // 07081804
+	{1111111111, "050471"},  // 14050471
+	{1234567890, "005924"},  // 89005924
+	{2000000000, "279037"},  // 69279037
+	{20000000000, "353130"}, // 65353130
+}
+
+func TestTOTPRFC6238Vectors(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range rfc6238Vectors {
+		t.Run(v.code, func(t *testing.T) {
+			step := v.unix / int64(TOTP_PERIOD.Seconds())
+			if got := totpCode(key, step); got != v.code {
+				t.Fatalf("totpCode(%d) = %s, want %s", step, got, v.code)
+			}
+
+			matched, ok := ValidateTOTP(rfc6238Secret, v.code, time.Unix(v.unix, 0))
+			if !ok || matched != step {
+				t.Fatalf("ValidateTOTP = (%d, %v), want (%d, true)", matched, ok, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / int64(TOTP_PERIOD.Seconds())
+	key, _ := totpEncoding.DecodeString(rfc6238Secret)
+
+	cases := []struct {
+		name   string
+		secret string
+		code   string
+		want   bool
+	}{
+		{"current step", rfc6238Secret, totpCode(key, step), true},
+		{"previous step", rfc6238Secret, totpCode(key, step-1), true},
+		{"next step", rfc6238Secret, totpCode(key, step+1), true},
+		{"two steps ago", rfc6238Secret, totpCode(key, step-2), false},
+		{"two steps ahead", rfc6238Secret, totpCode(key, step+2), false},
+		{"lowercase secret", strings.ToLower(rfc6238Secret), totpCode(key, step), true},
+		{"wrong code", rfc6238Secret, "000000", false},
+		{"short code", rfc6238Secret, totpCode(key, step)[1:], false},
+		{"long code", rfc6238Secret, totpCode(key, step) + "0", false},
+		{"empty code", rfc6238Secret, "", false},
+		{"invalid secret", "not base32!", totpCode(key, step), false},
+		{"other secret", "JBSWY3DPEHPK3PXP", totpCode(key, step), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(c.secret, c.code, now); ok != c.want {
+				t.Fatalf("ValidateTOTP(%q) = %v, want %v", c.code, ok, c.want)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) != totpSecretLen {
+		t.Fatalf("expected %d byte base32 secret, got %q (%v)", totpSecretLen, secret, err)
+	}
+
+	other, _ := GenerateTOTPSecret()
+	if other == secret {
+		t.Fatal("secrets must be random")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Lux List", "alice@example.com", rfc6238Secret)
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" || parsed.Path != "/Lux List:alice@example.com" {
+		t.Fatalf("unexpected label in %s", uri)
+	}
+
+	query := parsed.Query()
+	expected := map[string]string{"secret": rfc6238Secret, "issuer": "Lux List", "algorithm": "SHA1", "digits": "6", "period": "30"}
+	for name, want := range expected {
+		if got := query.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestTOTPSecretEncryption(t *testing.T) {
+	const key = "totp-encryption-key-0123456789abcdef"
+
+	encrypted, err := EncryptTOTPSecret(rfc6238Secret, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(encrypted, rfc6238Secret) {
+		t.Fatal("encrypted secret must not contain the secret")
+	}
+	again, _ := EncryptTOTPSecret(rfc6238Secret, key)
+	if again == encrypted {
+		t.Fatal("encryption must use a random nonce")
+	}
+
+	secret, err := DecryptTOTPSecret(encrypted, key)
+	if err != nil || secret != rfc6238Secret {
+		t.Fatalf("DecryptTOTPSecret = (%q, %v), want %q", secret, err, rfc6238Secret)
+	}
+
+	sealed, _ := base64.RawStdEncoding.DecodeString(encrypted)
+	flip := func(index int) string {
+		tampered := append([]byte(nil), sealed...)
+		tampered[index] ^= 0x01
+		return base64.RawStdEncoding.EncodeToString(tampered)
+	}
+
+	cases := []struct {
+		name      string
+		encrypted string
+		key       string
+	}{
+		{"wrong key", encrypted, key + "x"},
+		{"tampered nonce", flip(0), key},
+		{"tampered ciphertext", flip(len(sealed) / 2), key},
+		{"tampered tag", flip(len(sealed) - 1), key},
+		{"truncated", base64.RawStdEncoding.EncodeToString(sealed[:8]), key},
+		{"not base64", "%%%", key},
+		{"empty", "", key},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := DecryptTOTPSecret(c.encrypted, c.key); !errors.Is(err, ErrInvalidTOTPSecret) {
+				t.Fatalf("expected ErrInvalidTOTPSecret, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecryptTOTPSecretWithKeys(t *testing.T) {
+	const (
+		current  = "current-totp-key-0123456789abcdef"
+		previous = "previous-totp-key-0123456789abcdef"
+	)
+	withCurrent, _ := EncryptTOTPSecret(rfc6238Secret, current)
+	withPrevious, _ := EncryptTOTPSecret(rfc6238Secret, previous)
+
+	cases := []struct {
+		name         string
+		encrypted    string
+		previousKeys []string
+		rotated      bool
+		err          error
+	}{
+		{"current key", withCurrent, []string{previous}, false, nil},
+		{"previous key", withPrevious, []string{"unrelated", previous}, true, nil},
+		{"previous key not configured", withPrevious, nil, false, ErrInvalidTOTPSecret},
+		{"unknown key", withPrevious, []string{"unrelated"}, false, ErrInvalidTOTPSecret},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			secret, rotated, err := DecryptTOTPSecretWithKeys(c.encrypted, current, c.previousKeys)
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("expected %v, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil || secret != rfc6238Secret || rotated != c.rotated {
+				t.Fatalf("got (%q, %v, %v), want (%q, %v, nil)", secret, rotated, err, rfc6238Secret, c.rotated)
+			}
+		})
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != RECOVERY_CODE_COUNT || len(hashes) != RECOVERY_CODE_COUNT {
+		t.Fatalf("expected %d codes, got %d codes and %d hashes", RECOVERY_CODE_COUNT, len(codes), len(hashes))
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("code %q does not match xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+		if hashes[i] != HashRecoveryCode(code) {
+			t.Errorf("hash of %q does not match", code)
+		}
+	}
+
+	// 대소문자, 공백, '-'는 무시
+	for _, variant := range []string{"ABCDE-FGHIJ", " abcdefghij ", "abcde fghij", "abc-de-fghij"} {
+		if HashRecoveryCode(variant) != HashRecoveryCode("abcde-fghij") {
+			t.Errorf("HashRecoveryCode(%q) must match the normalized code", variant)
+		}
+	}
+	if HashRecoveryCode("abcde-fghik") == HashRecoveryCode("abcde-fghij") {
+		t.Error("different codes must not share a hash")
+	}
+}
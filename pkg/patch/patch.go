@@ -0,0 +1,433 @@
+// JSON 문서에 RFC 7396 (JSON Merge Patch)와 RFC 6902 (JSON Patch)를 적용하기 위한 패키지
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	CONTENT_TYPE_MERGE_PATCH = "application/merge-patch+json"
+	CONTENT_TYPE_JSON_PATCH  = "application/json-patch+json"
+)
+
+var (
+	ErrInvalidPatch  = errors.New("invalid patch document")
+	ErrInvalidPath   = errors.New("invalid JSON pointer")
+	ErrPathNotFound  = errors.New("path not found")
+	ErrTestFailed    = errors.New("test operation failed")
+	ErrInvalidTarget = errors.New("patch target is not a container")
+)
+
+// Operation은 RFC 6902 JSON Patch의 연산 하나를 나타내는 구조체
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MergePatch는 문서에 RFC 7396 JSON Merge Patch를 적용한 결과를 반환하는 함수
+func MergePatch(doc []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := decode(doc, &target); err != nil {
+		return nil, err
+	}
+
+	var patchValue interface{}
+	if err := decode(patch, &patchValue); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	return json.Marshal(mergeValue(target, patchValue))
+}
+
+// mergeValue는 RFC 7396 7장의 MergePatch 의사 코드를 구현한 함수
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// JSONPatch는 문서에 RFC 6902 JSON Patch를 적용한 결과를 반환하는 함수
+// 연산은 순서대로 적용되며 하나라도 실패하면 전체가 실패함
+func JSONPatch(doc []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := decode(doc, &target); err != nil {
+		return nil, err
+	}
+
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	for _, operation := range operations {
+		var err error
+		target, err = applyOperation(target, operation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+// applyOperation은 JSON Patch 연산 하나를 적용하는 함수
+func applyOperation(doc interface{}, operation Operation) (interface{}, error) {
+	if operation.Path == nil {
+		return nil, errors.New("operation " + operation.Op + " requires path")
+	}
+	path, err := parsePointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "remove":
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+	case "replace":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := getValue(doc, path); err != nil {
+			return nil, err
+		}
+		return replaceValue(doc, path, value)
+	case "move":
+		from, err := operationFrom(operation)
+		if err != nil {
+			return nil, err
+		}
+		// from 위치는 path의 상위 경로가 될 수 없음
+		if len(from) < len(path) && isPrefix(from, path) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "copy":
+		from, err := operationFrom(operation)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		copied, err := deepCopy(value)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, copied)
+	case "test":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		current, err := getValue(doc, path)
+		if err != nil {
+			return nil, ErrTestFailed
+		}
+		if !jsonEqual(current, value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	}
+
+	return nil, errors.New("unknown operation: " + operation.Op)
+}
+
+// operationValue는 연산의 value 멤버를 디코딩하는 함수
+func operationValue(operation Operation) (interface{}, error) {
+	if operation.Value == nil {
+		return nil, errors.New("operation " + operation.Op + " requires value")
+	}
+	var value interface{}
+	if err := decode(operation.Value, &value); err != nil {
+		return nil, ErrInvalidPatch
+	}
+	return value, nil
+}
+
+// operationFrom은 연산의 from 멤버를 JSON Pointer로 파싱하는 함수
+func operationFrom(operation Operation) ([]string, error) {
+	if operation.From == nil {
+		return nil, errors.New("operation " + operation.Op + " requires from")
+	}
+	return parsePointer(*operation.From)
+}
+
+// parsePointer는 RFC 6901 JSON Pointer 문자열을 토큰 목록으로 변환하는 함수
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPath
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+	return tokens, nil
+}
+
+// getValue는 경로에 위치한 값을 반환하는 함수
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	current := doc
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return current, nil
+}
+
+// addValue는 경로에 값을 추가하는 함수 (배열은 해당 위치에 삽입, "-"는 끝에 추가)
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			index, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		}
+		return nil, ErrInvalidTarget
+	})
+}
+
+// replaceValue는 경로에 위치한 값을 교체하는 함수
+func replaceValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			node[index] = value
+			return node, nil
+		}
+		return nil, ErrInvalidTarget
+	})
+}
+
+// removeValue는 경로에 위치한 값을 제거하고, 제거 된 값을 함께 반환하는 함수
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the document root")
+	}
+
+	var removed interface{}
+	doc, err := updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[index]
+			return append(node[:index], node[index+1:]...), nil
+		}
+		return nil, ErrInvalidTarget
+	})
+	return doc, removed, err
+}
+
+// updateParent는 경로의 부모 컨테이너에 fn을 적용하고, 변경 된 컨테이너를 상위에 다시 연결하는 함수
+// 배열은 길이가 바뀌면 새 슬라이스가 되므로 상위 컨테이너에 다시 대입해야 함
+func updateParent(doc interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+
+	token := path[0]
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		updated, err := updateParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = updated
+		return node, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateParent(node[index], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = updated
+		return node, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// arrayIndex는 배열 인덱스 토큰을 파싱하는 함수, 0 이상 max 이하만 허용
+func arrayIndex(token string, max int) (int, error) {
+	// RFC 6901: 선행 0은 허용되지 않음
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrInvalidPath
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
+
+// isPrefix는 prefix 경로가 path 경로의 상위 경로인지 확인하는 함수
+func isPrefix(prefix []string, path []string) bool {
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonEqual은 RFC 6902 4.6장의 규칙으로 두 JSON 값이 같은지 비교하는 함수 (숫자는 1과 1.0처럼 값이 같으면 같음)
+func jsonEqual(a interface{}, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		xr, xok := new(big.Rat).SetString(x.String())
+		yr, yok := new(big.Rat).SetString(y.String())
+		return xok && yok && xr.Cmp(yr) == 0
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// deepCopy는 JSON 값을 깊은 복사하는 함수
+func deepCopy(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	if err := decode(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// decode는 숫자 정밀도를 유지하도록 json.Number로 디코딩하는 함수
+func decode(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return ErrInvalidPatch
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual은 두 JSON 문서가 같은 값인지 비교하는 함수 (키 순서와 공백은 무시)
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid result %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid expectation %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+// RFC 7396 부록 A의 예시
+func TestMergePatchRFC7396Examples(t *testing.T) {
+	cases := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.target+" + "+c.patch, func(t *testing.T) {
+			got, err := MergePatch([]byte(c.target), []byte(c.patch))
+			if err != nil {
+				t.Fatalf("MergePatch: %v", err)
+			}
+			assertJSONEqual(t, got, c.want)
+		})
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	if _, err := MergePatch([]byte(`{"a":1}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Fatalf("expected ErrInvalidPatch, got %v", err)
+	}
+	if _, err := MergePatch([]byte(`{"a":1}`), []byte(`{"a":1} {"b":2}`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Fatalf("expected ErrInvalidPatch for trailing data, got %v", err)
+	}
+}
+
+// RFC 6902 부록 A의 예시 (A.1 ~ A.16)
+func TestJSONPatchRFC6902Examples(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+		err   error
+	}{
+		{"A.1 adding an object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`, nil},
+		{"A.2 adding an array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`, nil},
+		{"A.3 removing an object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`, nil},
+		{"A.4 removing an array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`, nil},
+		{"A.5 replacing a value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`, nil},
+		{"A.6 moving a value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`, nil},
+		{"A.7 moving an array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`, nil},
+		{"A.8 testing a value: success", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`, nil},
+		{"A.9 testing a value: error", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, "", ErrTestFailed},
+		{"A.10 adding a nested member object", `{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`, nil},
+		{"A.11 ignoring unrecognized elements", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`, `{"foo":"bar","baz":"qux"}`, nil},
+		{"A.12 adding to a nonexistent target", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, "", ErrPathNotFound},
+		{"A.14 ~ escape ordering", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`, nil},
+		{"A.15 comparing strings and numbers", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":"10"}]`, "", ErrTestFailed},
+		{"A.16 adding an array value", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(c.doc), []byte(c.patch))
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("expected %v, got %v (%s)", c.err, err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JSONPatch: %v", err)
+			}
+			assertJSONEqual(t, got, c.want)
+		})
+	}
+}
+
+func TestJSONPatchOperations(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+		err   error
+	}{
+		{"replace root", `{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`, nil},
+		{"replace missing member", `{"a":1}`, `[{"op":"replace","path":"/b","value":2}]`, "", ErrPathNotFound},
+		{"remove missing member", `{"a":1}`, `[{"op":"remove","path":"/b"}]`, "", ErrPathNotFound},
+		{"remove array end", `{"a":[1]}`, `[{"op":"remove","path":"/a/-"}]`, "", ErrPathNotFound},
+		{"add past array end", `{"a":[1]}`, `[{"op":"add","path":"/a/2","value":2}]`, "", ErrPathNotFound},
+		{"add at array end index", `{"a":[1]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2]}`, nil},
+		{"leading zero index", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/01"}]`, "", ErrInvalidPath},
+		{"pointer without slash", `{"a":1}`, `[{"op":"remove","path":"a"}]`, "", ErrInvalidPath},
+		{"copy is deep", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, `{"a":{"b":1},"c":{"b":2}}`, nil},
+		{"test numbers numerically", `{"a":1}`, `[{"op":"test","path":"/a","value":1.0}]`, `{"a":1}`, nil},
+		{"test missing path", `{"a":1}`, `[{"op":"test","path":"/b","value":1}]`, "", ErrTestFailed},
+		{"add into scalar", `{"a":1}`, `[{"op":"add","path":"/a/b","value":1}]`, "", ErrInvalidTarget},
+		{"failed operation aborts patch", `{"a":1}`, `[{"op":"add","path":"/b","value":2},{"op":"test","path":"/a","value":2}]`, "", ErrTestFailed},
+		{"not an array", `{"a":1}`, `{"op":"add","path":"/b","value":2}`, "", ErrInvalidPatch},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(c.doc), []byte(c.patch))
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("expected %v, got %v (%s)", c.err, err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JSONPatch: %v", err)
+			}
+			assertJSONEqual(t, got, c.want)
+		})
+	}
+}
+
+func TestJSONPatchInvalidOperations(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch string
+	}{
+		{"unknown op", `[{"op":"merge","path":"/a","value":1}]`},
+		{"missing path", `[{"op":"add","value":1}]`},
+		{"missing value", `[{"op":"add","path":"/b"}]`},
+		{"missing from", `[{"op":"move","path":"/b"}]`},
+		{"move into child", `[{"op":"move","from":"/a","path":"/a/b"}]`},
+		{"remove root", `[{"op":"remove","path":""}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got, err := JSONPatch([]byte(`{"a":{}}`), []byte(c.patch)); err == nil {
+				t.Fatalf("expected an error, got %s", got)
+			}
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{`1`, `1.0`, true},
+		{`1e2`, `100`, true},
+		{`0.1`, `0.10`, true},
+		{`1`, `2`, false},
+		{`1`, `"1"`, false},
+		{`{"a":[1,{"b":2}]}`, `{"a":[1.0,{"b":2e0}]}`, true},
+		{`{"a":1}`, `{"a":1,"b":null}`, false},
+		{`[1,2]`, `[2,1]`, false},
+		{`null`, `null`, true},
+		{`true`, `false`, false},
+	}
+
+	for _, c := range cases {
+		var a, b interface{}
+		if err := decode([]byte(c.a), &a); err != nil {
+			t.Fatal(err)
+		}
+		if err := decode([]byte(c.b), &b); err != nil {
+			t.Fatal(err)
+		}
+		if got := jsonEqual(a, b); got != c.want {
+			t.Errorf("jsonEqual(%s, %s) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -14,10 +14,6 @@ import (
 
 type AuthRedisClient = redis.Client
 
-const (
-	authSessionKey = "auth_session:"
-)
-
 var (
 	// auth_redis는 Redis 클라이언트 인스턴스
 	auth_redis *AuthRedisClient
@@ -57,36 +53,93 @@ func GetAuthRedis(ctx context.Context) (*AuthRedisClient, error) {
 	return auth_redis, nil
 }
 
-// SetAuthSession은 Redis에 인증 세션을 저장하는 함수
-func SetAuthSession(ctx context.Context, key interface{}, value interface{}) error {
-	set_key := authSessionKey + utils.InterfaceToString(key)
+// redisSessionStore는 AuthSessionStore 인터페이스를 Redis로 구현하는 구조체
+type redisSessionStore struct {
+	client *AuthRedisClient
+}
 
-	// TTL 1시간
-	err := auth_redis.Set(ctx, set_key, value, time.Hour).Err()
-	if err != nil {
-		return err
+// NewRedisSessionStore는 Redis 세션 저장소를 생성하는 함수
+func NewRedisSessionStore(client *AuthRedisClient) AuthSessionStore {
+	return &redisSessionStore{
+		client: client,
 	}
-	return nil
 }
 
-// DeleteAuthSession은 Redis에서 인증 세션을 삭제하는 함수
-func DeleteAuthSession(ctx context.Context, key interface{}) error {
-	set_key := authSessionKey + utils.InterfaceToString(key)
+// Set은 Redis에 key와 value를 ttl 동안 저장하는 메서드
+func (s *redisSessionStore) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if ttl == KEEP_TTL {
+		ttl = redis.KeepTTL
+	}
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
 
-	err := auth_redis.Del(ctx, set_key).Err()
+// compareAndSwapScript는 값이 ARGV[1]일 때만 ARGV[2]로 바꾸는 스크립트 (ARGV[3]: TTL 밀리초, 0 이하면 기존 TTL 유지)
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+end
+return 1
+`)
+
+// CompareAndSwap은 Redis에서 key의 값이 old일 때만 value로 바꾸는 메서드
+func (s *redisSessionStore) CompareAndSwap(ctx context.Context, key string, old string, value string, ttl time.Duration) (bool, error) {
+	swapped, err := compareAndSwapScript.Run(ctx, s.client, []string{key}, old, value, ttl.Milliseconds()).Int()
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+	return swapped == 1, nil
 }
 
-// GetAuthSession은 Redis에서 인증 세션을 가져오는 함수
-func GetAuthSession(ctx context.Context, key interface{}) (string, error) {
-	set_key := authSessionKey + utils.InterfaceToString(key)
-
-	val, err := auth_redis.Get(ctx, set_key).Result()
+// Get은 Redis에서 key의 값을 가져오는 메서드, 없으면 ErrSessionNotFound를 반환
+func (s *redisSessionStore) Get(ctx context.Context, key string) (string, error) {
+	val, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrSessionNotFound
+	}
 	if err != nil {
 		return "", err
 	}
 	return val, nil
 }
+
+// Delete는 Redis에서 keys를 삭제하는 메서드
+func (s *redisSessionStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.client.Del(ctx, keys...).Err()
+}
+
+// AddToSet은 Redis 집합에 member를 추가하고 만료 시간을 갱신하는 메서드
+func (s *redisSessionStore) AddToSet(ctx context.Context, key string, member string, ttl time.Duration) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, member)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// RemoveFromSet은 Redis 집합에서 members를 삭제하는 메서드
+func (s *redisSessionStore) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	return s.client.SRem(ctx, key, values...).Err()
+}
+
+// SetMembers는 Redis 집합의 모든 member를 반환하는 메서드
+func (s *redisSessionStore) SetMembers(ctx context.Context, key string) ([]string, error) {
+	return s.client.SMembers(ctx, key).Result()
+}
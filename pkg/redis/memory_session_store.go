@@ -0,0 +1,197 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 만료 된 세션을 정리하는 주기
+const memorySessionSweepInterval = time.Minute
+
+// memorySessionEntry는 메모리 세션 저장소의 값과 만료 시각
+// 문자열 값이면 value, 집합이면 members를 사용
+type memorySessionEntry struct {
+	value     string
+	members   map[string]struct{}
+	expiresAt time.Time // 0이면 만료 없음
+}
+
+// memorySessionStore는 AuthSessionStore 인터페이스를 프로세스 메모리로 구현하는 구조체
+// 인스턴스끼리 세션을 공유하지 않으므로 단일 인스턴스 배포에서만 사용해야 함
+type memorySessionStore struct {
+	mutex   sync.Mutex
+	entries map[string]*memorySessionEntry
+	now     func() time.Time
+}
+
+// NewMemorySessionStore는 메모리 세션 저장소를 생성하는 함수
+// ctx가 취소될 때까지 만료 된 세션을 주기적으로 정리함
+func NewMemorySessionStore(ctx context.Context) AuthSessionStore {
+	store := &memorySessionStore{
+		entries: make(map[string]*memorySessionEntry),
+		now:     time.Now,
+	}
+	go store.sweepLoop(ctx)
+	return store
+}
+
+// Set은 key에 value를 ttl 동안 저장하는 메서드
+// ttl이 0이면 만료되지 않고, KEEP_TTL이면 기존 만료 시간을 유지함
+func (s *memorySessionStore) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := &memorySessionEntry{value: value}
+	switch {
+	case ttl == KEEP_TTL:
+		if previous := s.lookup(key); previous != nil {
+			entry.expiresAt = previous.expiresAt
+		}
+	case ttl > 0:
+		entry.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// CompareAndSwap은 key의 값이 old일 때만 value로 바꾸는 메서드
+func (s *memorySessionStore) CompareAndSwap(ctx context.Context, key string, old string, value string, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.lookup(key)
+	if previous == nil || previous.members != nil || previous.value != old {
+		return false, nil
+	}
+
+	entry := &memorySessionEntry{value: value}
+	switch {
+	case ttl == KEEP_TTL:
+		entry.expiresAt = previous.expiresAt
+	case ttl > 0:
+		entry.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return true, nil
+}
+
+// Get은 key의 값을 가져오는 메서드, 없거나 만료 되었으면 ErrSessionNotFound를 반환
+func (s *memorySessionStore) Get(ctx context.Context, key string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := s.lookup(key)
+	if entry == nil || entry.members != nil {
+		return "", ErrSessionNotFound
+	}
+	return entry.value, nil
+}
+
+// Delete는 keys의 값을 삭제하는 메서드
+func (s *memorySessionStore) Delete(ctx context.Context, keys ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// AddToSet은 key 집합에 member를 추가하고 만료 시간을 ttl로 갱신하는 메서드
+func (s *memorySessionStore) AddToSet(ctx context.Context, key string, member string, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := s.lookup(key)
+	if entry == nil || entry.members == nil {
+		entry = &memorySessionEntry{members: make(map[string]struct{})}
+		s.entries[key] = entry
+	}
+	entry.members[member] = struct{}{}
+	if ttl > 0 {
+		entry.expiresAt = s.now().Add(ttl)
+	}
+	return nil
+}
+
+// RemoveFromSet은 key 집합에서 members를 삭제하는 메서드, 집합이 비면 key도 삭제함
+func (s *memorySessionStore) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := s.lookup(key)
+	if entry == nil || entry.members == nil {
+		return nil
+	}
+	for _, member := range members {
+		delete(entry.members, member)
+	}
+	if len(entry.members) == 0 {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// SetMembers는 key 집합의 모든 member를 반환하는 메서드
+func (s *memorySessionStore) SetMembers(ctx context.Context, key string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry := s.lookup(key)
+	if entry == nil || entry.members == nil {
+		return []string{}, nil
+	}
+	members := make([]string, 0, len(entry.members))
+	for member := range entry.members {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// lookup은 만료되지 않은 key의 항목을 반환하는 메서드, 만료 된 항목은 삭제함
+// 호출하는 쪽에서 mutex를 잡고 있어야 함
+func (s *memorySessionStore) lookup(key string) *memorySessionEntry {
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+	if s.expired(entry, s.now()) {
+		delete(s.entries, key)
+		return nil
+	}
+	return entry
+}
+
+// expired는 entry가 now 기준으로 만료 되었는지 확인하는 메서드
+func (s *memorySessionStore) expired(entry *memorySessionEntry, now time.Time) bool {
+	return !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt)
+}
+
+// sweepLoop는 만료 된 세션을 주기적으로 삭제하는 메서드
+func (s *memorySessionStore) sweepLoop(ctx context.Context) {
+	ticker := time.NewTicker(memorySessionSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// sweep은 만료 된 세션을 삭제하는 메서드
+func (s *memorySessionStore) sweep() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	for key, entry := range s.entries {
+		if s.expired(entry, now) {
+			delete(s.entries, key)
+		}
+	}
+}
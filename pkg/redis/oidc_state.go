@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"lux-list/internal/model"
+)
+
+const (
+	// state → OIDC 로그인 상태 (JSON)
+	oidcLoginStateKey = "oidc_login_state:"
+	// ID → 2단계 인증 코드를 기다리는 OIDC 로그인 (JSON)
+	oidcPendingLoginKey = "oidc_pending_login:"
+)
+
+// SetOIDCLoginState는 ID 공급자로 이동하기 전의 로그인 상태를 OIDC_LOGIN_STATE_TTL 동안 저장하는 함수
+func SetOIDCLoginState(ctx context.Context, state *model.OIDCLoginState) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return store.Set(ctx, oidcLoginStateKey+state.State, string(value), model.OIDC_LOGIN_STATE_TTL)
+}
+
+// ConsumeOIDCLoginState는 state의 로그인 상태를 가져오고 삭제하는 함수
+// 없거나 만료 되었거나 이미 사용한 state면 ErrSessionNotFound를 반환
+// 같은 state로 동시에 요청하면 하나만 성공함
+func ConsumeOIDCLoginState(ctx context.Context, state string) (*model.OIDCLoginState, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	key := oidcLoginStateKey + state
+	raw, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 빈 값으로 바꾼 요청만 상태를 사용할 수 있음
+	swapped, err := store.CompareAndSwap(ctx, key, raw, "", KEEP_TTL)
+	if err != nil {
+		return nil, err
+	}
+	if !swapped || raw == "" {
+		return nil, ErrSessionNotFound
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	var loginState model.OIDCLoginState
+	if err := json.Unmarshal([]byte(raw), &loginState); err != nil {
+		return nil, err
+	}
+	return &loginState, nil
+}
+
+// SetOIDCPendingLogin은 2단계 인증 코드를 기다리는 OIDC 로그인을 OIDC_TWO_FACTOR_TTL 동안 저장하는 함수
+func SetOIDCPendingLogin(ctx context.Context, pending *model.OIDCPendingLogin) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return store.Set(ctx, oidcPendingLoginKey+pending.ID, string(value), model.OIDC_TWO_FACTOR_TTL)
+}
+
+// GetOIDCPendingLogin은 2단계 인증 코드를 기다리는 OIDC 로그인을 가져오는 함수, 없거나 만료 되었으면 ErrSessionNotFound를 반환
+// 코드가 틀려도 만료될 때까지 다시 시도할 수 있도록 삭제하지 않음
+func GetOIDCPendingLogin(ctx context.Context, id string) (*model.OIDCPendingLogin, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := store.Get(ctx, oidcPendingLoginKey+id)
+	if err != nil {
+		return nil, err
+	}
+	var pending model.OIDCPendingLogin
+	if err := json.Unmarshal([]byte(raw), &pending); err != nil {
+		return nil, err
+	}
+	return &pending, nil
+}
+
+// DeleteOIDCPendingLogin은 2단계 인증을 마친 OIDC 로그인을 삭제하는 함수
+func DeleteOIDCPendingLogin(ctx context.Context, id string) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+	return store.Delete(ctx, oidcPendingLoginKey+id)
+}
@@ -0,0 +1,317 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"lux-list/internal/config"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 요청 기록 키 접두사 (그룹과 IP / 사용자 ID가 뒤에 붙음)
+const rateLimitKey = "rate_limit:"
+
+// RateLimitResult는 요청 제한 확인 결과
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter는 창 안의 가장 오래된 요청이 빠져 한 번 더 요청할 수 있게 될 때까지 남은 시간
+	ResetAfter time.Duration
+	// RetryAfter는 거부 되었을 때 다시 시도할 수 있을 때까지 남은 시간 (허용 되었으면 0)
+	RetryAfter time.Duration
+}
+
+// RateLimiter는 sliding window 방식으로 요청 수를 제한하는 인터페이스
+// 창(rule.Window) 안의 요청 기록을 모두 보관하므로, 창 경계에서 두 배로 허용되는 일이 없음
+type RateLimiter interface {
+	// Allow는 key의 요청 한 번을 기록하고 허용 여부를 반환, 거부 된 요청은 기록하지 않음
+	Allow(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error)
+	// Peek은 기록하지 않고 key가 지금 요청할 수 있는지만 확인
+	Peek(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error)
+	// Reset은 key의 요청 기록을 삭제
+	Reset(ctx context.Context, key string) error
+}
+
+var (
+	// rate_limiter는 요청 제한 저장소 인스턴스
+	rate_limiter RateLimiter
+	// rate_limiter_mutex는 저장소 교체를 위한 잠금
+	rate_limiter_mutex sync.RWMutex
+)
+
+// InitRateLimiter는 kind에 맞는 요청 제한 저장소를 초기화하는 함수 (종류는 SESSION_STORE와 같음)
+func InitRateLimiter(ctx context.Context, kind string) error {
+	var limiter RateLimiter
+	switch kind {
+	case SESSION_STORE_REDIS:
+		client, err := GetAuthRedis(ctx)
+		if err != nil {
+			return err
+		}
+		limiter = NewRedisRateLimiter(client)
+	case SESSION_STORE_MEMORY:
+		limiter = NewMemoryRateLimiter(ctx)
+	default:
+		return fmt.Errorf("unknown RATE_LIMIT_STORE: %s", kind)
+	}
+
+	SetRateLimiter(limiter)
+	return nil
+}
+
+// SetRateLimiter는 요청 제한 저장소를 교체하는 함수
+func SetRateLimiter(limiter RateLimiter) {
+	rate_limiter_mutex.Lock()
+	defer rate_limiter_mutex.Unlock()
+	rate_limiter = limiter
+}
+
+// GetRateLimiter는 요청 제한 저장소를 반환하는 함수
+func GetRateLimiter() (RateLimiter, error) {
+	rate_limiter_mutex.RLock()
+	defer rate_limiter_mutex.RUnlock()
+	if rate_limiter == nil {
+		return nil, errors.New("rate limiter is not initialized")
+	}
+	return rate_limiter, nil
+}
+
+// RateLimitKey는 그룹과 대상(IP, 사용자 ID, 계정 이름 등)으로 요청 기록 키를 만드는 함수
+func RateLimitKey(group string, subject string) string {
+	return rateLimitKey + group + ":" + subject
+}
+
+// redisRateLimiter는 RateLimiter 인터페이스를 Redis sorted set으로 구현하는 구조체
+// 요청 시각(마이크로초)을 score로 저장하며, 여러 인스턴스가 같은 기록을 공유함
+type redisRateLimiter struct {
+	client *AuthRedisClient
+}
+
+// NewRedisRateLimiter는 Redis 요청 제한 저장소를 생성하는 함수
+func NewRedisRateLimiter(client *AuthRedisClient) RateLimiter {
+	return &redisRateLimiter{
+		client: client,
+	}
+}
+
+// slidingWindowScript는 창 밖의 기록을 지우고 요청 수를 확인하는 스크립트
+// ARGV[1]: 현재 시각(마이크로초), ARGV[2]: 창 길이(마이크로초), ARGV[3]: 허용 횟수, ARGV[4]: 기록 여부(1 / 0), ARGV[5]: 기록할 member
+// 반환: {허용 여부, 창 안의 요청 수, 가장 오래된 요청 시각(없으면 0)}
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	allowed = 1
+	if ARGV[4] == "1" then
+		redis.call("ZADD", KEYS[1], now, ARGV[5])
+		redis.call("PEXPIRE", KEYS[1], math.ceil(window / 1000))
+		count = count + 1
+	end
+end
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if oldest[2] then
+	return {allowed, count, tonumber(oldest[2])}
+end
+return {allowed, count, 0}
+`)
+
+// Allow는 key의 요청 한 번을 기록하고 허용 여부를 반환하는 메서드
+func (l *redisRateLimiter) Allow(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	return l.run(ctx, key, rule, true)
+}
+
+// Peek은 기록하지 않고 key의 허용 여부를 반환하는 메서드
+func (l *redisRateLimiter) Peek(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	return l.run(ctx, key, rule, false)
+}
+
+// Reset은 key의 요청 기록을 삭제하는 메서드
+func (l *redisRateLimiter) Reset(ctx context.Context, key string) error {
+	return l.client.Del(ctx, key).Err()
+}
+
+// run은 sliding window 스크립트를 실행하는 메서드
+func (l *redisRateLimiter) run(ctx context.Context, key string, rule config.RateLimitRule, record bool) (*RateLimitResult, error) {
+	now := time.Now()
+	recordFlag := "0"
+	if record {
+		recordFlag = "1"
+	}
+	// 같은 마이크로초에 들어온 요청도 따로 세도록 member는 매번 다르게 만듦
+	member, err := randomMember()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := slidingWindowScript.Run(ctx, l.client, []string{key},
+		now.UnixMicro(), rule.Window.Microseconds(), rule.Limit, recordFlag, member).Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 3 {
+		return nil, errors.New("unexpected rate limit script result")
+	}
+
+	allowed, _ := values[0].(int64)
+	count, _ := values[1].(int64)
+	oldest, _ := values[2].(int64)
+	var oldestAt time.Time
+	if oldest > 0 {
+		oldestAt = time.UnixMicro(oldest)
+	}
+	return newRateLimitResult(rule, allowed == 1, int(count), oldestAt, now), nil
+}
+
+// memoryRateLimiter는 RateLimiter 인터페이스를 프로세스 메모리로 구현하는 구조체
+// 인스턴스끼리 기록을 공유하지 않으므로 단일 인스턴스 배포에서만 사용해야 함
+type memoryRateLimiter struct {
+	mutex   sync.Mutex
+	entries map[string]*memoryRateLimitEntry
+	now     func() time.Time
+}
+
+// memoryRateLimitEntry는 key의 요청 시각 목록 (오래된 순)
+type memoryRateLimitEntry struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+// NewMemoryRateLimiter는 메모리 요청 제한 저장소를 생성하는 함수
+// ctx가 취소될 때까지 창이 지난 기록을 주기적으로 정리함
+func NewMemoryRateLimiter(ctx context.Context) RateLimiter {
+	limiter := &memoryRateLimiter{
+		entries: make(map[string]*memoryRateLimitEntry),
+		now:     time.Now,
+	}
+	go limiter.sweepLoop(ctx)
+	return limiter
+}
+
+// Allow는 key의 요청 한 번을 기록하고 허용 여부를 반환하는 메서드
+func (l *memoryRateLimiter) Allow(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	return l.run(key, rule, true), nil
+}
+
+// Peek은 기록하지 않고 key의 허용 여부를 반환하는 메서드
+func (l *memoryRateLimiter) Peek(ctx context.Context, key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	return l.run(key, rule, false), nil
+}
+
+// Reset은 key의 요청 기록을 삭제하는 메서드
+func (l *memoryRateLimiter) Reset(ctx context.Context, key string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.entries, key)
+	return nil
+}
+
+// run은 창 밖의 기록을 지우고 요청 수를 확인하는 메서드, record면 허용 된 요청을 기록함
+func (l *memoryRateLimiter) run(key string, rule config.RateLimitRule, record bool) *RateLimitResult {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &memoryRateLimitEntry{}
+	}
+	entry.window = rule.Window
+	entry.prune(now)
+
+	allowed := len(entry.hits) < rule.Limit
+	if allowed && record {
+		entry.hits = append(entry.hits, now)
+	}
+	if len(entry.hits) > 0 {
+		l.entries[key] = entry
+	} else {
+		delete(l.entries, key)
+	}
+
+	var oldestAt time.Time
+	if len(entry.hits) > 0 {
+		oldestAt = entry.hits[0]
+	}
+	return newRateLimitResult(rule, allowed, len(entry.hits), oldestAt, now)
+}
+
+// prune은 now 기준으로 창이 지난 기록을 지우는 메서드
+func (e *memoryRateLimitEntry) prune(now time.Time) {
+	cutoff := now.Add(-e.window)
+	index := 0
+	for index < len(e.hits) && !e.hits[index].After(cutoff) {
+		index++
+	}
+	e.hits = e.hits[index:]
+}
+
+// sweepLoop는 창이 지난 기록을 주기적으로 삭제하는 메서드
+func (l *memoryRateLimiter) sweepLoop(ctx context.Context) {
+	ticker := time.NewTicker(memorySessionSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.sweep()
+		}
+	}
+}
+
+// sweep은 창이 지난 기록을 삭제하는 메서드
+func (l *memoryRateLimiter) sweep() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	for key, entry := range l.entries {
+		entry.prune(now)
+		if len(entry.hits) == 0 {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// newRateLimitResult는 창 안의 요청 수와 가장 오래된 요청 시각으로 결과를 만드는 함수
+func newRateLimitResult(rule config.RateLimitRule, allowed bool, count int, oldestAt time.Time, now time.Time) *RateLimitResult {
+	result := &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: rule.Limit - count,
+	}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+	if !oldestAt.IsZero() {
+		result.ResetAfter = oldestAt.Add(rule.Window).Sub(now)
+		if result.ResetAfter < 0 {
+			result.ResetAfter = 0
+		}
+	}
+	if !allowed {
+		result.RetryAfter = result.ResetAfter
+	}
+	return result
+}
+
+// randomMember는 sorted set에 기록할 고유한 member를 만드는 함수
+func randomMember() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
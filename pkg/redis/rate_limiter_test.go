@@ -0,0 +1,155 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lux-list/internal/config"
+)
+
+// newTestRateLimiter는 시계를 바꿀 수 있는 메모리 요청 제한 저장소를 만드는 함수
+// 반환하는 시각 포인터를 바꾸면 저장소의 현재 시각이 바뀜
+func newTestRateLimiter() (*memoryRateLimiter, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &memoryRateLimiter{
+		entries: make(map[string]*memoryRateLimitEntry),
+		now:     func() time.Time { return now },
+	}
+	return limiter, &now
+}
+
+// allow는 Allow 결과를 반환하고 오류가 있으면 테스트를 중단하는 함수
+func allow(t *testing.T, limiter RateLimiter, key string, rule config.RateLimitRule) *RateLimitResult {
+	t.Helper()
+	result, err := limiter.Allow(context.Background(), key, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestMemoryRateLimiterSlidingWindow(t *testing.T) {
+	limiter, now := newTestRateLimiter()
+	rule := config.RateLimitRule{Limit: 2, Window: time.Minute}
+	start := *now
+
+	if result := allow(t, limiter, "key", rule); !result.Allowed || result.Remaining != 1 || result.ResetAfter != time.Minute {
+		t.Fatalf("first request = %+v, want allowed with 1 remaining", result)
+	}
+	*now = start.Add(30 * time.Second)
+	if result := allow(t, limiter, "key", rule); !result.Allowed || result.Remaining != 0 || result.ResetAfter != 30*time.Second {
+		t.Fatalf("second request = %+v, want allowed with 0 remaining", result)
+	}
+
+	// 고정 창과 달리 창 경계를 지나도 창 안의 두 요청이 남아 있으면 거부함
+	*now = start.Add(time.Minute - time.Millisecond)
+	result := allow(t, limiter, "key", rule)
+	if result.Allowed || result.Remaining != 0 || result.RetryAfter != time.Millisecond {
+		t.Fatalf("request before the first one leaves the window = %+v, want denied for 1ms", result)
+	}
+
+	// 첫 요청이 창을 벗어나면 한 번 더 허용함
+	*now = start.Add(time.Minute)
+	if result := allow(t, limiter, "key", rule); !result.Allowed || result.Remaining != 0 || result.ResetAfter != 30*time.Second {
+		t.Fatalf("request after the first one leaves the window = %+v, want allowed", result)
+	}
+	if result := allow(t, limiter, "key", rule); result.Allowed || result.RetryAfter != 30*time.Second {
+		t.Fatalf("request over the limit = %+v, want denied for 30s", result)
+	}
+
+	// key마다 따로 셈
+	if result := allow(t, limiter, "other", rule); !result.Allowed || result.Remaining != 1 {
+		t.Fatalf("request of another key = %+v, want allowed", result)
+	}
+}
+
+func TestMemoryRateLimiterDoesNotCountDenied(t *testing.T) {
+	limiter, now := newTestRateLimiter()
+	rule := config.RateLimitRule{Limit: 1, Window: time.Minute}
+	start := *now
+
+	allow(t, limiter, "key", rule)
+	// 거부 된 요청을 기록하면 창이 계속 밀려 제한이 풀리지 않음
+	for i := 1; i < 10; i++ {
+		*now = start.Add(time.Duration(i) * 5 * time.Second)
+		if result := allow(t, limiter, "key", rule); result.Allowed {
+			t.Fatalf("request %d = %+v, want denied", i, result)
+		}
+	}
+	*now = start.Add(time.Minute)
+	if result := allow(t, limiter, "key", rule); !result.Allowed {
+		t.Fatalf("request after the window = %+v, want allowed", result)
+	}
+	if hits := len(limiter.entries["key"].hits); hits != 1 {
+		t.Fatalf("expected 1 recorded request, got %d", hits)
+	}
+}
+
+func TestMemoryRateLimiterPeekAndReset(t *testing.T) {
+	ctx := context.Background()
+	limiter, now := newTestRateLimiter()
+	rule := config.RateLimitRule{Limit: 2, Window: time.Minute}
+
+	// Peek은 기록하지 않음
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Peek(ctx, "key", rule)
+		if err != nil || !result.Allowed || result.Remaining != 2 {
+			t.Fatalf("Peek = (%+v, %v), want allowed with 2 remaining", result, err)
+		}
+	}
+	allow(t, limiter, "key", rule)
+	allow(t, limiter, "key", rule)
+	if result, err := limiter.Peek(ctx, "key", rule); err != nil || result.Allowed || result.RetryAfter != time.Minute {
+		t.Fatalf("Peek over the limit = (%+v, %v), want denied", result, err)
+	}
+
+	if err := limiter.Reset(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if result := allow(t, limiter, "key", rule); !result.Allowed || result.Remaining != 1 {
+		t.Fatalf("request after Reset = %+v, want allowed with 1 remaining", result)
+	}
+
+	// sweep은 창이 지난 기록만 삭제함
+	allow(t, limiter, "later", rule)
+	*now = now.Add(time.Minute)
+	limiter.sweep()
+	if _, ok := limiter.entries["key"]; ok {
+		t.Fatal("expected expired entry to be swept")
+	}
+	*now = now.Add(-time.Second)
+	allow(t, limiter, "fresh", rule)
+	*now = now.Add(time.Second)
+	limiter.sweep()
+	if _, ok := limiter.entries["fresh"]; !ok {
+		t.Fatal("expected entry inside the window to be kept")
+	}
+}
+
+func TestNewRateLimitResult(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule := config.RateLimitRule{Limit: 3, Window: time.Minute}
+
+	cases := []struct {
+		name     string
+		allowed  bool
+		count    int
+		oldestAt time.Time
+		want     RateLimitResult
+	}{
+		{"empty window", true, 0, time.Time{}, RateLimitResult{Allowed: true, Limit: 3, Remaining: 3}},
+		{"allowed", true, 1, now.Add(-10 * time.Second), RateLimitResult{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: 50 * time.Second}},
+		{"denied", false, 3, now.Add(-45 * time.Second), RateLimitResult{Allowed: false, Limit: 3, Remaining: 0, ResetAfter: 15 * time.Second, RetryAfter: 15 * time.Second}},
+		{"count over a lowered limit", false, 5, now.Add(-time.Second), RateLimitResult{Allowed: false, Limit: 3, Remaining: 0, ResetAfter: 59 * time.Second, RetryAfter: 59 * time.Second}},
+		{"oldest outside the window", true, 1, now.Add(-2 * time.Minute), RateLimitResult{Allowed: true, Limit: 3, Remaining: 2}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := newRateLimitResult(rule, c.allowed, c.count, c.oldestAt, now); *got != c.want {
+				t.Fatalf("newRateLimitResult = %+v, want %+v", *got, c.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,23 @@
 package redis
 
-import "context"
+import (
+	"context"
 
-// InitRedis는 Redis 클라이언트를 초기화하는 함수
+	"lux-list/internal/config"
+)
+
+// InitRedis는 인증 세션 저장소와 요청 제한 저장소를 초기화하는 함수
+// SESSION_STORE와 RATE_LIMIT_STORE가 모두 memory면 Redis에 연결하지 않음
 func InitRedis(ctx context.Context) error {
-	if _, err := InitAuthRedis(ctx); err != nil {
+	cfg := config.GetConfig()
+	if err := InitAuthSessionStore(ctx, cfg.Redis.SessionStore); err != nil {
 		return err
 	}
+	if cfg.RateLimit.Enabled {
+		if err := InitRateLimiter(ctx, cfg.RateLimit.Store); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,348 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"lux-list/internal/config"
+	"lux-list/internal/model"
+	"lux-list/pkg/utils"
+)
+
+// 인증 세션 저장소 종류 (SESSION_STORE)
+const (
+	SESSION_STORE_REDIS  = "redis"
+	SESSION_STORE_MEMORY = "memory" // 프로세스 메모리, 단일 인스턴스 배포와 테스트용
+)
+
+// KEEP_TTL은 Set에서 기존 만료 시간을 유지하도록 지정하는 TTL 값
+const KEEP_TTL time.Duration = -1
+
+// sessionNow는 refresh token 유예 시간을 확인할 때 사용하는 현재 시각 (테스트에서 교체)
+var sessionNow = time.Now
+
+const (
+	// 세션 ID → 세션 정보 (JSON)
+	authSessionKey = "auth_session:"
+	// 사용자 ID → 세션 ID 집합
+	authUserSessionsKey = "auth_user_sessions:"
+	// last_seen_at을 갱신하는 최소 간격 (요청마다 쓰기가 발생하지 않도록)
+	authSessionTouchInterval = time.Minute
+)
+
+var (
+	// ErrSessionNotFound는 세션이 없거나 만료 되었을 때 반환되는 에러
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrRefreshTokenInvalid는 세션의 refresh token이 아닐 때 반환되는 에러
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid")
+	// ErrRefreshTokenSuperseded는 직전 refresh token이 유예 시간 안에 다시 제시되었을 때 반환되는 에러
+	// 다른 요청이 방금 토큰을 교체한 것이므로 세션은 유지되며, 새 토큰은 먼저 갱신한 요청의 쿠키에 있음
+	ErrRefreshTokenSuperseded = errors.New("refresh token was just rotated by another request")
+	// ErrRefreshTokenConflict는 같은 세션을 동시에 갱신하다 다른 요청이 먼저 저장했을 때 반환되는 에러
+	ErrRefreshTokenConflict = errors.New("session was refreshed by another request")
+	// ErrRefreshTokenReused는 이미 사용한 refresh token이 다시 제시되었을 때 반환되는 에러 (세션, 즉 토큰 family 전체가 해제됨)
+	ErrRefreshTokenReused = errors.New("refresh token was already used, session revoked")
+)
+
+// AuthSessionStore는 인증 세션을 TTL과 함께 저장하는 저장소 인터페이스
+type AuthSessionStore interface {
+	// Set은 ttl이 0이면 만료 없이, KEEP_TTL이면 기존 만료 시간을 유지하여 저장
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Get(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
+	// CompareAndSwap은 key의 값이 old일 때만 value로 바꾸고, 바꿨는지 여부를 반환 (ttl 규칙은 Set과 같음)
+	CompareAndSwap(ctx context.Context, key string, old string, value string, ttl time.Duration) (bool, error)
+
+	// AddToSet은 key 집합에 member를 추가하고 집합의 만료 시간을 ttl로 갱신
+	AddToSet(ctx context.Context, key string, member string, ttl time.Duration) error
+	RemoveFromSet(ctx context.Context, key string, members ...string) error
+	SetMembers(ctx context.Context, key string) ([]string, error)
+}
+
+var (
+	// auth_session_store는 인증 세션 저장소 인스턴스
+	auth_session_store AuthSessionStore
+	// auth_session_store_mutex는 저장소 교체를 위한 잠금
+	auth_session_store_mutex sync.RWMutex
+)
+
+// InitAuthSessionStore는 kind에 맞는 인증 세션 저장소를 초기화하는 함수
+func InitAuthSessionStore(ctx context.Context, kind string) error {
+	var store AuthSessionStore
+	switch kind {
+	case SESSION_STORE_REDIS:
+		client, err := InitAuthRedis(ctx)
+		if err != nil {
+			return err
+		}
+		store = NewRedisSessionStore(client)
+	case SESSION_STORE_MEMORY:
+		store = NewMemorySessionStore(ctx)
+	default:
+		return fmt.Errorf("unknown SESSION_STORE: %s", kind)
+	}
+
+	SetAuthSessionStore(store)
+	return nil
+}
+
+// SetAuthSessionStore는 인증 세션 저장소를 교체하는 함수
+func SetAuthSessionStore(store AuthSessionStore) {
+	auth_session_store_mutex.Lock()
+	defer auth_session_store_mutex.Unlock()
+	auth_session_store = store
+}
+
+// GetAuthSessionStore는 인증 세션 저장소를 반환하는 함수
+func GetAuthSessionStore() (AuthSessionStore, error) {
+	auth_session_store_mutex.RLock()
+	defer auth_session_store_mutex.RUnlock()
+	if auth_session_store == nil {
+		return nil, errors.New("session store is not initialized")
+	}
+	return auth_session_store, nil
+}
+
+// authSessionTTL은 인증 세션 TTL을 반환하는 함수, refresh token의 유효 시간과 같음
+func authSessionTTL() time.Duration {
+	return config.GetConfig().Auth.RefreshTokenTTL
+}
+
+// refreshTokenGracePeriod는 직전 refresh token을 허용하는 유예 시간을 반환하는 함수
+func refreshTokenGracePeriod() time.Duration {
+	return config.GetConfig().Auth.RefreshTokenGracePeriod
+}
+
+// sessionTTL은 session을 저장할 TTL을 반환하는 함수, 고정 만료 시각이 더 빠르면 그 시각까지만 저장
+func sessionTTL(session *model.AuthSession) time.Duration {
+	ttl := authSessionTTL()
+	if session.ExpiresAt != nil {
+		if remaining := time.Until(*session.ExpiresAt); remaining < ttl {
+			ttl = max(remaining, time.Second)
+		}
+	}
+	return ttl
+}
+
+// SetAuthSession은 인증 세션을 저장하고 사용자의 세션 목록에 추가하는 함수
+func SetAuthSession(ctx context.Context, session *model.AuthSession) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := store.Set(ctx, authSessionKey+session.ID, string(value), sessionTTL(session)); err != nil {
+		return err
+	}
+	return store.AddToSet(ctx, userSessionsKey(session.UserID), session.ID, authSessionTTL())
+}
+
+// GetAuthSession은 세션 ID로 인증 세션을 가져오는 함수, 세션이 없으면 ErrSessionNotFound를 반환
+func GetAuthSession(ctx context.Context, sessionID string) (*model.AuthSession, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	session, _, err := getAuthSession(ctx, store, sessionID)
+	return session, err
+}
+
+// TouchAuthSession은 세션의 마지막 사용 시각과 IP를 갱신하는 함수
+// 마지막 갱신 후 authSessionTouchInterval이 지나지 않았고 IP가 같으면 저장하지 않으며, 만료 시간은 바꾸지 않음
+// 그 사이 다른 요청이 세션을 바꿨다면(토큰 갱신 등) 갱신을 건너뜀
+func TouchAuthSession(ctx context.Context, sessionID string, ip string) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+
+	session, raw, err := getAuthSession(ctx, store, sessionID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(session.LastSeenAt) < authSessionTouchInterval && session.IP == ip {
+		return nil
+	}
+
+	session.LastSeenAt = now
+	session.IP = ip
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	_, err = store.CompareAndSwap(ctx, authSessionKey+sessionID, raw, string(value), KEEP_TTL)
+	return err
+}
+
+// RotateAuthSession은 refresh token으로 세션을 갱신하는 함수
+// refreshTokenHash가 세션의 현재 refresh token이면 update로 세션을 바꾸고 만료 시간을 다시 늘림
+// 직전 refresh token이 REFRESH_TOKEN_GRACE_PERIOD 안에 다시 오면 세션을 유지하고 ErrRefreshTokenSuperseded를,
+// 그보다 이전에 사용한 refresh token이면 세션을 해제하고 ErrRefreshTokenReused를, 그 밖에 일치하지 않으면 ErrRefreshTokenInvalid를 반환
+// 세션 하나가 토큰 family 전체이므로(model.AuthSession 참고) 세션을 해제하면 family의 모든 토큰이 무효가 됨
+// 같은 토큰으로 동시에 요청하면 하나만 성공하고 나머지는 ErrRefreshTokenConflict 또는 ErrRefreshTokenSuperseded를 반환
+func RotateAuthSession(ctx context.Context, sessionID string, refreshTokenHash string, update func(session *model.AuthSession) error) (*model.AuthSession, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	session, raw, err := getAuthSession(ctx, store, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.RefreshTokenHash != refreshTokenHash {
+		if session.IsPreviousRefreshToken(refreshTokenHash, sessionNow().UTC(), refreshTokenGracePeriod()) {
+			return session, ErrRefreshTokenSuperseded
+		}
+		if session.IsUsedRefreshToken(refreshTokenHash) {
+			if err := DeleteAuthSession(ctx, session.UserID, session.ID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	if err := update(session); err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+
+	swapped, err := store.CompareAndSwap(ctx, authSessionKey+sessionID, raw, string(value), sessionTTL(session))
+	if err != nil {
+		return nil, err
+	}
+	if !swapped {
+		return nil, ErrRefreshTokenConflict
+	}
+	if err := store.AddToSet(ctx, userSessionsKey(session.UserID), session.ID, authSessionTTL()); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListAuthSessions는 사용자의 유효한 인증 세션 목록을 반환하는 함수
+// 만료 되어 사라진 세션은 목록에서 정리함
+func ListAuthSessions(ctx context.Context, userID int) ([]*model.AuthSession, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionIDs, err := store.SetMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*model.AuthSession, 0, len(sessionIDs))
+	var expired []string
+	for _, sessionID := range sessionIDs {
+		session, err := GetAuthSession(ctx, sessionID)
+		if errors.Is(err, ErrSessionNotFound) {
+			expired = append(expired, sessionID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		if err := store.RemoveFromSet(ctx, userSessionsKey(userID), expired...); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// DeleteAuthSession은 사용자의 인증 세션 하나를 삭제하는 함수
+func DeleteAuthSession(ctx context.Context, userID int, sessionID string) error {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(ctx, authSessionKey+sessionID); err != nil {
+		return err
+	}
+	return store.RemoveFromSet(ctx, userSessionsKey(userID), sessionID)
+}
+
+// DeleteAuthSessions는 exceptSessionID를 제외한 사용자의 모든 인증 세션을 삭제하는 함수
+// exceptSessionID가 비어있으면 모든 세션을 삭제하며, 삭제한 세션 수를 반환
+func DeleteAuthSessions(ctx context.Context, userID int, exceptSessionID string) (int, error) {
+	store, err := GetAuthSessionStore()
+	if err != nil {
+		return 0, err
+	}
+
+	sessionIDs, err := store.SetMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		keys    []string
+		members []string
+	)
+	for _, sessionID := range sessionIDs {
+		if sessionID == exceptSessionID {
+			continue
+		}
+		keys = append(keys, authSessionKey+sessionID)
+		members = append(members, sessionID)
+	}
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	if err := store.Delete(ctx, keys...); err != nil {
+		return 0, err
+	}
+	if err := store.RemoveFromSet(ctx, userSessionsKey(userID), members...); err != nil {
+		return 0, err
+	}
+	return len(members), nil
+}
+
+// getAuthSession은 인증 세션과 저장 된 원본 값을 가져오는 함수 (CompareAndSwap 비교용)
+// 고정 만료 시각(ExpiresAt)이 지난 세션은 삭제하고 ErrSessionNotFound를 반환
+func getAuthSession(ctx context.Context, store AuthSessionStore, sessionID string) (*model.AuthSession, string, error) {
+	raw, err := store.Get(ctx, authSessionKey+sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var session model.AuthSession
+	if err := json.Unmarshal([]byte(raw), &session); err != nil {
+		return nil, "", err
+	}
+	if session.IsExpired(time.Now().UTC()) {
+		if err := DeleteAuthSession(ctx, session.UserID, session.ID); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrSessionNotFound
+	}
+	return &session, raw, nil
+}
+
+// userSessionsKey는 사용자의 세션 ID 집합 키를 반환하는 함수
+func userSessionsKey(userID int) string {
+	return authUserSessionsKey + utils.InterfaceToString(userID)
+}
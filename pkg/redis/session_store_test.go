@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lux-list/internal/model"
+)
+
+// TestMain은 config.GetConfig가 읽을 .env를 임시 디렉터리에 만들고 그 디렉터리에서 테스트를 실행하는 함수
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "lux-list-redis-test")
+	if err != nil {
+		panic(err)
+	}
+	env := "REFRESH_TOKEN_TTL=1h\nREFRESH_TOKEN_GRACE_PERIOD=10s\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newTestSession은 메모리 저장소를 새로 만들고 refreshTokenHash를 현재 토큰으로 가진 세션을 저장하는 함수
+// sessionNow는 반환하는 시계 포인터로 바꿀 수 있음
+func newTestSession(t *testing.T, refreshTokenHash string) (*model.AuthSession, *time.Time) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	SetAuthSessionStore(NewMemorySessionStore(ctx))
+
+	now := time.Now().UTC()
+	sessionNow = func() time.Time { return now }
+	t.Cleanup(func() { sessionNow = time.Now })
+
+	session := &model.AuthSession{ID: "session-" + t.Name(), UserID: 1, CreatedAt: now, LastSeenAt: now, RefreshTokenHash: refreshTokenHash}
+	if err := SetAuthSession(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	return session, &now
+}
+
+// rotateTo는 현재 토큰 hash로 세션을 갱신하여 next를 현재 토큰으로 만드는 함수
+func rotateTo(now *time.Time, next string) func(session *model.AuthSession) error {
+	return func(session *model.AuthSession) error {
+		session.RotateRefreshToken(next, *now)
+		return nil
+	}
+}
+
+func TestRotateAuthSession(t *testing.T) {
+	ctx := context.Background()
+	session, now := newTestSession(t, "hash-1")
+
+	rotated, err := RotateAuthSession(ctx, session.ID, "hash-1", rotateTo(now, "hash-2"))
+	if err != nil || rotated.RefreshTokenHash != "hash-2" {
+		t.Fatalf("RotateAuthSession = (%+v, %v), want hash-2", rotated, err)
+	}
+	stored, err := GetAuthSession(ctx, session.ID)
+	if err != nil || stored.RefreshTokenHash != "hash-2" || !stored.IsUsedRefreshToken("hash-1") {
+		t.Fatalf("expected stored session to use hash-2 and remember hash-1, got %+v (%v)", stored, err)
+	}
+
+	// 토큰과 관계없는 값은 세션을 해제하지 않음
+	if _, err := RotateAuthSession(ctx, session.ID, "unknown", rotateTo(now, "hash-x")); !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Fatalf("expected ErrRefreshTokenInvalid, got %v", err)
+	}
+	if _, err := RotateAuthSession(ctx, "missing", "hash-2", rotateTo(now, "hash-x")); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := GetAuthSession(ctx, session.ID); err != nil {
+		t.Fatalf("session must survive invalid tokens: %v", err)
+	}
+}
+
+func TestRotateAuthSessionReuse(t *testing.T) {
+	cases := []struct {
+		name    string
+		token   string
+		elapsed time.Duration
+		err     error
+		revoked bool
+	}{
+		{"previous token within grace", "hash-2", time.Second, ErrRefreshTokenSuperseded, false},
+		{"previous token after grace", "hash-2", 11 * time.Second, ErrRefreshTokenReused, true},
+		{"older token within grace", "hash-1", time.Second, ErrRefreshTokenReused, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			session, now := newTestSession(t, "hash-1")
+			for _, next := range []string{"hash-2", "hash-3"} {
+				if _, err := RotateAuthSession(ctx, session.ID, session.RefreshTokenHash, rotateTo(now, next)); err != nil {
+					t.Fatal(err)
+				}
+				session.RefreshTokenHash = next
+			}
+
+			*now = now.Add(c.elapsed)
+			current, err := RotateAuthSession(ctx, session.ID, c.token, rotateTo(now, "hash-4"))
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected %v, got %v", c.err, err)
+			}
+
+			stored, getErr := GetAuthSession(ctx, session.ID)
+			if c.revoked {
+				if !errors.Is(getErr, ErrSessionNotFound) {
+					t.Fatalf("expected session to be revoked, got %+v (%v)", stored, getErr)
+				}
+				return
+			}
+			if getErr != nil || stored.RefreshTokenHash != "hash-3" || current.RefreshTokenHash != "hash-3" {
+				t.Fatalf("expected session to keep hash-3, got %+v (%v)", stored, getErr)
+			}
+		})
+	}
+}
+
+// TestRotateAuthSessionRace는 두 탭이 같은 토큰으로 동시에 갱신하는 경우를 확인하는 테스트
+// 첫 번째 요청의 update 안에서 두 번째 요청이 먼저 저장하도록 하여 경쟁 상태를 재현함
+func TestRotateAuthSessionRace(t *testing.T) {
+	ctx := context.Background()
+	session, now := newTestSession(t, "hash-1")
+
+	var innerErr error
+	_, err := RotateAuthSession(ctx, session.ID, "hash-1", func(authSession *model.AuthSession) error {
+		_, innerErr = RotateAuthSession(ctx, session.ID, "hash-1", rotateTo(now, "hash-winner"))
+		authSession.RotateRefreshToken("hash-loser", *now)
+		return nil
+	})
+	if innerErr != nil {
+		t.Fatalf("first writer must succeed, got %v", innerErr)
+	}
+	if !errors.Is(err, ErrRefreshTokenConflict) {
+		t.Fatalf("expected ErrRefreshTokenConflict, got %v", err)
+	}
+
+	stored, err := GetAuthSession(ctx, session.ID)
+	if err != nil || stored.RefreshTokenHash != "hash-winner" {
+		t.Fatalf("expected winner's token to be kept, got %+v (%v)", stored, err)
+	}
+
+	// 늦게 도착한 같은 토큰의 요청은 세션을 해제하지 않음
+	if _, err := RotateAuthSession(ctx, session.ID, "hash-1", rotateTo(now, "hash-late")); !errors.Is(err, ErrRefreshTokenSuperseded) {
+		t.Fatalf("expected ErrRefreshTokenSuperseded, got %v", err)
+	}
+	if stored, err := GetAuthSession(ctx, session.ID); err != nil || stored.RefreshTokenHash != "hash-winner" {
+		t.Fatalf("expected session to survive, got %+v (%v)", stored, err)
+	}
+}
@@ -3,4 +3,11 @@ package types
 const (
 	CONTEXT_USERID       = "userID"
 	CONTEXT_ACCESS_TOKEN = "access_token"
+	CONTEXT_SESSION_ID   = "session_id"
+	CONTEXT_TOKEN_ID     = "token_id" // 개인 액세스 토큰으로 인증한 경우
+	// 관리자가 대리 로그인한 세션이면 관리자의 사용자 ID
+	CONTEXT_IMPERSONATOR_ID = "impersonator_id"
+	// X-Workspace-ID 헤더로 고른 작업 공간 (없으면 개인 작업 공간)과 그 안에서의 역할
+	CONTEXT_WORKSPACE_ID   = "workspace_id"
+	CONTEXT_WORKSPACE_ROLE = "workspace_role"
 )
@@ -1,6 +1,14 @@
 package types
 
 const (
-	SESSION_USERID       = "userID"
-	SESSION_ACCESS_TOKEN = "access_token"
+	SESSION_USERID        = "userID"
+	SESSION_ACCESS_TOKEN  = "access_token"
+	SESSION_ID            = "session_id"
+	SESSION_REFRESH_TOKEN = "refresh_token"
+	SESSION_OIDC_STATE    = "oidc_state"   // OIDC 로그인을 시작한 브라우저 확인용
+	SESSION_OIDC_PENDING  = "oidc_pending" // 2단계 인증 코드를 기다리는 OIDC 로그인 ID
+	SESSION_CSRF_TOKEN    = "csrf_token"   // 상태를 바꾸는 요청의 X-CSRF-Token 헤더와 비교
 )
+
+// SESSION_COOKIE_NAME은 쿠키 세션의 쿠키 이름
+const SESSION_COOKIE_NAME = "ss-token"
@@ -21,3 +21,33 @@ func GetUserIDFromContext(ctx *gin.Context) (int, error) {
 
 	return userIDInt, nil
 }
+
+// Context에서 현재 요청의 인증 세션 ID를 가져오는 함수
+func GetSessionIDFromContext(ctx *gin.Context) (string, error) {
+	sessionID, exists := ctx.Get("session_id")
+	if !exists {
+		return "", errors.New("session_id not found in context")
+	}
+
+	sessionIDString, ok := sessionID.(string)
+	if !ok {
+		return "", errors.New("session_id is not of type string")
+	}
+
+	return sessionIDString, nil
+}
+
+// Context에서 현재 요청의 작업 공간 ID를 가져오는 함수
+func GetWorkspaceIDFromContext(ctx *gin.Context) (int, error) {
+	workspaceID, exists := ctx.Get("workspace_id")
+	if !exists {
+		return 0, errors.New("workspace_id not found in context")
+	}
+
+	workspaceIDInt, ok := workspaceID.(int)
+	if !ok {
+		return 0, errors.New("workspace_id is not of type int")
+	}
+
+	return workspaceIDInt, nil
+}
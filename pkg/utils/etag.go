@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lux-list/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionETag는 리소스 버전으로 strong ETag를 생성하는 함수
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// WeakETag는 응답 본문의 해시로 weak ETag를 생성하는 함수 (목록 조회용)
+func WeakETag(body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// CheckIfNoneMatch는 ETag 헤더를 설정하고, If-None-Match와 일치하면 304를 응답하는 함수
+// true를 반환하면 응답이 이미 작성된 것이므로 핸들러는 바로 반환해야 함
+func CheckIfNoneMatch(ctx *gin.Context, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	ctx.Header("ETag", etag)
+
+	header := ctx.GetHeader("If-None-Match")
+	if header == "" || !matchETag(header, etag, true) {
+		return false
+	}
+
+	ctx.Status(http.StatusNotModified)
+	return true
+}
+
+// CheckIfMatch는 If-Match 헤더를 현재 ETag와 비교하는 함수
+// false를 반환하면 412 또는 428 응답이 이미 작성된 것이므로 핸들러는 바로 반환해야 함
+func CheckIfMatch(ctx *gin.Context, etag string) bool {
+	header := ctx.GetHeader("If-Match")
+	if header == "" {
+		if config.GetConfig().Server.RequireIfMatch {
+			ctx.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+			return false
+		}
+		return true
+	}
+
+	if !matchETag(header, etag, false) {
+		ctx.Header("ETag", etag)
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": "resource was modified by another request"})
+		return false
+	}
+	return true
+}
+
+// matchETag는 If-Match / If-None-Match 헤더 값에 etag가 포함되어 있는지 확인하는 함수
+// weak가 true면 W/ 접두사를 무시하고 비교 (If-None-Match), false면 strong 비교 (If-Match)
+func matchETag(header string, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+			continue
+		}
+		if !strings.HasPrefix(candidate, "W/") && candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,19 @@
 package utils
 
 import (
+	"lux-list/pkg/types"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
-// 세션을 지워주는 함수
+// 세션을 지워주는 함수, 로그아웃 뒤에도 다시 로그인할 수 있도록 CSRF 토큰은 남겨둠
 func ClearSession(ctx *gin.Context) {
 	session := sessions.Default(ctx)
+	csrfToken := session.Get(types.SESSION_CSRF_TOKEN)
 	session.Clear()
+	if csrfToken != nil {
+		session.Set(types.SESSION_CSRF_TOKEN, csrfToken)
+	}
 	_ = session.Save()
 }